@@ -0,0 +1,70 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/vitessio/vitess-releaser/go/releaser"
+	"github.com/vitessio/vitess-releaser/go/releaser/git"
+	"github.com/vitessio/vitess-releaser/go/releaser/post_release"
+)
+
+var cleanupCmd = &cobra.Command{
+	Use:   "cleanup",
+	Short: "Delete the branches generated by vitess-releaser once their Pull Requests are merged or closed",
+	Run: func(cmd *cobra.Command, args []string) {
+		state := releaser.UnwrapState(cmd.Context())
+		git.CorrectRepo(state.VitessRelease.Repo)
+		state.LoadIssue()
+
+		branches := post_release.FindBranchesToCleanup(state)
+		if len(branches) == 0 {
+			fmt.Println("No branch to cleanup.")
+			return
+		}
+
+		fmt.Println("The following branches will be deleted:")
+
+		for _, b := range branches {
+			fmt.Printf("\t%s\n", b)
+		}
+
+		fmt.Print("Continue? [y/N] ")
+
+		answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+		if strings.ToLower(strings.TrimSpace(answer)) != "y" {
+			return
+		}
+
+		pl, fn := post_release.CleanupBranches(state, branches)
+		fn()
+
+		for _, step := range pl.GetStepInProgress() {
+			fmt.Println(step)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(cleanupCmd)
+}
@@ -42,6 +42,17 @@ const VERSION = "v1.0.5"
 var (
 	releaseVersion     string
 	vtopReleaseVersion string
+	project            string
+	protectionSource   string
+	sign               string
+	verify             []string
+	releaseAssets      []string
+	reviewers          []string
+	pushRemote         string
+	vtopPushRemote     string
+	gitCacheDir        string
+	verbose            bool
+	autoMerge          bool
 	releaseDate        string
 	rcIncrement        int
 	live               = true
@@ -57,14 +68,19 @@ var (
 			}
 			ctx := cmd.Context()
 			state := releaser.UnwrapState(ctx)
-			git.CorrectCleanRepo(state.VitessRelease.Repo)
+			git.CorrectRepo(state.VitessRelease.Repo)
 
 			// TODO: The assumption that the Release Manager won't be
 			// modifying the release issue while using vitess-releaser
 			// is made here, perhaps there is a better way of doing it
 			state.LoadIssue()
+			state.SyncIssueFromProjectBoard()
 
 			interactive.MainScreen(ctx, state)
+
+			if state.Verbose {
+				fmt.Println(git.FetchStats())
+			}
 		},
 	}
 )
@@ -76,6 +92,17 @@ func init() {
 	rootCmd.PersistentFlags().IntVarP(&rcIncrement, flags.RCIncrement, "", 0, "Define the release as an RC release, value is used to determine the number of the RC.")
 	rootCmd.PersistentFlags().StringVarP(&releaseVersion, flags.MajorRelease, "r", "", "Number of the major release on which we want to create a new release.")
 	rootCmd.PersistentFlags().StringVarP(&vtopReleaseVersion, flags.VtOpRelease, "", "", "Number of the major and minor release on which we want to create a new release, i.e. '2.11', leave empty for no vtop release.")
+	rootCmd.PersistentFlags().StringVarP(&project, flags.Project, "", "", "GitHub Projects (v2) board on which the release steps are mirrored, with the format owner/number, i.e. 'vitessio/12'. Leave empty to disable.")
+	rootCmd.PersistentFlags().StringVarP(&protectionSource, flags.ProtectionSource, "", "main", "Branch whose protection rules, and the rulesets targeting it, are copied to the new release branch during the code freeze.")
+	rootCmd.PersistentFlags().StringVarP(&sign, flags.Sign, "", "", "Sign the commits and tags created by the tool using the given format: 'gpg' or 'ssh'. The key configured with git's user.signingkey is used. Leave empty to disable.")
+	rootCmd.PersistentFlags().StringSliceVarP(&verify, flags.Verify, "", nil, "Local checks run on each commit before pushing it, any of: build, vet, yaml, maven. Leave empty to disable.")
+	rootCmd.PersistentFlags().StringSliceVarP(&releaseAssets, flags.Assets, "", nil, "Local files, or glob patterns, attached to the vitess GitHub release, i.e. checksums, signatures or SBOMs. Assets already uploaded with the same content are skipped. Leave empty to disable.")
+	rootCmd.PersistentFlags().StringSliceVarP(&reviewers, flags.Reviewers, "", nil, "GitHub users, or teams with the format org/team, whose review is requested on the Pull Requests created by the tool. The author of the Pull Requests is never requested. Defaults to the vitessio/release team when running live.")
+	rootCmd.PersistentFlags().StringVarP(&pushRemote, flags.PushRemote, "", "", "Name of the git remote, pointing to your fork, on which the generated branches are pushed. Pull Requests are still opened against the upstream repository and tags are pushed to upstream. Leave empty to push to upstream.")
+	rootCmd.PersistentFlags().StringVarP(&vtopPushRemote, flags.VtOpPushRemote, "", "", "Same as --push-remote, for the vitess-operator clone whose remotes are named independently. Leave empty to push to upstream.")
+	rootCmd.PersistentFlags().StringVarP(&gitCacheDir, flags.GitCacheDir, "", "", "Directory in which partial clones of the repositories are kept and reused across sessions, all git operations are then done there instead of in your clones. Leave empty to use your clones.")
+	rootCmd.PersistentFlags().BoolVar(&verbose, flags.Verbose, false, "Show additional details, such as the number of git fetches and the time saved by the fetch cache.")
+	rootCmd.PersistentFlags().BoolVar(&autoMerge, flags.AutoMerge, false, "On the release day, enable GitHub auto-merge on the release Pull Requests: with a merge commit for vitess, squashed for vtop. They are merged once approved and green.")
 	rootCmd.PersistentFlags().BoolVarP(&version, "version", "v", false, "Prints the version.")
 
 	err := cobra.MarkFlagRequired(rootCmd.PersistentFlags(), flags.MajorRelease)
@@ -85,7 +112,10 @@ func init() {
 }
 
 func Execute() {
+	// The flags of the subcommands are unknown to the root command, they are parsed, and validated, by ExecuteContext.
+	rootCmd.FParseErrWhitelist.UnknownFlags = true
 	err := rootCmd.ParseFlags(os.Args)
+	rootCmd.FParseErrWhitelist.UnknownFlags = false
 
 	if help {
 		if err := rootCmd.Help(); err != nil {
@@ -117,10 +147,17 @@ func Execute() {
 	resetGHUser := utils.SetGHUser()
 	defer resetGHUser()
 
-	s := &releaser.State{}
+	git.EnableSigning(sign)
+	github.RequestReviewsFrom(releaser.ParseReviewersFlag(reviewers, live))
+
+	s := &releaser.State{Verbose: verbose, AutoMerge: autoMerge, ProtectionSourceBranch: protectionSource}
 
 	vitessRepo, vtopRepo := getGitRepos()
 
+	if gitCacheDir != "" {
+		s.UseGitCache(gitCacheDir, vtopReleaseVersion != "")
+	}
+
 	vitessRelease, issueNb, issueLink := setUpVitessReleaseInformation(s, vitessRepo, rcIncrement)
 	vtopRelease := setUpVtOpReleaseInformation(s, vtopRepo, rcIncrement)
 
@@ -128,6 +165,9 @@ func Execute() {
 	s.VtOpRelease = vtopRelease
 	s.IssueNbGH = issueNb
 	s.IssueLink = issueLink
+	s.Project = releaser.ParseProjectFlag(project)
+	s.VerifyChecks = releaser.ParseVerifyFlag(verify)
+	s.ReleaseAssets = releaser.ParseReleaseAssetsFlag(releaseAssets)
 	s.Issue.RC = rcIncrement
 	s.Issue.DoVtOp = s.VtOpRelease.Release != ""
 	s.Issue.VtopRelease = s.VtOpRelease.Release
@@ -146,9 +186,11 @@ func Execute() {
 func setUpVitessReleaseInformation(s *releaser.State, repo string, rc int) (releaser.ReleaseInformation, int, string) {
 	s.GoToVitess()
 
-	git.CorrectCleanRepo(repo)
+	git.CorrectRepo(repo)
+	git.PruneWorktrees()
 
 	remote := git.FindRemoteName(repo)
+	pushRemote, pushOwner := setUpPushRemote(repo, remote, pushRemote)
 	release, releaseBranch, isLatestRelease, isFromMain, ga := releaser.FindNextRelease(remote, releaseVersion, false, rc)
 	issueNb, issueLink, releaseFromIssue := github.GetReleaseIssueInfo(repo, releaseVersion, rcIncrement)
 
@@ -166,6 +208,8 @@ func setUpVitessReleaseInformation(s *releaser.State, repo string, rc int) (rele
 	vitessRelease := releaser.ReleaseInformation{
 		Repo:          repo,
 		Remote:        remote,
+		PushRemote:    pushRemote,
+		PushOwner:     pushOwner,
 		ReleaseBranch: releaseBranch,
 		// BaseReleaseBranch is the same as ReleaseBranch for Vitess post v21, maybe we can merge these two at a later date
 		BaseReleaseBranch: releaseBranch,
@@ -190,14 +234,18 @@ func setUpVtOpReleaseInformation(s *releaser.State, repo string, rc int) release
 	s.GoToVtOp()
 	defer s.GoToVitess()
 
-	git.CorrectCleanRepo(repo)
+	git.CorrectRepo(repo)
+	git.PruneWorktrees()
 
 	remote := git.FindRemoteName(repo)
+	pushRemote, pushOwner := setUpPushRemote(repo, remote, vtopPushRemote)
 	release, releaseBranch, isLatestRelease, _, _ := releaser.FindNextRelease(remote, vtopReleaseVersion, true, rc)
 
 	vtopRelease := releaser.ReleaseInformation{
 		Repo:            repo,
 		Remote:          remote,
+		PushRemote:      pushRemote,
+		PushOwner:       pushOwner,
 		Release:         release,
 		ReleaseBranch:   releaseBranch,
 		IsLatestRelease: isLatestRelease,
@@ -206,6 +254,28 @@ func setUpVtOpReleaseInformation(s *releaser.State, repo string, rc int) release
 	return vtopRelease
 }
 
+// setUpPushRemote returns the remote on which generated branches are pushed, and the owner of
+// the fork it points to. Without a push remote, branches are pushed to upstream.
+func setUpPushRemote(repo, upstreamRemote, pushRemote string) (string, string) {
+	if pushRemote == "" || pushRemote == upstreamRemote {
+		return upstreamRemote, ""
+	}
+
+	owner, name := git.RemoteRepository(pushRemote)
+	upstreamOwner, upstreamName, _ := strings.Cut(repo, "/")
+
+	// i.e. the remote of the vitess fork given for the vitess-operator clone
+	if !strings.EqualFold(name, upstreamName) {
+		utils.BailOut(nil, "remote %s points to %s/%s, which is not a fork of %s", pushRemote, owner, name, repo)
+	}
+
+	if strings.EqualFold(owner, upstreamOwner) {
+		return pushRemote, ""
+	}
+
+	return pushRemote, owner
+}
+
 func setUpIssueDate(s *releaser.State) {
 	// We only require the release date if the release issue does not exist on GH
 	// If the issue already exist we ignore the flag, the value will be loaded from the Issue
@@ -17,10 +17,21 @@ limitations under the License.
 package flags
 
 const (
-	MajorRelease = "release"
-	ReleaseDate  = "date"
-	RCIncrement  = "rc"
-	RunLive      = "live"
-	VtOpRelease  = "vtop-release"
-	Help         = "help"
+	MajorRelease     = "release"
+	ReleaseDate      = "date"
+	RCIncrement      = "rc"
+	RunLive          = "live"
+	VtOpRelease      = "vtop-release"
+	Project          = "project"
+	ProtectionSource = "protection-source"
+	Sign             = "sign"
+	Verify           = "verify"
+	PushRemote       = "push-remote"
+	VtOpPushRemote   = "vtop-push-remote"
+	GitCacheDir      = "git-cache-dir"
+	Verbose          = "verbose"
+	AutoMerge        = "auto-merge"
+	Assets           = "release-assets"
+	Reviewers        = "reviewers"
+	Help             = "help"
 )
@@ -0,0 +1,45 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/vitessio/vitess-releaser/go/releaser"
+	"github.com/vitessio/vitess-releaser/go/releaser/release"
+)
+
+var githubReleaseCmd = &cobra.Command{
+	Use:   "github-release",
+	Short: "Update the GitHub release of an already tagged release from its release notes, and upload the release assets",
+	Run: func(cmd *cobra.Command, args []string) {
+		state := releaser.UnwrapState(cmd.Context())
+
+		pl, fn := release.UpdateGitHubRelease(state)
+		fn()
+
+		for _, step := range pl.GetStepInProgress() {
+			fmt.Println(step)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(githubReleaseCmd)
+}
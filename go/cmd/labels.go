@@ -0,0 +1,93 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/vitessio/vitess-releaser/go/releaser"
+	"github.com/vitessio/vitess-releaser/go/releaser/post_release"
+)
+
+var (
+	retireLabels       bool
+	retiredLabelPrefix string
+	supportedReleases  int
+)
+
+var labelsCmd = &cobra.Command{
+	Use:   "labels",
+	Short: "List the release labels with their usage, and retire the labels of unsupported release branches",
+	Run: func(cmd *cobra.Command, args []string) {
+		state := releaser.UnwrapState(cmd.Context())
+
+		labels := post_release.ListReleaseLabels(state, retiredLabelPrefix, supportedReleases)
+		if len(labels) == 0 {
+			fmt.Println("No release label found.")
+			return
+		}
+
+		var toRetire []string
+
+		for _, l := range labels {
+			fmt.Printf("\t%s\n", l)
+
+			if !l.Supported && !l.Retired {
+				toRetire = append(toRetire, l.Name)
+			}
+		}
+
+		if !retireLabels {
+			return
+		}
+
+		if len(toRetire) > 0 {
+			fmt.Printf("The following labels will be renamed with the prefix '%s':\n", retiredLabelPrefix)
+
+			for _, name := range toRetire {
+				fmt.Printf("\t%s\n", name)
+			}
+
+			fmt.Print("Continue? [y/N] ")
+
+			answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+			if strings.ToLower(strings.TrimSpace(answer)) != "y" {
+				return
+			}
+		}
+
+		pl, fn := post_release.RetireReleaseLabels(state, labels, retiredLabelPrefix)
+		fn()
+
+		for _, step := range pl.GetStepInProgress() {
+			fmt.Println(step)
+		}
+	},
+}
+
+func init() {
+	labelsCmd.Flags().BoolVar(&retireLabels, "retire", false, "Rename the labels of the unsupported release branches with the retired prefix.")
+	labelsCmd.Flags().StringVar(&retiredLabelPrefix, "retired-prefix", post_release.DefaultRetiredLabelPrefix, "Prefix prepended to the name of the retired labels.")
+	labelsCmd.Flags().IntVar(&supportedReleases, "supported-releases", post_release.DefaultSupportedReleases, "Number of supported major releases, including the one being released. The labels of older release branches are retired.")
+
+	rootCmd.AddCommand(labelsCmd)
+}
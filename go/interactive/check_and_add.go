@@ -63,9 +63,12 @@ func actCheckAndAdd(mi *ui.MenuItem) (*ui.MenuItem, tea.Cmd) {
 
 	pl, add := prerequisite.CheckAndAddPRsIssues(mi.State)
 
+	// the tracker is shown once the release issue is refreshed and the progress dialog is closed
+	tracker := ui.NewTrackerDialog(mi.State, pl, prerequisite.RemindAuthors)
+
 	return mi, tea.Batch(func() tea.Msg {
 		return checkAndAdd(add())
-	}, ui.PushDialog(ui.NewProgressDialog("", pl)))
+	}, tea.Sequence(ui.PushDialog(tracker), ui.PushDialog(ui.NewProgressDialog("", pl))))
 }
 
 func checkAndAddUpdate(mi *ui.MenuItem, msg tea.Msg) (*ui.MenuItem, tea.Cmd) {
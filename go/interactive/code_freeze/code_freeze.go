@@ -41,6 +41,7 @@ func CodeFreezeMenuItem(ctx context.Context) *ui.MenuItem {
 		Act:    act,
 		Update: codeFreezeUpdate,
 		Info:   state.Issue.CodeFreeze.URL,
+		PRRepo: state.VitessRelease.Repo,
 		IsDone: state.Issue.CodeFreeze.Done,
 
 		// We only want to do code freeze if we are doing a patch release or RC-1.
@@ -41,6 +41,7 @@ func UpdateSnapshotOnMainMenuItem(ctx context.Context) *ui.MenuItem {
 		Act:    act,
 		Update: updateSnapshotOnMainUpdate,
 		Info:   state.Issue.UpdateSnapshotOnMain.URL,
+		PRRepo: state.VitessRelease.Repo,
 		IsDone: state.Issue.UpdateSnapshotOnMain.Done,
 
 		// We only want to update the SNAPSHOT version on main if we are doing a first RC release.
@@ -41,6 +41,7 @@ func VtopBumpMainVersionMenuItem(ctx context.Context) *ui.MenuItem {
 		Act:    act,
 		Update: vtopBumpMainVersionUpdate,
 		Info:   state.Issue.VtopBumpMainVersion.URL,
+		PRRepo: state.VtOpRelease.Repo,
 		IsDone: state.Issue.VtopBumpMainVersion.Done,
 
 		Ignore: state.VtOpRelease.Release == "" || state.Issue.RC != 1,
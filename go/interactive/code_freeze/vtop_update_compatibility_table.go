@@ -0,0 +1,90 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package code_freeze
+
+import (
+	"context"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/vitessio/vitess-releaser/go/interactive/ui"
+	"github.com/vitessio/vitess-releaser/go/releaser"
+	"github.com/vitessio/vitess-releaser/go/releaser/code_freeze"
+	"github.com/vitessio/vitess-releaser/go/releaser/steps"
+)
+
+func VtopUpdateCompatibilityTableMenuItem(ctx context.Context) *ui.MenuItem {
+	state := releaser.UnwrapState(ctx)
+
+	return &ui.MenuItem{
+		State:  state,
+		Name:   steps.VtopUpdateCompatibilityTable,
+		Act:    vtopUpdateCompatibilityTableAct,
+		Update: vtopUpdateCompatibilityTableUpdate,
+		IsDone: state.Issue.VtopUpdateCompatibilityTable,
+
+		Ignore: state.VtOpRelease.Release == "" || state.Issue.RC != 1,
+	}
+}
+
+type (
+	vtopCompatibilityTableBypassed struct{}
+	vtopCompatibilityTableUpdated  string
+)
+
+func vtopUpdateCompatibilityTableUpdate(mi *ui.MenuItem, msg tea.Msg) (*ui.MenuItem, tea.Cmd) {
+	switch msg := msg.(type) {
+	case vtopCompatibilityTableBypassed:
+		return mi, ui.PushDialog(&ui.DoneDialog{
+			Title:    steps.VtopUpdateCompatibilityTable,
+			Message:  code_freeze.VtopUpdateCompatibilityTable(mi.State),
+			IsDone:   mi.IsDone,
+			StepName: steps.VtopUpdateCompatibilityTable,
+		})
+	case ui.DoneDialogAction:
+		if string(msg) != steps.VtopUpdateCompatibilityTable {
+			return mi, nil
+		}
+
+		pl, fn := code_freeze.VtopSetCompatibilityTableDone(mi.State, !mi.IsDone)
+
+		return mi, tea.Batch(func() tea.Msg {
+			return vtopCompatibilityTableUpdated(fn())
+		}, ui.PushDialog(ui.NewProgressDialog(steps.VtopUpdateCompatibilityTable, pl)))
+	case vtopCompatibilityTableUpdated:
+		mi.IsDone = mi.State.Issue.VtopUpdateCompatibilityTable
+	}
+
+	return mi, nil
+}
+
+// vtopUpdateCompatibilityTableAct lets the release team bypass the branch protection rules
+// before showing the instructions, unless the step is already done.
+func vtopUpdateCompatibilityTableAct(mi *ui.MenuItem) (*ui.MenuItem, tea.Cmd) {
+	if mi.IsDone {
+		return mi, func() tea.Msg {
+			return vtopCompatibilityTableBypassed{}
+		}
+	}
+
+	pl, fn := code_freeze.VtopBypassCompatibilityTableProtection(mi.State)
+
+	return mi, tea.Batch(func() tea.Msg {
+		fn()
+		return vtopCompatibilityTableBypassed{}
+	}, ui.PushDialog(ui.NewProgressDialog(steps.VtopUpdateCompatibilityTable, pl)))
+}
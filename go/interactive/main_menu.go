@@ -30,7 +30,6 @@ import (
 	"github.com/vitessio/vitess-releaser/go/interactive/ui"
 	"github.com/vitessio/vitess-releaser/go/releaser"
 	"github.com/vitessio/vitess-releaser/go/releaser/github"
-	postreleaselogic "github.com/vitessio/vitess-releaser/go/releaser/post_release"
 	prereleaselogic "github.com/vitessio/vitess-releaser/go/releaser/pre_release"
 	releaselogic "github.com/vitessio/vitess-releaser/go/releaser/release"
 	"github.com/vitessio/vitess-releaser/go/releaser/steps"
@@ -61,7 +60,7 @@ func MainScreen(ctx context.Context, state *releaser.State) {
 		code_freeze.CreateMilestoneMenuItem(ctx),
 		code_freeze.VtopCreateBranchMenuItem(ctx),
 		code_freeze.VtopBumpMainVersionMenuItem(ctx),
-		vtopUpdateCompatibilityTableMenuItem(ctx),
+		code_freeze.VtopUpdateCompatibilityTableMenuItem(ctx),
 	)
 
 	preReleaseMenu := ui.NewMenu(
@@ -99,7 +98,8 @@ func MainScreen(ctx context.Context, state *releaser.State) {
 		"Post Release",
 		slackAnnouncementMenuItem(ctx, slackAnnouncementPostRelease),
 		twitterMenuItem(ctx),
-		simpleMenuItem(ctx, "RemoveBypassProtection", postreleaselogic.RemoveByPassProtectionRules(), steps.RemoveBypassProtection, false),
+		post_release.RemoveBypassProtectionItem(ctx),
+		post_release.CleanupBranchesItem(ctx),
 		post_release.CloseIssueItem(ctx),
 	)
 
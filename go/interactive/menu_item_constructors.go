@@ -23,7 +23,6 @@ import (
 
 	"github.com/vitessio/vitess-releaser/go/interactive/ui"
 	"github.com/vitessio/vitess-releaser/go/releaser"
-	"github.com/vitessio/vitess-releaser/go/releaser/code_freeze"
 	"github.com/vitessio/vitess-releaser/go/releaser/post_release"
 	"github.com/vitessio/vitess-releaser/go/releaser/prerequisite"
 	"github.com/vitessio/vitess-releaser/go/releaser/release"
@@ -67,18 +66,6 @@ func requestCrossPostBlogPostMenuItem(ctx context.Context) *ui.MenuItem {
 		!state.Issue.GA)
 }
 
-func vtopUpdateCompatibilityTableMenuItem(ctx context.Context) *ui.MenuItem {
-	state := releaser.UnwrapState(ctx)
-
-	return newBooleanMenu(
-		ctx,
-		code_freeze.VtopUpdateCompatibilityTable(state),
-		steps.VtopUpdateCompatibilityTable,
-		func() { state.Issue.VtopUpdateCompatibilityTable = !state.Issue.VtopUpdateCompatibilityTable },
-		state.Issue.VtopUpdateCompatibilityTable,
-		state.VtOpRelease.Release == "" || state.Issue.RC != 1)
-}
-
 func websiteDocumentationItem(ctx context.Context) *ui.MenuItem {
 	state := releaser.UnwrapState(ctx)
 
@@ -133,7 +120,7 @@ func createBlogPostPRMenuItem(ctx context.Context) *ui.MenuItem {
 	return newBooleanMenu(
 		ctx,
 		releaser.CreateBlogPostPR(),
-		steps.DraftBlogPost,
+		steps.CreateBlogPostPR,
 		func() { state.Issue.CreateBlogPostPR = !state.Issue.CreateBlogPostPR },
 		state.Issue.CreateBlogPostPR,
 		!state.Issue.GA)
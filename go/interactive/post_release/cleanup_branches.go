@@ -0,0 +1,79 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package post_release
+
+import (
+	"context"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/vitessio/vitess-releaser/go/interactive/ui"
+	"github.com/vitessio/vitess-releaser/go/releaser"
+	"github.com/vitessio/vitess-releaser/go/releaser/post_release"
+	"github.com/vitessio/vitess-releaser/go/releaser/steps"
+)
+
+func CleanupBranchesItem(ctx context.Context) *ui.MenuItem {
+	state := releaser.UnwrapState(ctx)
+
+	return &ui.MenuItem{
+		State:  state,
+		Name:   steps.CleanupBranches,
+		Act:    cleanupBranchesAct,
+		Update: cleanupBranchesUpdate,
+		IsDone: state.Issue.CleanupBranches,
+	}
+}
+
+type (
+	cleanupBranchesFound []post_release.BranchToCleanup
+	cleanupBranchesDone  string
+)
+
+func cleanupBranchesUpdate(mi *ui.MenuItem, msg tea.Msg) (*ui.MenuItem, tea.Cmd) {
+	switch msg := msg.(type) {
+	case cleanupBranchesFound:
+		if len(msg) == 0 {
+			return mi, ui.PushDialog(ui.NewInfoDialog(steps.CleanupBranches, []string{"No branch to cleanup."}))
+		}
+
+		message := make([]string, 0, len(msg))
+		for _, b := range msg {
+			message = append(message, b.String())
+		}
+
+		pl, fn := post_release.CleanupBranches(mi.State, msg)
+
+		return mi, ui.PushDialog(ui.ConfirmDialog{
+			Title:   "The following branches will be deleted",
+			Message: message,
+			OnConfirm: tea.Batch(func() tea.Msg {
+				return cleanupBranchesDone(fn())
+			}, ui.PushDialog(ui.NewProgressDialog(steps.CleanupBranches, pl))),
+		})
+	case cleanupBranchesDone:
+		mi.IsDone = mi.State.Issue.CleanupBranches
+	}
+
+	return mi, nil
+}
+
+func cleanupBranchesAct(mi *ui.MenuItem) (*ui.MenuItem, tea.Cmd) {
+	return mi, func() tea.Msg {
+		return cleanupBranchesFound(post_release.FindBranchesToCleanup(mi.State))
+	}
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package post_release
+
+import (
+	"context"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/vitessio/vitess-releaser/go/interactive/ui"
+	"github.com/vitessio/vitess-releaser/go/releaser"
+	"github.com/vitessio/vitess-releaser/go/releaser/post_release"
+	"github.com/vitessio/vitess-releaser/go/releaser/steps"
+)
+
+func RemoveBypassProtectionItem(ctx context.Context) *ui.MenuItem {
+	state := releaser.UnwrapState(ctx)
+	act := removeBypassProtectionAct
+
+	if state.Issue.RemoveBypassProtection && len(state.BypassedBranches()) == 0 {
+		act = nil
+	}
+
+	return &ui.MenuItem{
+		State:  state,
+		Name:   steps.RemoveBypassProtection,
+		Act:    act,
+		Update: removeBypassProtectionUpdate,
+		Info:   strings.Join(state.BypassedBranches(), ", "),
+		IsDone: state.Issue.RemoveBypassProtection,
+	}
+}
+
+type removeBypassProtectionUrl string
+
+func removeBypassProtectionUpdate(mi *ui.MenuItem, msg tea.Msg) (*ui.MenuItem, tea.Cmd) {
+	_, ok := msg.(removeBypassProtectionUrl)
+	if !ok {
+		return mi, nil
+	}
+
+	mi.Info = strings.Join(mi.State.BypassedBranches(), ", ")
+	mi.IsDone = mi.State.Issue.RemoveBypassProtection
+
+	return mi, nil
+}
+
+func removeBypassProtectionAct(mi *ui.MenuItem) (*ui.MenuItem, tea.Cmd) {
+	pl, fn := post_release.RemoveBypassProtection(mi.State)
+
+	return mi, tea.Batch(func() tea.Msg {
+		return removeBypassProtectionUrl(fn())
+	}, ui.PushDialog(ui.NewProgressDialog(steps.RemoveBypassProtection, pl)))
+}
@@ -41,6 +41,7 @@ func CreateReleasePRMenuItem(ctx context.Context) *ui.MenuItem {
 		Act:    act,
 		Update: createReleasePRUpdate,
 		Info:   state.Issue.CreateReleasePR.URL,
+		PRRepo: state.VitessRelease.Repo,
 		IsDone: state.Issue.CreateReleasePR.Done,
 	}
 }
@@ -47,6 +47,7 @@ func VtopUpdateGolangMenuItem(ctx context.Context) *ui.MenuItem {
 		Update: vtopUpdateGolangUpdate,
 		IsDone: state.Issue.VtopUpdateGolang.Done,
 		Info:   state.Issue.VtopUpdateGolang.URL,
+		PRRepo: state.VtOpRelease.Repo,
 
 		Ignore: state.VtOpRelease.Release == "",
 	}
@@ -41,6 +41,7 @@ func BackToDevModeItem(ctx context.Context) *ui.MenuItem {
 		Act:    act,
 		Update: backToDevModeUpdate,
 		Info:   state.Issue.BackToDevMode.URL,
+		PRRepo: state.VitessRelease.Repo,
 		IsDone: state.Issue.BackToDevMode.Done,
 	}
 }
@@ -41,6 +41,7 @@ func BackToDevModeBaseBranchItem(ctx context.Context) *ui.MenuItem {
 		Act:    act,
 		Update: backToDevModeBaseBranchUpdate,
 		Info:   state.Issue.BackToDevModeBaseBranch.URL,
+		PRRepo: state.VitessRelease.Repo,
 		IsDone: state.Issue.BackToDevModeBaseBranch.Done,
 
 		// We only want to do this during the GA release
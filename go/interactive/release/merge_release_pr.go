@@ -46,6 +46,7 @@ func MergeReleasePRItem(ctx context.Context) *ui.MenuItem {
 		Act:    act,
 		Update: mergeReleasePRUpdate,
 		Info:   info,
+		PRRepo: state.VitessRelease.Repo,
 		IsDone: state.Issue.MergeReleasePR.Done,
 	}
 }
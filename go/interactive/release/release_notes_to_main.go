@@ -41,6 +41,7 @@ func ReleaseNotesOnMainItem(ctx context.Context) *ui.MenuItem {
 		Act:    act,
 		Update: releaseNotesOnMainUpdate,
 		Info:   state.Issue.ReleaseNotesOnMain.URL,
+		PRRepo: state.VitessRelease.Repo,
 		IsDone: state.Issue.ReleaseNotesOnMain.Done,
 	}
 }
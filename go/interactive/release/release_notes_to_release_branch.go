@@ -41,6 +41,7 @@ func ReleaseNotesOnReleaseBranchItem(ctx context.Context) *ui.MenuItem {
 		Act:    act,
 		Update: releaseNotesOnReleaseBranchUpdate,
 		Info:   state.Issue.ReleaseNotesOnReleaseBranch.URL,
+		PRRepo: state.VitessRelease.Repo,
 		IsDone: state.Issue.ReleaseNotesOnReleaseBranch.Done,
 
 		// We want to ignore this step if we are doing a patch release.
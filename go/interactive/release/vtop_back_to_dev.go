@@ -42,6 +42,7 @@ func VtopBackToDevModeItem(ctx context.Context) *ui.MenuItem {
 		Update: vtopBackToDevUpdate,
 		IsDone: state.Issue.VtopBackToDevMode.Done,
 		Info:   state.Issue.VtopBackToDevMode.URL,
+		PRRepo: state.VtOpRelease.Repo,
 
 		Ignore: state.VtOpRelease.Release == "",
 	}
@@ -42,6 +42,7 @@ func VtopCreateReleasePRMenuItem(ctx context.Context) *ui.MenuItem {
 		Update: vtopCreateReleasePRUpdate,
 		IsDone: state.Issue.VtopCreateReleasePR.Done,
 		Info:   state.Issue.VtopCreateReleasePR.URL,
+		PRRepo: state.VtOpRelease.Repo,
 
 		Ignore: state.VtOpRelease.Release == "",
 	}
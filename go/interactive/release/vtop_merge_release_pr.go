@@ -47,6 +47,7 @@ func VtopMergeReleasePRItem(ctx context.Context) *ui.MenuItem {
 		Update: vtopMergeReleasePRUpdate,
 		IsDone: state.Issue.VtopMergeReleasePR.Done,
 		Info:   info,
+		PRRepo: state.VtOpRelease.Repo,
 
 		Ignore: state.VtOpRelease.Release == "",
 	}
@@ -0,0 +1,132 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ui
+
+import (
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/vitessio/vitess-releaser/go/releaser/github"
+)
+
+// checksInterval is how often the CI checks of the Pull Requests shown in a menu are refreshed.
+const checksInterval = 30 * time.Second
+
+type (
+	// checksTickMsg triggers a refresh of the checks of the given menu, as messages are sent to all the menus.
+	// Ticks of an older generation of the polling loop are dropped, see Menu.Init.
+	checksTickMsg struct {
+		menu       *Menu
+		generation int
+	}
+
+	checksMsg struct {
+		url     string
+		pr      github.PR
+		summary github.CheckSummary
+		err     error
+	}
+
+	// prChecks are the last known checks of the Pull Request of a MenuItem.
+	prChecks struct {
+		url     string
+		pr      github.PR
+		summary github.CheckSummary
+		err     error
+	}
+)
+
+// pullRequestURL returns the URL of the Pull Request of the item, if it has one.
+func (mi *MenuItem) pullRequestURL() string {
+	if mi.PRRepo == "" || !strings.Contains(mi.Info, "/pull/") {
+		return ""
+	}
+
+	return mi.Info
+}
+
+// checksFinal is true when the checks of the item will not change anymore.
+func (mi *MenuItem) checksFinal() bool {
+	c := mi.checks
+	if c == nil || c.url != mi.pullRequestURL() || c.err != nil {
+		return false
+	}
+
+	return c.pr.State != "OPEN" && c.summary.Pending == 0
+}
+
+func (mi *MenuItem) checksInfo() string {
+	c := mi.checks
+	if c == nil || c.url != mi.pullRequestURL() {
+		return ""
+	}
+
+	if c.err != nil {
+		return "checks: unavailable"
+	}
+
+	return c.summary.String()
+}
+
+func (m *Menu) hasPullRequests() bool {
+	for _, mi := range m.Items {
+		if mi.PRRepo != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *Menu) checksTick() tea.Cmd {
+	generation := m.checksGeneration
+
+	return tea.Tick(checksInterval, func(time.Time) tea.Msg {
+		return checksTickMsg{menu: m, generation: generation}
+	})
+}
+
+// refreshChecks fetches the checks of the Pull Requests of the menu in the background.
+func (m *Menu) refreshChecks() tea.Cmd {
+	var cmds []tea.Cmd
+
+	for _, mi := range m.Items {
+		url := mi.pullRequestURL()
+		if url == "" || mi.checksFinal() {
+			continue
+		}
+
+		repo := mi.PRRepo
+
+		cmds = append(cmds, func() tea.Msg {
+			pr, summary, err := github.GetPRChecks(repo, github.URLToNb(url))
+			return checksMsg{url: url, pr: pr, summary: summary, err: err}
+		})
+	}
+
+	return tea.Batch(cmds...)
+}
+
+func (m *Menu) updateChecks(msg checksMsg) {
+	for _, mi := range m.Items {
+		if mi.pullRequestURL() == msg.url {
+			mi.checks = &prChecks{url: msg.url, pr: msg.pr, summary: msg.summary, err: msg.err}
+		}
+	}
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/vitessio/vitess-releaser/go/releaser/github"
+)
+
+// logTailLines is the number of lines of logs shown for each failed job.
+const logTailLines = 20
+
+type checksDialog struct {
+	title    string
+	repo     string
+	checks   *prChecks
+	logs     map[int64]string
+	viewport viewport.Model
+}
+
+type checksLogsMsg map[int64]string
+
+var _ tea.Model = &checksDialog{}
+
+// newChecksDialog shows the failed checks of a Pull Request, with the end of the logs of the GitHub Actions jobs.
+func newChecksDialog(repo string, checks *prChecks) *checksDialog {
+	return &checksDialog{
+		title:    fmt.Sprintf("CI checks of %s: %s", checks.url, checks.summary),
+		repo:     repo,
+		checks:   checks,
+		viewport: viewport.New(0, 0),
+	}
+}
+
+func (c *checksDialog) Init() tea.Cmd {
+	c.viewport.SetContent(c.content())
+
+	repo := c.repo
+	failed := c.checks.summary.FailedChecks()
+
+	return func() tea.Msg {
+		logs := checksLogsMsg{}
+
+		for _, check := range failed {
+			if check.JobID != 0 {
+				logs[check.JobID] = github.GetJobLogTail(repo, check.JobID, logTailLines)
+			}
+		}
+
+		return logs
+	}
+}
+
+func (c *checksDialog) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		c.viewport.Width = msg.Width
+		c.viewport.Height = max(msg.Height-4, 1)
+
+		return c, nil
+
+	case checksLogsMsg:
+		c.logs = msg
+		c.viewport.SetContent(c.content())
+
+		return c, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "esc", "enter":
+			return c, popDialog
+		}
+	}
+
+	var cmd tea.Cmd
+	c.viewport, cmd = c.viewport.Update(msg)
+
+	return c, cmd
+}
+
+func (c *checksDialog) content() string {
+	failed := c.checks.summary.FailedChecks()
+	if len(failed) == 0 {
+		var pending []string
+
+		for _, check := range c.checks.summary.Checks {
+			if check.State == github.CheckPending {
+				pending = append(pending, " - "+check.Name)
+			}
+		}
+
+		if len(pending) == 0 {
+			return "No failed checks."
+		}
+
+		return "No failed checks, still pending:\n" + strings.Join(pending, "\n")
+	}
+
+	var b strings.Builder
+
+	for _, check := range failed {
+		b.WriteString(selectedStyle.Render(check.Name) + "\n")
+		b.WriteString(check.URL + "\n")
+
+		switch {
+		case check.JobID == 0:
+			b.WriteString(cellStyle.Render("No logs, see the link above.") + "\n")
+		case c.logs == nil:
+			b.WriteString(cellStyle.Render("Loading the logs...") + "\n")
+		default:
+			b.WriteString(cellStyle.Render(c.logs[check.JobID]) + "\n")
+		}
+
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+func (c *checksDialog) View() string {
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		bgStyle.Render(c.title),
+		"",
+		c.viewport.View(),
+		bgStyle.Render("'up'/'down' = scroll, 'q' = back"),
+	)
+}
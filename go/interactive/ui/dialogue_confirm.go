@@ -0,0 +1,70 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/lipgloss/table"
+)
+
+// ConfirmDialog lists Message and asks the user for confirmation, OnConfirm
+// is run once the dialog is closed if the user pressed 'y'.
+type ConfirmDialog struct {
+	height, width int
+	Title         string
+	Message       []string
+	OnConfirm     tea.Cmd
+}
+
+var _ tea.Model = ConfirmDialog{}
+
+func (c ConfirmDialog) Init() tea.Cmd {
+	return nil
+}
+
+func (c ConfirmDialog) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		c.height = msg.Height
+		c.width = msg.Width
+
+		return c, nil
+
+	case tea.KeyMsg:
+		if msg.String() == "y" {
+			return c, tea.Sequence(popDialog, c.OnConfirm)
+		}
+
+		return c, popDialog
+	}
+
+	return c, nil
+}
+
+func (c ConfirmDialog) View() string {
+	var rows [][]string
+	for _, s := range c.Message {
+		rows = append(rows, []string{s})
+	}
+
+	lines := []string{c.Title, ""}
+	lines = append(lines, table.New().Data(table.NewStringData(rows...)).Width(c.width).Render())
+	lines = append(lines, "", "Press 'y' to confirm, any other key to cancel")
+
+	return lipgloss.JoinVertical(lipgloss.Center, lines...)
+}
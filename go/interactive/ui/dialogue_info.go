@@ -30,6 +30,10 @@ type infoDialog struct {
 
 var _ tea.Model = infoDialog{}
 
+func NewInfoDialog(title string, message []string) tea.Model {
+	return infoDialog{title: title, message: message}
+}
+
 func (c infoDialog) Init() tea.Cmd {
 	return nil
 }
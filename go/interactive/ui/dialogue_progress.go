@@ -33,6 +33,9 @@ type ProgressDialog struct {
 	progress      []string
 	pl            *logging.ProgressLogging
 	progressBar   progress.Model
+
+	// cancelled is set once the user cancelled a wait, the dialog then closes by itself when the step returns.
+	cancelled bool
 }
 
 func NewProgressDialog(title string, pl *logging.ProgressLogging) *ProgressDialog {
@@ -67,6 +70,10 @@ func (c ProgressDialog) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return c, nil
 
 	case tickMsg:
+		if c.cancelled && c.pl.GetDone() == c.pl.GetTotal() {
+			return c, popDialog
+		}
+
 		cmd := c.progressBar.SetPercent(float64(c.pl.GetDone()) / float64(c.pl.GetTotal()))
 		c.progress = c.pl.GetStepInProgress()
 
@@ -74,6 +81,11 @@ func (c ProgressDialog) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case tea.KeyMsg:
 		if c.pl.GetDone() != c.pl.GetTotal() {
+			if msg.String() == "c" && c.pl.IsWaiting() {
+				c.pl.Cancel()
+				c.cancelled = true
+			}
+
 			return c, nil
 		}
 
@@ -99,8 +111,15 @@ func (c ProgressDialog) View() string {
 	lines := []string{c.title, c.progressBar.View(), ""}
 	lines = append(lines, table.New().Data(table.NewStringData(rows...)).Width(c.width).Render())
 
-	if c.pl.GetDone() == c.pl.GetTotal() {
+	switch {
+	case c.pl.GetDone() == c.pl.GetTotal():
 		lines = append(lines, "", "Press any key to continue")
+	case c.cancelled:
+		lines = append(lines, "", "Cancelling...")
+	case c.pl.IsWaiting():
+		lines = append(lines, "", c.pl.GetStatus(), "Press 'c' to stop waiting, the step can be run again later")
+	case c.pl.GetStatus() != "":
+		lines = append(lines, "", c.pl.GetStatus())
 	}
 
 	return lipgloss.JoinVertical(lipgloss.Center, lines...)
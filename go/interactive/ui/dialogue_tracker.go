@@ -0,0 +1,343 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	tbl "github.com/charmbracelet/lipgloss/table"
+
+	"github.com/vitessio/vitess-releaser/go/releaser"
+	"github.com/vitessio/vitess-releaser/go/releaser/github"
+	"github.com/vitessio/vitess-releaser/go/releaser/logging"
+)
+
+// trackerTitleWidth is the maximum width of the titles shown in the tracker.
+const trackerTitleWidth = 50
+
+var trackerColumns = []string{"", "ITEM", "KIND", "TITLE", "AUTHOR", "AGE", "CI", "REVIEW", "MERGEABLE", "STATUS"}
+
+// RemindFunc comments on the given items to remind their authors of the release, see prerequisite.RemindAuthors.
+type RemindFunc func(state *releaser.State, items []github.TrackedItem) (*logging.ProgressLogging, func() string)
+
+type (
+	TrackerDialog struct {
+		state   *releaser.State
+		refresh *logging.ProgressLogging
+		remind  RemindFunc
+
+		rows     []trackerRow
+		selected map[int]bool
+		loading  bool
+		err      error
+		idx      int
+		width    int
+	}
+
+	trackerRow struct {
+		nb             int
+		releaseBlocker bool
+		done           bool
+		outcome        string
+
+		// item is nil until the item is loaded, or if it could not be.
+		item *github.TrackedItem
+	}
+
+	trackerMsg struct {
+		dialog *TrackerDialog
+		items  []github.TrackedItem
+		err    error
+	}
+
+	// trackerRemindedMsg is sent once the authors of the selected items were reminded.
+	trackerRemindedMsg struct {
+		dialog *TrackerDialog
+	}
+)
+
+var _ tea.Model = &TrackerDialog{}
+
+// NewTrackerDialog shows the backports and release blockers tracked by the release issue, with where each of them
+// stands, and reminds the authors of the selected items with remind. The items are loaded once the refresh of the
+// release issue, tracked by refresh, is done, and again every time the dialog becomes active.
+func NewTrackerDialog(state *releaser.State, refresh *logging.ProgressLogging, remind RemindFunc) *TrackerDialog {
+	return &TrackerDialog{
+		state:    state,
+		refresh:  refresh,
+		remind:   remind,
+		selected: map[int]bool{},
+	}
+}
+
+func (t *TrackerDialog) Init() tea.Cmd {
+	if t.refresh.GetDone() != t.refresh.GetTotal() {
+		return nil
+	}
+
+	t.rows = nil
+	t.idx = 0
+
+	var numbers []int
+
+	add := func(parent releaser.ParentOfItems, releaseBlocker bool) {
+		for _, item := range parent.Items {
+			if nb, ok := github.ItemNumber(item.URL); ok {
+				t.rows = append(t.rows, trackerRow{nb: nb, releaseBlocker: releaseBlocker, done: item.Done, outcome: item.Outcome})
+				numbers = append(numbers, nb)
+			}
+		}
+	}
+
+	add(t.state.Issue.CheckBackport, false)
+	add(t.state.Issue.ReleaseBlocker, true)
+
+	if len(numbers) == 0 {
+		return nil
+	}
+
+	t.loading = true
+	repo := t.state.VitessRelease.Repo
+
+	return func() tea.Msg {
+		items, err := github.GetTrackedItems(repo, numbers)
+		return trackerMsg{dialog: t, items: items, err: err}
+	}
+}
+
+func (t *TrackerDialog) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		t.width = msg.Width
+
+	case trackerMsg:
+		if msg.dialog != t {
+			return t, nil
+		}
+
+		t.loading, t.err = false, msg.err
+
+		for _, item := range msg.items {
+			for i := range t.rows {
+				if t.rows[i].nb == item.Number {
+					t.rows[i].item = &item
+				}
+			}
+		}
+
+		for nb := range t.selected {
+			if !t.canRemind(nb) {
+				delete(t.selected, nb)
+			}
+		}
+
+	case trackerRemindedMsg:
+		if msg.dialog == t {
+			clear(t.selected)
+		}
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "esc":
+			return t, popDialog
+		case "up":
+			if len(t.rows) > 0 {
+				t.idx = (t.idx - 1 + len(t.rows)) % len(t.rows)
+			}
+		case "down":
+			if len(t.rows) > 0 {
+				t.idx = (t.idx + 1) % len(t.rows)
+			}
+		case " ":
+			if len(t.rows) > 0 && t.canRemind(t.rows[t.idx].nb) {
+				nb := t.rows[t.idx].nb
+				t.selected[nb] = !t.selected[nb]
+			}
+		case "r":
+			return t, t.confirmReminder()
+		}
+	}
+
+	return t, nil
+}
+
+// canRemind is true when the item is loaded, still pending and was not opened by a bot.
+func (t *TrackerDialog) canRemind(nb int) bool {
+	for _, row := range t.rows {
+		if row.nb == nb && row.item != nil {
+			return !row.done && row.item.State == "OPEN" && !strings.HasPrefix(row.item.Author.Login, "app/")
+		}
+	}
+
+	return false
+}
+
+func (t *TrackerDialog) confirmReminder() tea.Cmd {
+	var items []github.TrackedItem
+	var message []string
+
+	for _, row := range t.rows {
+		if t.selected[row.nb] && row.item != nil {
+			items = append(items, *row.item)
+			message = append(message, fmt.Sprintf("#%d by @%s: %s", row.nb, row.item.Author.Login, row.item.Title))
+		}
+	}
+
+	if len(items) == 0 {
+		return nil
+	}
+
+	pl, remind := t.remind(t.state, items)
+
+	return PushDialog(ConfirmDialog{
+		Title:   fmt.Sprintf("Post a reminder comment on the %d item(s) below?", len(items)),
+		Message: message,
+		OnConfirm: tea.Batch(func() tea.Msg {
+			remind()
+			return trackerRemindedMsg{dialog: t}
+		}, PushDialog(NewProgressDialog("Remind the authors", pl))),
+	})
+}
+
+func (t *TrackerDialog) View() string {
+	title := fmt.Sprintf("Pending backports and release blockers of v%s", t.state.VitessRelease.Release)
+
+	var status string
+
+	switch {
+	case t.refresh.GetDone() != t.refresh.GetTotal():
+		status = "Refreshing the release issue..."
+	case t.loading:
+		status = "Loading the items..."
+	case len(t.rows) == 0:
+		status = "No backports nor release blockers are tracked by the release issue."
+	case t.err != nil:
+		status = fmt.Sprintf("Some items could not be loaded: %s", t.err)
+	}
+
+	var rows [][]string
+	for _, row := range t.rows {
+		rows = append(rows, t.renderRow(row))
+	}
+
+	table := tbl.
+		New().
+		Width(t.width).
+		Headers(trackerColumns...).
+		Rows(rows...).
+		Border(lipgloss.ThickBorder()).
+		BorderStyle(borderStyle).
+		StyleFunc(func(row, col int) lipgloss.Style {
+			switch row {
+			case tbl.HeaderRow:
+				return headerStyle
+			case t.idx:
+				return selectedStyle
+			default:
+				return cellStyle
+			}
+		}).
+		Render()
+
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		bgStyle.Render(title),
+		status,
+		table,
+		bgStyle.Render("'up'/'down' = move, 'space' = select, 'r' = remind the authors of the selected items, 'q' = back"),
+	)
+}
+
+func (t *TrackerDialog) renderRow(row trackerRow) []string {
+	selection := ""
+	if t.canRemind(row.nb) {
+		selection = "[ ]"
+		if t.selected[row.nb] {
+			selection = "[x]"
+		}
+	}
+
+	kind := "backport"
+	if row.releaseBlocker {
+		kind = "release blocker"
+	}
+
+	link := fmt.Sprintf("#%d", row.nb)
+
+	it := row.item
+	if it == nil {
+		return []string{selection, link, kind, "unavailable", "", "", "", "", "", trackerStatus(row)}
+	}
+
+	title := it.Title
+	if len(title) > trackerTitleWidth {
+		title = title[:trackerTitleWidth-3] + "..."
+	}
+
+	ci, review, mergeable := "-", "-", "-"
+	if it.IsPR {
+		ci = trackerValue(it.Checks, "none")
+		review = trackerValue(it.ReviewDecision, "not required")
+		mergeable = trackerValue(it.Mergeable, "unknown")
+	}
+
+	if it.State != "OPEN" {
+		mergeable = "-"
+	}
+
+	if !it.IsPR {
+		kind += " issue"
+	}
+
+	return []string{selection, link, kind, title, "@" + it.Author.Login, trackerAge(it.CreatedAt), ci, review, mergeable, trackerStatus(row)}
+}
+
+// trackerStatus is the outcome recorded in the release issue for the items that are done,
+// and where the item stands on GitHub otherwise.
+func trackerStatus(row trackerRow) string {
+	switch {
+	case row.done && row.outcome != "":
+		return row.outcome
+	case row.done:
+		return "done"
+	case row.item == nil:
+		return "pending"
+	}
+
+	return trackerValue(row.item.State, "")
+}
+
+func trackerValue(v, empty string) string {
+	if v == "" {
+		return empty
+	}
+
+	return strings.ReplaceAll(strings.ToLower(v), "_", " ")
+}
+
+func trackerAge(createdAt time.Time) string {
+	age := time.Since(createdAt)
+	if age < 24*time.Hour {
+		return fmt.Sprintf("%dh", int(age.Hours()))
+	}
+
+	return fmt.Sprintf("%dd", int(age.Hours()/24))
+}
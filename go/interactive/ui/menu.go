@@ -38,6 +38,10 @@ type (
 		columns    []string
 		width      int
 		Sequential bool
+
+		// checksGeneration identifies the current loop refreshing the CI checks, a new loop
+		// is started every time the menu becomes active.
+		checksGeneration int
 	}
 
 	MenuItem struct {
@@ -56,6 +60,11 @@ type (
 		DontCountInProgress bool
 
 		Ignore bool
+
+		// PRRepo is the repository of the Pull Request whose URL is in Info, its CI checks are then
+		// shown next to it and can be inspected with the 'c' key.
+		PRRepo string
+		checks *prChecks
 	}
 )
 
@@ -155,6 +164,10 @@ func (m *Menu) At(row, cell int) string {
 	}
 
 	if cell == 2 {
+		if checks := item.checksInfo(); checks != "" {
+			return fmt.Sprintf("%s (%s)", item.Info, checks)
+		}
+
 		return item.Info
 	}
 
@@ -190,6 +203,13 @@ func (m *Menu) Init() tea.Cmd {
 		}
 	}
 
+	// Init is called every time the menu becomes active: the checks are refreshed right away, and the
+	// ticks of the previous loop, which may have been lost while another dialog was shown, are dropped
+	if m.hasPullRequests() {
+		m.checksGeneration++
+		cmds = append(cmds, m.refreshChecks(), m.checksTick())
+	}
+
 	return tea.Batch(cmds...)
 }
 
@@ -199,6 +219,14 @@ func (m *Menu) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
+	case checksTickMsg:
+		if msg.menu != m || msg.generation != m.checksGeneration {
+			return m, nil
+		}
+
+		return m, tea.Batch(m.refreshChecks(), m.checksTick())
+	case checksMsg:
+		m.updateChecks(msg)
 	case tea.KeyMsg:
 		switch msg.String() {
 		case "esc", "q":
@@ -217,6 +245,13 @@ func (m *Menu) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					break
 				}
 			}
+		case "c":
+			selected := m.Items[m.idx]
+			if selected.checksInfo() == "" || selected.checks.err != nil {
+				return m, nil
+			}
+
+			return m, PushDialog(newChecksDialog(selected.PRRepo, selected.checks))
 		case "enter":
 			selected := m.Items[m.idx]
 			if selected.isActBlocked(m.Sequential) {
@@ -24,6 +24,8 @@ import (
 	"github.com/charmbracelet/lipgloss"
 
 	"github.com/vitessio/vitess-releaser/go/releaser"
+	"github.com/vitessio/vitess-releaser/go/releaser/git"
+	"github.com/vitessio/vitess-releaser/go/releaser/github"
 )
 
 type (
@@ -129,7 +131,7 @@ func (m UI) View() string {
 		"",
 	}
 
-	elems = append(elems, bgStyle.Render("Vitess Releaser: 'q' = back, 'enter' = action"))
+	elems = append(elems, bgStyle.Render("Vitess Releaser: 'q' = back, 'enter' = action, 'c' = CI checks"))
 	elems = append(elems, bgStyle.Render(fmt.Sprintf("Vitess repo: %s | Vitess release: v%s", m.State.VitessRelease.Repo, m.State.VitessRelease.Release)))
 
 	if m.State.VtOpRelease.Release != "" {
@@ -138,6 +140,14 @@ func (m UI) View() string {
 
 	elems = append(elems, bgStyle.Render(fmt.Sprintf("Release Date: %s", m.State.Issue.Date.Format(time.DateOnly))))
 
+	if budget := github.RateLimitSummary(); budget != "" {
+		elems = append(elems, bgStyle.Render(budget))
+	}
+
+	if m.State.Verbose {
+		elems = append(elems, bgStyle.Render(git.FetchStats().String()))
+	}
+
 	return lipgloss.JoinVertical(
 		lipgloss.Left,
 		elems...,
@@ -0,0 +1,128 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package releaser
+
+import (
+	"encoding/json"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/vitessio/vitess-releaser/go/releaser/github"
+	"github.com/vitessio/vitess-releaser/go/releaser/logging"
+	"github.com/vitessio/vitess-releaser/go/releaser/utils"
+)
+
+const (
+	// releaseTeam is the team of the vitessio organization allowed to bypass the branch protection rules.
+	releaseTeam = "release"
+	vitessOrg   = "vitessio"
+)
+
+func branchProtectionKey(repo, branch string) string {
+	return repo + ":" + branch
+}
+
+// extraStepf adds a step to the progress logging, on top of the total steps planned by the caller.
+func extraStepf(pl *logging.ProgressLogging, msg string, args ...any) {
+	pl.SetTotalStep(pl.GetTotal() + 1)
+	pl.NewStepf(msg, args...)
+}
+
+// BypassBranchProtection lets the release team bypass the required Pull Request reviews of the branch, and lifts
+// the rules for the administrators. The required status checks, such as the code freeze check, still block
+// everyone else. The original rules, as returned by the API, are snapshotted and stored in the release issue
+// before being modified, so they can be restored with RestoreBranchProtection. If a snapshot already exists for the branch, it is kept as is.
+// The steps are added to the total steps of pl.
+// Outside the vitessio organization, where the release team does not exist, the current user is allowed instead.
+func (s *State) BypassBranchProtection(pl *logging.ProgressLogging, repo, branch string) {
+	key := branchProtectionKey(repo, branch)
+
+	snapshot, ok := s.Issue.BranchProtectionSnapshots[key]
+	if !ok {
+		extraStepf(pl, "Snapshot the branch protection rules of %s on %s", branch, repo)
+
+		snapshot = github.SnapshotBranchProtection(repo, branch)
+		if snapshot == nil {
+			extraStepf(pl, "Branch %s is not protected, nothing to bypass", branch)
+			return
+		}
+
+		if s.Issue.BranchProtectionSnapshots == nil {
+			s.Issue.BranchProtectionSnapshots = map[string]json.RawMessage{}
+		}
+
+		s.Issue.BranchProtectionSnapshots[key] = snapshot
+
+		if s.IssueNbGH != 0 {
+			extraStepf(pl, "Store the snapshot in Issue %s", s.IssueLink)
+			_, fn := s.UploadIssue()
+			fn()
+		}
+	}
+
+	var teams, users []string
+	if strings.HasPrefix(repo, vitessOrg+"/") {
+		teams = []string{releaseTeam}
+	} else {
+		users = []string{github.CurrentUser()}
+	}
+
+	extraStepf(pl, "Allow %s to bypass the required reviews of %s, and lift the rules for admins (required checks still apply to non-admins)", strings.Join(append(teams, users...), ", "), branch)
+	github.BypassBranchProtection(repo, branch, snapshot, teams, users)
+}
+
+// RestoreBranchProtection restores the protection rules of the branch from the snapshot stored in the
+// release issue and verifies the restored rules match it. The snapshot is then removed from the issue.
+// It does nothing if there is no snapshot for the branch. The steps are added to the total steps of pl.
+func (s *State) RestoreBranchProtection(pl *logging.ProgressLogging, repo, branch string) {
+	key := branchProtectionKey(repo, branch)
+
+	snapshot, ok := s.Issue.BranchProtectionSnapshots[key]
+	if !ok {
+		return
+	}
+
+	extraStepf(pl, "Restore and verify the branch protection rules of %s on %s", branch, repo)
+
+	err := github.RestoreBranchProtection(repo, branch, snapshot)
+	if err != nil {
+		utils.BailOut(err, "failed to restore the branch protection rules of %s, the snapshot is kept in the release issue", branch)
+	}
+
+	delete(s.Issue.BranchProtectionSnapshots, key)
+
+	if s.IssueNbGH != 0 {
+		extraStepf(pl, "Remove the snapshot from Issue %s", s.IssueLink)
+		_, fn := s.UploadIssue()
+		fn()
+	}
+}
+
+// BypassedBranches lists the branches, as "repo:branch", whose protection rules are bypassed
+// and must still be restored.
+func (s *State) BypassedBranches() []string {
+	return slices.Sorted(maps.Keys(s.Issue.BranchProtectionSnapshots))
+}
+
+// RestoreAllBranchProtections restores the protection rules of every branch that is still bypassed.
+func (s *State) RestoreAllBranchProtections(pl *logging.ProgressLogging) {
+	for _, key := range s.BypassedBranches() {
+		repo, branch, _ := strings.Cut(key, ":")
+		s.RestoreBranchProtection(pl, repo, branch)
+	}
+}
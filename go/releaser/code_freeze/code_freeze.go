@@ -17,10 +17,12 @@ limitations under the License.
 package code_freeze
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
+	"path"
 	"strings"
-	"time"
 
 	"github.com/vitessio/vitess-releaser/go/releaser"
 	"github.com/vitessio/vitess-releaser/go/releaser/git"
@@ -41,23 +43,34 @@ const (
 // CodeFreeze will freeze the branch of the next release we want to release.
 // The function returns the URL of the code freeze Pull Request, this Pull
 // Request must be forced-merged by a Vitess maintainer, this step cannot be automated.
+// Once the branch is frozen, the authors of the open Pull Requests targeting it are notified.
 func CodeFreeze(state *releaser.State) (*logging.ProgressLogging, func() string) {
 	pl := &logging.ProgressLogging{
 		TotalSteps: 12,
 	}
 
-	waitForPRToBeMerged := func(nb int) {
-		pl.NewStepf("Waiting for the PR to be merged. You must enable bypassing the branch protection rules in: https://github.com/%s/settings/branches", state.VitessRelease.Repo)
-	outer:
-		for {
-			select {
-			case <-time.After(5 * time.Second):
-				if github.IsPRMerged(state.VitessRelease.Repo, nb) {
-					break outer
-				}
-			}
+	// waitForPRToBeMerged returns false if the wait was cancelled, the step can then be resumed later.
+	// The release team may bypass the required reviews while we wait, they are restored once the PR is merged.
+	// The code freeze check still fails, so the PR must be merged by an admin.
+	waitForPRToBeMerged := func(nb int) bool {
+		state.BypassBranchProtection(pl, state.VitessRelease.Repo, state.VitessRelease.ReleaseBranch)
+
+		pl.NewStepf("Waiting for the PR to be merged by an admin, the required reviews of %s can be bypassed but not the failing code freeze check", state.VitessRelease.ReleaseBranch)
+
+		err := releaser.WaitForPRToBeMerged(pl, state.VitessRelease.Repo, nb, utils.WaitOptions{})
+		if errors.Is(err, context.Canceled) {
+			pl.NewStepf("Stopped waiting, run this step again once the PR is merged")
+			return false
+		}
+
+		if err != nil {
+			utils.BailOut(err, "failed to wait for the Code Freeze Pull Request to be merged")
 		}
+
 		pl.NewStepf("PR has been merged")
+		state.RestoreBranchProtection(pl, state.VitessRelease.Repo, state.VitessRelease.ReleaseBranch)
+
+		return true
 	}
 
 	var done bool
@@ -83,22 +96,16 @@ func CodeFreeze(state *releaser.State) (*logging.ProgressLogging, func() string)
 			pl.NewStepf("Fetch from git remote")
 		}
 
-		git.CorrectCleanRepo(state.VitessRelease.Repo)
+		git.CorrectRepo(state.VitessRelease.Repo)
 
-		// For RC-1 we need to create two branches, the new release branch ("release-20.0")
-		// and the rc release branch ("release-20.0-rc")
+		// For RC-1 we need to create the new release branch ("release-20.0") from main
 		if state.Issue.RC == 1 {
-			git.ResetHard(state.VitessRelease.Remote, "main")
-
-			if err := git.CreateBranchAndCheckout(state.VitessRelease.BaseReleaseBranch, fmt.Sprintf("%s/main", state.VitessRelease.Remote)); err != nil {
-				git.Checkout(state.VitessRelease.BaseReleaseBranch)
-			} else {
-				git.Push(state.VitessRelease.Remote, state.VitessRelease.BaseReleaseBranch)
-			}
-		} else {
-			git.ResetHard(state.VitessRelease.Remote, state.VitessRelease.ReleaseBranch)
+			git.CreateRemoteBranch(state.VitessRelease.Remote, state.VitessRelease.BaseReleaseBranch, "main")
 		}
 
+		wt := git.NewWorktree(state.VitessRelease.Remote, state.VitessRelease.ReleaseBranch)
+		defer wt.Remove()
+
 		codeFreezePRName := fmt.Sprintf("[%s] Code Freeze for `v%s`", state.VitessRelease.ReleaseBranch, state.VitessRelease.Release)
 
 		// look for existing code freeze PRs
@@ -107,9 +114,11 @@ func CodeFreeze(state *releaser.State) (*logging.ProgressLogging, func() string)
 		if nb, url = github.FindPR(state.VitessRelease.Repo, codeFreezePRName); url != "" {
 			pl.TotalSteps = 7 // only 7 total steps in this situation
 			pl.NewStepf("An opened Code Freeze Pull Request was found: %s", url)
-			waitForPRToBeMerged(nb)
+			done = waitForPRToBeMerged(nb)
 
-			done = true
+			if done {
+				NotifyFrozenPRs(pl, state)
+			}
 
 			return url
 		}
@@ -120,6 +129,7 @@ func CodeFreeze(state *releaser.State) (*logging.ProgressLogging, func() string)
 		if isCurrentBranchFrozen() {
 			pl.TotalSteps = 6 // only 6 total steps in this situation
 			pl.NewStepf("Branch %s is already frozen, no action needed", state.VitessRelease.ReleaseBranch)
+			NotifyFrozenPRs(pl, state)
 
 			done = true
 
@@ -127,38 +137,49 @@ func CodeFreeze(state *releaser.State) (*logging.ProgressLogging, func() string)
 		}
 
 		pl.NewStepf("Create new branch based on %s/%s", state.VitessRelease.Remote, state.VitessRelease.ReleaseBranch)
-		newBranchName := git.FindNewGeneratedBranch(state.VitessRelease.Remote, state.VitessRelease.ReleaseBranch, "code-freeze")
+		newBranchName := git.FindNewGeneratedBranch(state.VitessRelease.Remote, state.VitessRelease.ReleaseBranch, git.BranchCodeFreeze)
 
 		pl.NewStepf("Turn on code freeze on branch %s", newBranchName)
 		activateCodeFreeze()
 
 		pl.NewStepf("Commit and push to branch %s", newBranchName)
 
-		if git.CommitAll(fmt.Sprintf("Code Freeze of %s", state.VitessRelease.ReleaseBranch)) {
+		if git.CommitAll(fmt.Sprintf("Code Freeze of %s", state.VitessRelease.ReleaseBranch), CodeFreezeFiles) {
 			pl.TotalSteps = 9 // only 9 total steps in this situation
 			pl.NewStepf("Nothing to commit, seems like code freeze is already done")
+			NotifyFrozenPRs(pl, state)
 
 			done = true
 
 			return ""
 		}
 
-		git.Push(state.VitessRelease.Remote, newBranchName)
+		if !state.VerifyAndPush(pl, state.VitessRelease.PushRemote, newBranchName) {
+			return ""
+		}
 
 		pl.NewStepf("Create Pull Request")
 
 		pr := github.PR{
 			Title:  codeFreezePRName,
 			Body:   fmt.Sprintf("This Pull Request freezes the branch `%s` for `v%s`", state.VitessRelease.ReleaseBranch, state.VitessRelease.Release),
-			Branch: newBranchName,
+			Branch: state.VitessRelease.HeadRef(newBranchName),
 			Base:   state.VitessRelease.ReleaseBranch,
 			Labels: []github.Label{{Name: "Component: General"}, {Name: "Type: Release"}},
 		}
-		nb, url = pr.Create(state.IssueLink, state.VitessRelease.Repo)
+
+		var reviewErr error
+		nb, url, reviewErr = pr.Create(state.IssueLink, state.VitessRelease.Repo)
 		pl.NewStepf("Pull Request created %s", url)
-		waitForPRToBeMerged(nb)
+		if reviewErr != nil {
+			pl.Warnf("%s", reviewErr)
+		}
 
-		done = true
+		done = waitForPRToBeMerged(nb)
+
+		if done {
+			NotifyFrozenPRs(pl, state)
+		}
 
 		return url
 	}
@@ -179,6 +200,9 @@ func activateCodeFreeze() {
 	changeCodeFreezeWorkflow(codeFreezeActivated)
 }
 
+// CodeFreezeFiles are the files modified when activating or deactivating the code freeze.
+var CodeFreezeFiles = []string{path.Clean(codeFreezeWorkflowFile)}
+
 func DeactivateCodeFreeze() {
 	changeCodeFreezeWorkflow(codeFreezeDeactivated)
 }
@@ -17,6 +17,8 @@ limitations under the License.
 package code_freeze
 
 import (
+	"strings"
+
 	"github.com/vitessio/vitess-releaser/go/releaser"
 	"github.com/vitessio/vitess-releaser/go/releaser/github"
 	"github.com/vitessio/vitess-releaser/go/releaser/logging"
@@ -41,8 +43,16 @@ func CopyBranchProtectionRules(state *releaser.State) (*logging.ProgressLogging,
 			return ""
 		}
 
-		pl.NewStepf("Duplicating the branch protection rules for %s", state.VitessRelease.BaseReleaseBranch)
-		github.CopyBranchProtectionRules(state.VitessRelease.Repo, state.VitessRelease.BaseReleaseBranch)
+		source := state.ProtectionSourceBranch
+		pl.NewStepf("Duplicating the branch protection rules of %s for %s", source, state.VitessRelease.BaseReleaseBranch)
+		diff := github.CopyBranchProtectionRules(state.VitessRelease.Repo, source, state.VitessRelease.BaseReleaseBranch)
+
+		pl.TotalSteps++
+		if len(diff) == 0 {
+			pl.NewStepf("The branch protection rules of %s and %s match", source, state.VitessRelease.BaseReleaseBranch)
+		} else {
+			pl.NewStepf("Differences between %s and %s: %s", source, state.VitessRelease.BaseReleaseBranch, strings.Join(diff, "; "))
+		}
 
 		return ""
 	}
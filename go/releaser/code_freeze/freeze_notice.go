@@ -0,0 +1,141 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package code_freeze
+
+import (
+	"bytes"
+	"errors"
+	"slices"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/vitessio/vitess-releaser/go/releaser"
+	"github.com/vitessio/vitess-releaser/go/releaser/github"
+	"github.com/vitessio/vitess-releaser/go/releaser/logging"
+	"github.com/vitessio/vitess-releaser/go/releaser/utils"
+)
+
+const (
+	freezeNoticeFrozen   = "frozen"
+	freezeNoticeUnfrozen = "unfrozen"
+
+	// releasePRLabel is carried by the Pull Requests created by the tool, they are never notified, nor are the Pull Requests of bots.
+	releasePRLabel = "Type: Release"
+
+	frozenNoticeTemplate = `Hello @{{.Author}} :wave:
+
+The branch ` + "`{{.Branch}}`" + ` is now frozen for the release of ` + "`v{{.Release}}`" + `, scheduled on {{.Date}}. Until the release is out, this Pull Request cannot be merged: the code freeze check of the CI fails on purpose.
+
+If this Pull Request must be part of ` + "`v{{.Release}}`" + `, please ask the release team for an exception on the release issue, {{.IssueLink}}, or in the ` + "`#release`" + ` channel of the Vitess Slack. Otherwise, no action is needed, you will be notified once the branch is unfrozen.
+`
+
+	unfrozenNoticeTemplate = `Hello @{{.Author}} :wave:
+
+The branch ` + "`{{.Branch}}`" + ` is being unfrozen by {{.ReleasePR}}, as part of the release of ` + "`v{{.Release}}`" + `. Once it is merged, this Pull Request can be merged again, the code freeze check of the CI will pass after a new run.
+`
+)
+
+type freezeNotice struct {
+	Author    string
+	Branch    string
+	Release   string
+	Date      string
+	IssueLink string
+	ReleasePR string
+}
+
+// NotifyFrozenPRs comments on the open Pull Requests targeting the frozen release branch to tell their authors
+// about the code freeze, the release date and how to request an exception. The Pull Requests already notified
+// are skipped, so the step can be run again. The steps are added on top of the total steps of pl.
+func NotifyFrozenPRs(pl *logging.ProgressLogging, state *releaser.State) {
+	notifyPRs(pl, state, freezeNoticeFrozen, frozenNoticeTemplate, 0, "")
+}
+
+// NotifyUnfrozenPRs comments on the open Pull Requests targeting the release branch to tell their authors
+// that the branch is unfrozen by the release Pull Request. The Pull Requests already notified are skipped,
+// so the step can be run again. The steps are added on top of the total steps of pl.
+func NotifyUnfrozenPRs(pl *logging.ProgressLogging, state *releaser.State, releasePRNb int, releasePRURL string) {
+	notifyPRs(pl, state, freezeNoticeUnfrozen, unfrozenNoticeTemplate, releasePRNb, releasePRURL)
+}
+
+func notifyPRs(pl *logging.ProgressLogging, state *releaser.State, notice, text string, releasePRNb int, releasePRURL string) {
+	branch := state.VitessRelease.ReleaseBranch
+
+	pl.SetTotalStep(pl.GetTotal() + 2)
+	pl.NewStepf("Look for open Pull Requests targeting %s to notify that it is %s", branch, notice)
+
+	var prs []github.PR
+
+	for _, pr := range github.GetOpenedPRsByBase(state.VitessRelease.Repo, branch) {
+		isReleasePR := pr.Number == releasePRNb || slices.ContainsFunc(pr.Labels, func(l github.Label) bool { return l.Name == releasePRLabel })
+		isBot := strings.HasPrefix(pr.Author.Login, "app/")
+
+		if isReleasePR || isBot || state.Issue.FreezeNotices[pr.Number] == notice {
+			continue
+		}
+
+		prs = append(prs, pr)
+	}
+
+	if len(prs) == 0 {
+		pl.NewStepf("No Pull Request to notify")
+		return
+	}
+
+	pl.NewStepf("Notify the authors of %d Pull Request(s)", len(prs))
+
+	t := template.Must(template.New(notice).Parse(text))
+
+	err := github.CommentOnPRs(state.VitessRelease.Repo, prs, func(pr github.PR) string {
+		b := bytes.NewBufferString("")
+
+		err := t.Execute(b, freezeNotice{
+			Author:    pr.Author.Login,
+			Branch:    branch,
+			Release:   state.VitessRelease.Release,
+			Date:      state.Issue.Date.Format(time.DateOnly),
+			IssueLink: state.IssueLink,
+			ReleasePR: releasePRURL,
+		})
+		if err != nil {
+			utils.BailOut(err, "failed to execute the %s notice template", notice)
+		}
+
+		return b.String()
+	}, pl.ItemProgress("Pull Requests"))
+
+	var bulkErr *github.BulkError
+	if err != nil && !errors.As(err, &bulkErr) {
+		utils.BailOut(err, "failed to notify the Pull Requests targeting %s", branch)
+	}
+
+	if state.Issue.FreezeNotices == nil {
+		state.Issue.FreezeNotices = map[int]string{}
+	}
+
+	for _, pr := range prs {
+		if bulkErr == nil || bulkErr.Failures[pr.Number] == nil {
+			state.Issue.FreezeNotices[pr.Number] = notice
+		}
+	}
+
+	if bulkErr != nil {
+		pl.SetTotalStep(pl.GetTotal() + 1)
+		pl.NewStepf("Warning: some authors could not be notified, %s", bulkErr)
+	}
+}
@@ -18,6 +18,7 @@ package code_freeze
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/vitessio/vitess-releaser/go/releaser"
 	"github.com/vitessio/vitess-releaser/go/releaser/github"
@@ -26,7 +27,7 @@ import (
 
 func NewMilestone(state *releaser.State) (*logging.ProgressLogging, func() string) {
 	pl := &logging.ProgressLogging{
-		TotalSteps: 5,
+		TotalSteps: 6,
 	}
 
 	// Two extra steps if we are doing an RC-1 release
@@ -60,7 +61,13 @@ func NewMilestone(state *releaser.State) (*logging.ProgressLogging, func() strin
 
 				if len(prs) > 0 {
 					pl.NewStepf("Move %d Pull Requests to the %s Milestone", len(prs), newMilestone)
-					github.AssignMilestoneToPRs(state.VitessRelease.Repo, newMilestone, prs)
+
+					// The Pull Requests that could not be moved are moved again when closing the milestone on the release day
+					err := github.AssignMilestoneToPRs(state.VitessRelease.Repo, newMilestone, prs, pl.ItemProgress("Pull Requests"))
+					if err != nil {
+						pl.SetTotalStep(pl.GetTotal() + 1)
+						pl.NewStepf("Warning: %s", err)
+					}
 				} else {
 					pl.NewStepf("No opened Pull Request found for Milestone %s, nothing to move", currentMilestone)
 				}
@@ -80,6 +87,15 @@ func NewMilestone(state *releaser.State) (*logging.ProgressLogging, func() strin
 			pl.NewStepf("Issue updated, see: %s", issueLink)
 		}()
 
+		// The milestone of the current release is due on the release date
+		currentMilestone := fmt.Sprintf("v%s", releaser.RemoveRCFromReleaseTitle(state.VitessRelease.Release))
+		if len(github.GetMilestonesByName(state.VitessRelease.Repo, currentMilestone)) > 0 {
+			pl.NewStepf("Set the due date of Milestone %s to %s", currentMilestone, state.Issue.Date.Format(time.DateOnly))
+			github.UpdateMilestoneDetails(state.VitessRelease.Repo, currentMilestone, milestoneDescription(state, currentMilestone), state.Issue.Date)
+		} else {
+			pl.NewStepf("Milestone %s not found, its due date is not set", currentMilestone)
+		}
+
 		ms := github.GetMilestonesByName(state.VitessRelease.Repo, newMilestone)
 		if len(ms) > 0 {
 			pl.TotalSteps -= 1 // we have one less step in this situation (not creating a new milestone)
@@ -90,9 +106,14 @@ func NewMilestone(state *releaser.State) (*logging.ProgressLogging, func() strin
 		}
 
 		pl.NewStepf("Creating Milestone %s on GitHub", newMilestone)
-		link = github.CreateNewMilestone(state.VitessRelease.Repo, newMilestone)
+		link = github.CreateNewMilestone(state.VitessRelease.Repo, newMilestone, milestoneDescription(state, newMilestone), time.Time{})
 		pl.NewStepf("New Milestone %s created: %s", newMilestone, link)
 
 		return link
 	}
 }
+
+// milestoneDescription links the milestone to the release issue during which it was created or updated.
+func milestoneDescription(state *releaser.State, milestone string) string {
+	return fmt.Sprintf("Milestone %s, managed by vitess-releaser during the release of v%s: %s", milestone, state.VitessRelease.Release, state.IssueLink)
+}
@@ -46,9 +46,10 @@ func UpdateSnapshotOnMain(state *releaser.State) (*logging.ProgressLogging, func
 		}()
 
 		pl.NewStepf("Fetch from git remote")
-		git.CorrectCleanRepo(state.VitessRelease.Repo)
-		git.Checkout("main")
-		git.ResetHard(state.VitessRelease.Remote, "main")
+		git.CorrectRepo(state.VitessRelease.Repo)
+
+		wt := git.NewWorktree(state.VitessRelease.Remote, "main")
+		defer wt.Remove()
 
 		nextNextRelease := releaser.FindVersionAfterNextRelease(state)
 		snapshotRelease := fmt.Sprintf("%s-SNAPSHOT", nextNextRelease)
@@ -68,7 +69,7 @@ func UpdateSnapshotOnMain(state *releaser.State) (*logging.ProgressLogging, func
 		}
 
 		pl.NewStepf("Create new branch based on %s/%s", state.VitessRelease.Remote, "main")
-		newBranchName := git.FindNewGeneratedBranch(state.VitessRelease.Remote, "main", "snapshot-update")
+		newBranchName := git.FindNewGeneratedBranch(state.VitessRelease.Remote, "main", git.BranchSnapshotUpdate)
 
 		pl.NewStepf("Update version.go")
 		releaser.UpdateVersionGoFile(snapshotRelease)
@@ -78,7 +79,7 @@ func UpdateSnapshotOnMain(state *releaser.State) (*logging.ProgressLogging, func
 
 		pl.NewStepf("Commit and push to branch %s", newBranchName)
 
-		if git.CommitAll(fmt.Sprintf("Snapshot update: %s", snapshotUpdatePRName)) {
+		if git.CommitAll(fmt.Sprintf("Snapshot update: %s", snapshotUpdatePRName), releaser.VersionFiles) {
 			pl.TotalSteps = 9 // only 9 total steps in this situation
 			pl.NewStepf("Nothing to commit, seems like back to dev mode is already done")
 
@@ -87,19 +88,26 @@ func UpdateSnapshotOnMain(state *releaser.State) (*logging.ProgressLogging, func
 			return ""
 		}
 
-		git.Push(state.VitessRelease.Remote, newBranchName)
+		if !state.VerifyAndPush(pl, state.VitessRelease.PushRemote, newBranchName) {
+			return ""
+		}
 
 		pl.NewStepf("Create Pull Request")
 
 		pr := github.PR{
 			Title:  snapshotUpdatePRName,
 			Body:   fmt.Sprintf("Includes the changes required to update the SNAPSHOT version (v%s) after the release of v%s.", snapshotRelease, state.VitessRelease.Release),
-			Branch: newBranchName,
+			Branch: state.VitessRelease.HeadRef(newBranchName),
 			Base:   "main",
 			Labels: []github.Label{{Name: "Component: General"}, {Name: "Type: Release"}},
 		}
-		_, url = pr.Create(state.IssueLink, state.VitessRelease.Repo)
+
+		var reviewErr error
+		_, url, reviewErr = pr.Create(state.IssueLink, state.VitessRelease.Repo)
 		pl.NewStepf("Pull Request created %s", url)
+		if reviewErr != nil {
+			pl.Warnf("%s", reviewErr)
+		}
 
 		done = true
 
@@ -19,6 +19,7 @@ package code_freeze
 import (
 	"fmt"
 	"os"
+	"path"
 	"time"
 
 	"github.com/vitessio/vitess-releaser/go/releaser"
@@ -81,8 +82,10 @@ func VtopBumpMainVersion(state *releaser.State) (*logging.ProgressLogging, func(
 		}()
 
 		pl.NewStepf("Fetch from git remote")
-		git.CorrectCleanRepo(state.VtOpRelease.Repo)
-		git.ResetHard(state.VtOpRelease.Remote, state.VtOpRelease.ReleaseBranch)
+		git.CorrectRepo(state.VtOpRelease.Repo)
+
+		wt := git.NewWorktree(state.VtOpRelease.Remote, "main")
+		defer wt.Remove()
 
 		bumpPRName := fmt.Sprintf("[main] Bump version.go to %s", state.VtOpRelease.Release)
 		pl.NewStepf("Look for an existing Release Pull Request named '%s'", bumpPRName)
@@ -98,25 +101,32 @@ func VtopBumpMainVersion(state *releaser.State) (*logging.ProgressLogging, func(
 
 		pl.NewStepf("Create temporary branch from main")
 
-		newBranchName := git.FindNewGeneratedBranch(state.VtOpRelease.Remote, "main", "bump-main-version")
+		newBranchName := git.FindNewGeneratedBranch(state.VtOpRelease.Remote, "main", git.BranchBumpMainVersion)
 
 		pl.NewStepf("Bump version.go to %s", state.VtOpRelease.Release)
 		UpdateVtOpVersionGoFile(state.VtOpRelease.Release)
 
-		if !git.CommitAll("Go back to dev mode") {
-			git.Push(state.VtOpRelease.Remote, newBranchName)
+		if !git.CommitAll("Go back to dev mode", VtOpVersionFiles) {
+			if !state.VerifyAndPush(pl, state.VtOpRelease.PushRemote, newBranchName) {
+				return ""
+			}
 
 			pl.NewStepf("Create Pull Request")
 
 			pr := github.PR{
 				Title:  bumpPRName,
 				Body:   fmt.Sprintf("This Pull Request bumps the version/version.go file to %s", state.VtOpRelease.Release),
-				Branch: newBranchName,
+				Branch: state.VtOpRelease.HeadRef(newBranchName),
 				Base:   "main",
 				Labels: []github.Label{},
 			}
-			_, url = pr.Create(state.IssueLink, state.VtOpRelease.Repo)
+
+			var reviewErr error
+			_, url, reviewErr = pr.Create(state.IssueLink, state.VtOpRelease.Repo)
 			pl.NewStepf("Pull Request created %s", url)
+			if reviewErr != nil {
+				pl.Warnf("%s", reviewErr)
+			}
 		} else {
 			pl.TotalSteps -= 2
 		}
@@ -127,6 +137,9 @@ func VtopBumpMainVersion(state *releaser.State) (*logging.ProgressLogging, func(
 	}
 }
 
+// VtOpVersionFiles are the files modified by UpdateVtOpVersionGoFile.
+var VtOpVersionFiles = []string{path.Clean(vtopVersionGoFile)}
+
 func UpdateVtOpVersionGoFile(newVersion string) {
 	err := os.WriteFile(vtopVersionGoFile, []byte(fmt.Sprintf(vtopVersionGo, time.Now().Year(), newVersion)), os.ModePerm)
 	if err != nil {
@@ -17,8 +17,6 @@ limitations under the License.
 package code_freeze
 
 import (
-	"fmt"
-
 	"github.com/vitessio/vitess-releaser/go/releaser"
 	"github.com/vitessio/vitess-releaser/go/releaser/git"
 	"github.com/vitessio/vitess-releaser/go/releaser/logging"
@@ -33,16 +31,11 @@ func VtopCreateBranch(state *releaser.State) (*logging.ProgressLogging, func() s
 		state.GoToVtOp()
 		defer state.GoToVitess()
 
-		git.CorrectCleanRepo(state.VtOpRelease.Repo)
+		git.CorrectRepo(state.VtOpRelease.Repo)
 		pl.NewStepf("Create branch %s", state.VtOpRelease.ReleaseBranch)
 
-		err := git.CreateBranchAndCheckout(state.VtOpRelease.ReleaseBranch, fmt.Sprintf("%s/main", state.VtOpRelease.Remote))
-		if err != nil {
-			git.Checkout(state.VtOpRelease.ReleaseBranch)
-			git.ResetHard(state.VtOpRelease.Remote, state.VtOpRelease.ReleaseBranch)
-		} else {
-			git.Push(state.VtOpRelease.Remote, state.VtOpRelease.ReleaseBranch)
-		}
+		git.FetchBranches(state.VtOpRelease.Remote, "main")
+		git.CreateRemoteBranch(state.VtOpRelease.Remote, state.VtOpRelease.ReleaseBranch, "main")
 
 		state.Issue.VtopCreateBranch = true
 		pl.NewStepf("Update Issue %s on GitHub", state.IssueLink)
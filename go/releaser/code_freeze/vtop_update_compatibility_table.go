@@ -20,12 +20,51 @@ import (
 	"fmt"
 
 	"github.com/vitessio/vitess-releaser/go/releaser"
+	"github.com/vitessio/vitess-releaser/go/releaser/logging"
 )
 
+const vtopCompatibilityTableBranch = "main"
+
 func VtopUpdateCompatibilityTable(state *releaser.State) []string {
 	return []string{
 		fmt.Sprintf("You open a Pull Request that updates the compatibility table found in the README of https://github.com/%s", state.VtOpRelease.Repo),
 		fmt.Sprintf("Add a new row before the last row. This new row should include the v%s vitess-operator release and the v%s.0.*, along with the matching K8S version.", state.VtOpRelease.Release, state.VitessRelease.MajorRelease),
-		fmt.Sprintf("Once the Pull Request, you may bypass the branch protection rules by changing the settings in https://github.com/%s/settings/branches", state.VtOpRelease.Repo),
+		fmt.Sprintf("Until this step is marked as done, the required reviews of %s can be bypassed and the rules do not apply to admins, they are restored afterward. The required status checks must still pass for non-admins.", vtopCompatibilityTableBranch),
+	}
+}
+
+// VtopBypassCompatibilityTableProtection lets the release team bypass the branch protection rules of
+// vitess-operator's main branch, so the Pull Request updating the compatibility table can be merged.
+func VtopBypassCompatibilityTableProtection(state *releaser.State) (*logging.ProgressLogging, func()) {
+	pl := &logging.ProgressLogging{
+		TotalSteps: 1,
+	}
+
+	return pl, func() {
+		state.BypassBranchProtection(pl, state.VtOpRelease.Repo, vtopCompatibilityTableBranch)
+		pl.NewStepf("Open and merge the Pull Request, then mark this step as done")
+	}
+}
+
+// VtopSetCompatibilityTableDone marks the step as done, or not, and restores the branch protection
+// rules of vitess-operator's main branch once it is done.
+func VtopSetCompatibilityTableDone(state *releaser.State, done bool) (*logging.ProgressLogging, func() string) {
+	pl := &logging.ProgressLogging{
+		TotalSteps: 2,
+	}
+
+	return pl, func() string {
+		state.Issue.VtopUpdateCompatibilityTable = done
+
+		if done {
+			state.RestoreBranchProtection(pl, state.VtOpRelease.Repo, vtopCompatibilityTableBranch)
+		}
+
+		pl.NewStepf("Update Issue %s on GitHub", state.IssueLink)
+		_, fn := state.UploadIssue()
+		issueLink := fn()
+		pl.NewStepf("Issue updated, see: %s", issueLink)
+
+		return issueLink
 	}
 }
@@ -0,0 +1,110 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import (
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/vitessio/vitess-releaser/go/releaser/utils"
+)
+
+// ChangedFile is a file modified, added, deleted or untracked in the working tree.
+type ChangedFile struct {
+	Status string
+	Path   string
+}
+
+// ChangedFiles lists the files that differ from HEAD in the working tree, including untracked files.
+func ChangedFiles() []ChangedFile {
+	out := utils.Exec("git", "status", "--porcelain=v1", "-z", "--untracked-files=all")
+
+	var files []ChangedFile
+
+	entries := strings.Split(out, "\x00")
+	for i := 0; i < len(entries); i++ {
+		entry := entries[i]
+		if len(entry) < 4 {
+			continue
+		}
+
+		status := entry[:2]
+		files = append(files, ChangedFile{Status: strings.TrimSpace(status), Path: entry[3:]})
+
+		// renames and copies are followed by the original path
+		if status[0] == 'R' || status[0] == 'C' {
+			i++
+		}
+	}
+
+	return files
+}
+
+// MatchPath reports whether the path matches the pattern. Patterns are relative to the root
+// of the repository and use the path.Match syntax, with "**" matching any number of directories,
+// i.e. "java/**/pom.xml" or "changelog/**".
+func MatchPath(pattern, p string) bool {
+	return matchSegments(strings.Split(path.Clean(pattern), "/"), strings.Split(path.Clean(p), "/"))
+}
+
+func matchSegments(pattern, p []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(p); i++ {
+				if matchSegments(pattern[1:], p[i:]) {
+					return true
+				}
+			}
+
+			return false
+		}
+
+		if len(p) == 0 {
+			return false
+		}
+
+		if ok, err := path.Match(pattern[0], p[0]); err != nil || !ok {
+			return false
+		}
+
+		pattern, p = pattern[1:], p[1:]
+	}
+
+	return len(p) == 0
+}
+
+// checkAllowedChanges stops the releaser if files outside the allowed paths were modified
+// or created, instead of committing them as part of an automated commit.
+func checkAllowedChanges(allowed []string) {
+	var unexpected []string
+
+	for _, f := range ChangedFiles() {
+		if !slices.ContainsFunc(allowed, func(pattern string) bool { return MatchPath(pattern, f.Path) }) {
+			unexpected = append(unexpected, f.Status+" "+f.Path)
+		}
+	}
+
+	if len(unexpected) == 0 {
+		return
+	}
+
+	utils.BailOut(nil,
+		"refusing to commit in %s, files outside of the allowed paths were modified:\n\t%s\nallowed paths:\n\t%s",
+		getWorkingDir(), strings.Join(unexpected, "\n\t"), strings.Join(allowed, "\n\t"),
+	)
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import "testing"
+
+func TestMatchPath(t *testing.T) {
+	tcs := []struct {
+		pattern, path string
+		match         bool
+	}{
+		// exact paths and path.Match syntax
+		{pattern: "go/vt/servenv/version.go", path: "go/vt/servenv/version.go", match: true},
+		{pattern: "go/vt/servenv/version.go", path: "go/vt/servenv/version_test.go"},
+		{pattern: "java/*/pom.xml", path: "java/client/pom.xml", match: true},
+		{pattern: "java/*/pom.xml", path: "java/grpc-client/src/pom.xml"},
+		{pattern: "*.go", path: "go/main.go"},
+
+		// "**" matches any number of directories
+		{pattern: "java/**/pom.xml", path: "java/pom.xml", match: true},
+		{pattern: "java/**/pom.xml", path: "java/client/pom.xml", match: true},
+		{pattern: "java/**/pom.xml", path: "java/grpc-client/src/pom.xml", match: true},
+		{pattern: "java/**/pom.xml", path: "java/client/pom.xml.bak"},
+		{pattern: "changelog/**", path: "changelog/21.0/21.0.0/summary.md", match: true},
+		{pattern: "changelog/**", path: "go/changelog/summary.md"},
+		{pattern: "**", path: "examples/compose/docker-compose.yml", match: true},
+		{pattern: "**/*.yaml", path: "test/config.yaml", match: true},
+		{pattern: "**/*.yaml", path: "config.yaml", match: true},
+
+		// "./" prefixes and redundant separators are ignored
+		{pattern: "./go/vt/servenv/version.go", path: "go/vt/servenv/version.go", match: true},
+		{pattern: "go/vt/servenv/version.go", path: "./go/vt/servenv/version.go", match: true},
+		{pattern: "./changelog//**", path: "changelog/21.0/README.md", match: true},
+
+		// a directory only matches its content through "**"
+		{pattern: "changelog/21.0", path: "changelog/21.0/README.md"},
+		{pattern: "changelog/21.0/", path: "changelog/21.0/README.md"},
+		{pattern: "changelog/21.0/**", path: "changelog/21.0", match: true},
+		{pattern: "changelog/21.0/**", path: "changelog/21.0/", match: true},
+		{pattern: "changelog/21.0/**", path: "changelog/21.00/README.md"},
+
+		// invalid patterns never match
+		{pattern: "go/[", path: "go/["},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.pattern+" "+tc.path, func(t *testing.T) {
+			if got := MatchPath(tc.pattern, tc.path); got != tc.match {
+				t.Fatalf("expected MatchPath(%q, %q) to be %t", tc.pattern, tc.path, tc.match)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"slices"
+
+	"github.com/vitessio/vitess-releaser/go/releaser/utils"
+)
+
+// EnsurePartialClone creates, or updates, a partial clone of a repository in dir with the given remotes.
+// The clone is created empty and blobs are only downloaded when needed: combined with targeted fetches,
+// we only download the history of the branches the release needs.
+func EnsurePartialClone(dir string, remotes map[string]string) {
+	if len(remotes) == 0 {
+		utils.BailOut(nil, "no remote to configure in %s", dir)
+	}
+
+	if _, err := os.Stat(dir); errors.Is(err, fs.ErrNotExist) {
+		utils.Exec("git", "init", "--quiet", dir)
+	} else if err != nil {
+		utils.BailOut(err, "failed to access the git cache directory %s", dir)
+	}
+
+	previousDir := getWorkingDir()
+	changeDir(dir)
+	defer changeDir(previousDir)
+
+	existing := ListRemotes()
+
+	names := make([]string, 0, len(remotes))
+	for name := range remotes {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	for _, name := range names {
+		switch current, ok := existing[name]; {
+		case !ok:
+			utils.Exec("git", "remote", "add", name, remotes[name])
+		case current != remotes[name]:
+			utils.Exec("git", "remote", "set-url", name, remotes[name])
+		}
+
+		utils.Exec("git", "config", "remote."+name+".promisor", "true")
+		utils.Exec("git", "config", "remote."+name+".partialclonefilter", "blob:none")
+	}
+
+	utils.Exec("git", "config", "core.repositoryformatversion", "1")
+	utils.Exec("git", "config", "extensions.partialClone", names[0])
+}
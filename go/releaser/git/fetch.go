@@ -0,0 +1,163 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/vitessio/vitess-releaser/go/releaser/utils"
+)
+
+// fetchCache remembers which refs were fetched during the session, along with how long it took to
+// fetch them, so we fetch each ref only once until it is explicitly invalidated.
+// Refs are identified by the git directory of the repository, the remote and the refspec.
+var fetchCache = struct {
+	mu      sync.Mutex
+	entries map[string]time.Duration
+	stats   FetchStatistics
+}{
+	entries: map[string]time.Duration{},
+}
+
+// FetchStatistics describes how many fetches were done during the session, and how
+// many were avoided thanks to the cache.
+type FetchStatistics struct {
+	Fetches   int
+	CacheHits int
+	Duration  time.Duration
+	Saved     time.Duration
+}
+
+func (fs FetchStatistics) String() string {
+	return fmt.Sprintf(
+		"git fetch: %d fetches (%s), %d served from cache (~%s saved)",
+		fs.Fetches, fs.Duration.Round(time.Millisecond), fs.CacheHits, fs.Saved.Round(time.Millisecond),
+	)
+}
+
+func FetchStats() FetchStatistics {
+	fetchCache.mu.Lock()
+	defer fetchCache.mu.Unlock()
+
+	return fetchCache.stats
+}
+
+// FetchBranches fetches only the given branches of the remote into the remote-tracking branches.
+// Branches already fetched during this session are not fetched again, unless they were invalidated.
+func FetchBranches(remote string, branches ...string) {
+	refspecs := make([]string, 0, len(branches))
+	for _, b := range branches {
+		refspecs = append(refspecs, fmt.Sprintf("+refs/heads/%s:refs/remotes/%s/%s", b, remote, b))
+	}
+
+	fetch(remote, refspecs)
+}
+
+// FetchTags fetches only the given tags of the remote.
+func FetchTags(remote string, tags ...string) {
+	refspecs := make([]string, 0, len(tags))
+	for _, t := range tags {
+		refspecs = append(refspecs, fmt.Sprintf("refs/tags/%s:refs/tags/%s", t, t))
+	}
+
+	fetch(remote, refspecs)
+}
+
+func fetch(remote string, refspecs []string) {
+	gitDir := commonGitDir()
+
+	fetchCache.mu.Lock()
+	defer fetchCache.mu.Unlock()
+
+	var toFetch []string
+
+	for _, refspec := range refspecs {
+		if d, ok := fetchCache.entries[fetchKey(gitDir, remote, refspec)]; ok {
+			fetchCache.stats.CacheHits++
+			fetchCache.stats.Saved += d
+
+			continue
+		}
+
+		toFetch = append(toFetch, refspec)
+	}
+
+	if len(toFetch) == 0 {
+		return
+	}
+
+	start := time.Now()
+
+	utils.Exec("git", append([]string{"fetch", "--no-tags", remote}, toFetch...)...)
+
+	d := time.Since(start)
+	fetchCache.stats.Fetches++
+	fetchCache.stats.Duration += d
+
+	for _, refspec := range toFetch {
+		fetchCache.entries[fetchKey(gitDir, remote, refspec)] = d
+	}
+}
+
+// InvalidateFetchCache forgets that the given branches of the remote were fetched, the next call to
+// FetchBranches will fetch them again. Without any branch, all the refs of the remote are invalidated.
+func InvalidateFetchCache(remote string, branches ...string) {
+	gitDir := commonGitDir()
+
+	fetchCache.mu.Lock()
+	defer fetchCache.mu.Unlock()
+
+	prefix := fetchKey(gitDir, remote, "")
+
+	for key := range fetchCache.entries {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+
+		if len(branches) == 0 {
+			delete(fetchCache.entries, key)
+			continue
+		}
+
+		for _, b := range branches {
+			if strings.HasPrefix(key, fmt.Sprintf("%s+refs/heads/%s:", prefix, b)) {
+				delete(fetchCache.entries, key)
+			}
+		}
+	}
+}
+
+// InvalidateAllFetches forgets all the refs fetched during the session, for instance
+// after waiting for a Pull Request to be merged.
+func InvalidateAllFetches() {
+	fetchCache.mu.Lock()
+	defer fetchCache.mu.Unlock()
+
+	clear(fetchCache.entries)
+}
+
+func fetchKey(gitDir, remote, refspec string) string {
+	return fmt.Sprintf("%s|%s|%s", gitDir, remote, refspec)
+}
+
+// commonGitDir returns the git directory shared by the current repository and all its worktrees.
+func commonGitDir() string {
+	return strings.TrimSpace(utils.Exec("git", "rev-parse", "--path-format=absolute", "--git-common-dir"))
+}
@@ -20,6 +20,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"regexp"
 	"strings"
 
 	"github.com/vitessio/vitess-releaser/go/releaser/utils"
@@ -27,23 +28,58 @@ import (
 
 var errBranchExists = fmt.Errorf("branch already exists")
 
-func checkCurrentRepo(repoWanted string) bool {
-	out := utils.Exec("git", "remote", "-v")
-	return strings.Contains(out, repoWanted)
+// Suffixes given to FindNewGeneratedBranch, the branches it creates are named <base>-<suffix>-<n>.
+const (
+	BranchCodeFreeze      = "code-freeze"
+	BranchSnapshotUpdate  = "snapshot-update"
+	BranchBumpMainVersion = "bump-main-version"
+	BranchCreateRelease   = "create-release"
+	BranchGoUpgrade       = "go-upgrade"
+	BranchBackToDevMode   = "back-to-dev-mode"
+	BranchBackToDev       = "back-to-dev"
+
+	// BranchReleaseNotes is followed by the branch on which the release notes are copied.
+	BranchReleaseNotes = "release-notes"
+)
+
+// ShowFile returns the content of a file on remote/branch without checking out the branch.
+// The branch must have been fetched beforehand.
+func ShowFile(remote, branch, path string) string {
+	return ShowFileAtRef(fmt.Sprintf("%s/%s", remote, branch), path)
 }
 
-func cleanLocalState() bool {
-	out := utils.Exec("git", "status", "-s")
-	return len(out) == 0
+// ShowFileAtRef returns the content of a file at the given git ref, i.e. a commit SHA.
+func ShowFileAtRef(ref, path string) string {
+	return utils.Exec("git", "show", fmt.Sprintf("%s:%s", ref, strings.TrimPrefix(path, "./")))
 }
 
-func Checkout(branch string) {
-	utils.Exec("git", "checkout", branch)
+// IsAncestor returns true if the commit is part of the history of the given ref.
+func IsAncestor(commit, ref string) bool {
+	_, err := utils.ExecWithError("git", "merge-base", "--is-ancestor", commit, ref)
+	return err == nil
 }
 
-func ResetHard(remote, branch string) {
-	utils.Exec("git", "fetch", remote)
-	utils.Exec("git", "reset", "--hard", remote+"/"+branch)
+// RemoteBranchExists returns true if the branch exists on the remote.
+func RemoteBranchExists(remote, branch string) bool {
+	out := utils.Exec("git", "ls-remote", "--heads", remote, branch)
+	return len(strings.TrimSpace(out)) > 0
+}
+
+// CreateRemoteBranch creates the branch on the remote from the latest commit of remote/base, without
+// creating a local branch. Nothing is done if the branch already exists on the remote.
+func CreateRemoteBranch(remote, branch, base string) {
+	if RemoteBranchExists(remote, branch) {
+		return
+	}
+
+	// the remote-tracking ref may be stale, or missing from a new cache, the branch is cut from the current base
+	InvalidateFetchCache(remote, base)
+	FetchBranches(remote, base)
+
+	sha := strings.TrimSpace(utils.Exec("git", "rev-parse", "--verify", fmt.Sprintf("refs/remotes/%s/%s^{commit}", remote, base)))
+
+	utils.Exec("git", "push", remote, fmt.Sprintf("%s:refs/heads/%s", sha, branch))
+	InvalidateFetchCache(remote, branch)
 }
 
 func CreateBranchAndCheckout(branch, base string) error {
@@ -61,13 +97,17 @@ func CreateBranchAndCheckout(branch, base string) error {
 
 func Push(remote, branch string) {
 	utils.Exec("git", "push", remote, branch)
+	InvalidateFetchCache(remote, branch)
 }
 
-func CommitAll(msg string) (empty bool) {
+// CommitAll commits all the changes of the working tree. Only the paths matching one of the
+// allowed patterns (see MatchPath) may be modified, otherwise we stop before committing anything.
+func CommitAll(msg string, allowed []string) (empty bool) {
+	checkAllowedChanges(allowed)
+
 	utils.Exec("git", "add", "--all")
 
-	out, err := utils.ExecWithError(
-		"git",
+	args := []string{
 		"commit",
 		"-n",
 		"-s",
@@ -77,7 +117,13 @@ func CommitAll(msg string) (empty bool) {
 		"This commit was made automatically by the vitess-releaser tool.",
 		"-m",
 		"See https://github.com/vitessio/vitess-releaser",
-	)
+	}
+
+	if SigningEnabled() {
+		args = append(args, "-S")
+	}
+
+	out, err := utils.ExecWithError("git", gitArgs(args...)...)
 	if err != nil {
 		if strings.Contains(out, "nothing to commit, working tree clean") {
 			return true
@@ -89,43 +135,80 @@ func CommitAll(msg string) (empty bool) {
 	return false
 }
 
-// FindRemoteName takes the output of `git remote -v` and a repository name,
-// and returns the name of the remote associated with that repository.
-// If no remote is found, an empty string is returned.
-func FindRemoteName(repository string) string {
-	out := utils.Exec("git", "remote", "-v")
+// CorrectRepo makes sure the current directory is a clone of the given repository.
+// The clone does not need to be clean: all the changes are made in temporary worktrees.
+func CorrectRepo(repo string) {
+	if FindRemoteName(repo) == "" {
+		utils.BailOut(nil, "failed to find remote %s in %s", repo, getWorkingDir())
+	}
+}
 
-	lines := strings.Split(out, "\n")
-	for _, line := range lines {
-		parts := strings.Fields(line)
-		if len(parts) >= 2 {
-			remoteName, remoteURL := parts[0], parts[1]
-			if strings.Contains(remoteURL, repository) {
-				return remoteName
-			}
-		}
+func getWorkingDir() string {
+	dir, err := os.Getwd()
+	if err != nil {
+		utils.BailOut(err, "failed to find the current working dir")
 	}
 
-	return ""
+	return dir
 }
 
-func CorrectCleanRepo(repo string) {
-	if !checkCurrentRepo(repo + ".git") {
-		utils.BailOut(nil, "failed to find remote %s in %s", repo, getWorkingDir())
+// generatedBranchRegexp matches the branches created by FindNewGeneratedBranch, i.e. release-21.0-code-freeze-1.
+var generatedBranchRegexp = func() *regexp.Regexp {
+	const base = `(main|release-[0-9.]+(-rc)?)`
+
+	suffixes := []string{BranchReleaseNotes + "-" + base}
+	for _, suffix := range []string{BranchCodeFreeze, BranchSnapshotUpdate, BranchBumpMainVersion, BranchCreateRelease, BranchGoUpgrade, BranchBackToDevMode, BranchBackToDev} {
+		suffixes = append(suffixes, regexp.QuoteMeta(suffix))
 	}
 
-	if !cleanLocalState() {
-		utils.BailOut(nil, "the %s repository should have a clean state", getWorkingDir())
+	return regexp.MustCompile(fmt.Sprintf(`^%s-(%s)-[0-9]+$`, base, strings.Join(suffixes, "|")))
+}()
+
+// IsGeneratedBranch returns true if the branch was created by FindNewGeneratedBranch.
+func IsGeneratedBranch(branch string) bool {
+	return generatedBranchRegexp.MatchString(branch)
+}
+
+// ListRemoteBranches returns the name of all the branches found on the remote.
+func ListRemoteBranches(remote string) []string {
+	out := utils.Exec("git", "ls-remote", "--heads", remote)
+
+	var branches []string
+
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+
+		branches = append(branches, strings.TrimPrefix(fields[1], "refs/heads/"))
 	}
+
+	return branches
 }
 
-func getWorkingDir() string {
-	dir, err := os.Getwd()
+// ListLocalBranches returns the name of all the local branches.
+func ListLocalBranches() []string {
+	out := utils.Exec("git", "for-each-ref", "--format=%(refname:short)", "refs/heads")
+	return strings.Fields(out)
+}
+
+func DeleteLocalBranch(branch string) error {
+	out, err := utils.ExecWithError("git", "branch", "-D", branch)
 	if err != nil {
-		utils.BailOut(err, "failed to find the current working dir")
+		return fmt.Errorf("%w, got: %s", err, out)
 	}
 
-	return dir
+	return nil
+}
+
+func DeleteRemoteBranch(remote, branch string) error {
+	out, err := utils.ExecWithError("git", "push", remote, "--delete", branch)
+	if err != nil {
+		return fmt.Errorf("%w, got: %s", err, out)
+	}
+
+	return nil
 }
 
 func FindNewGeneratedBranch(remote, baseBranch, branchName string) string {
@@ -151,19 +234,78 @@ func FindNewGeneratedBranch(remote, baseBranch, branchName string) string {
 	return newBranch
 }
 
-func TagAndPush(remote, tag string) (exists bool) {
-	out, err := utils.ExecWithError("git", "tag", tag)
-	if err != nil {
-		if strings.Contains(out, "already exists") {
-			return true
+// TagAndPush creates an annotated tag on the target commit with the given message and pushes it.
+// The tag is signed if signing is enabled. If the tag already exists, locally or on the remote,
+// it must point to the target commit, otherwise we bail out.
+func TagAndPush(remote, tag, target, msg string) (exists bool) {
+	localTarget := LocalTagTarget(tag)
+	remoteTarget := RemoteTagTarget(remote, tag)
+
+	for _, t := range []string{localTarget, remoteTarget} {
+		if t != "" && t != target {
+			utils.BailOut(nil, "tag %s already exists and points to %s instead of %s", tag, t, target)
 		}
+	}
 
-		utils.BailOut(err, "got: %s", out)
+	if remoteTarget != "" {
+		if localTarget == "" {
+			utils.Exec("git", "fetch", remote, fmt.Sprintf("refs/tags/%s:refs/tags/%s", tag, tag))
+		}
+
+		return true
+	}
+
+	if localTarget == "" {
+		args := []string{"tag", "-a", tag, "-m", msg}
+		if SigningEnabled() {
+			args = append(args, "-s")
+		}
+
+		out, err := utils.ExecWithError("git", gitArgs(append(args, target)...)...)
+		if err != nil {
+			utils.BailOut(err, "got: %s", out)
+		}
 	}
 
 	utils.Exec("git", "push", remote, tag)
 
-	return false
+	return localTarget != ""
+}
+
+// LocalTagTarget returns the commit targeted by the local tag, or an empty string if the tag does not exist.
+func LocalTagTarget(tag string) string {
+	out, err := utils.ExecWithError("git", "rev-parse", "-q", "--verify", fmt.Sprintf("refs/tags/%s^{commit}", tag))
+	if err != nil {
+		return ""
+	}
+
+	return strings.TrimSpace(out)
+}
+
+// RemoteTagTarget returns the commit targeted by the tag on the remote, or an empty string if the tag does not exist.
+func RemoteTagTarget(remote, tag string) string {
+	ref := "refs/tags/" + tag
+	out := utils.Exec("git", "ls-remote", "--tags", remote, ref, ref+"^{}")
+
+	var target string
+
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+
+		switch fields[1] {
+		case ref + "^{}":
+			// the peeled commit of an annotated tag
+			return fields[0]
+		case ref:
+			// the commit of a lightweight tag, or the tag object of an annotated tag
+			target = fields[0]
+		}
+	}
+
+	return target
 }
 
 func GetSHAForGitRef(ref string) string {
@@ -174,3 +316,9 @@ func GetSHAForGitRef(ref string) string {
 func CheckoutPath(remote, branch, path string) {
 	utils.Exec("git", "checkout", fmt.Sprintf("%s/%s", remote, branch), path)
 }
+
+// ChangedFilesInLastCommit returns the path of the files modified by the HEAD commit.
+func ChangedFilesInLastCommit() []string {
+	out := utils.Exec("git", "diff", "--name-only", "HEAD~1", "HEAD")
+	return strings.Fields(out)
+}
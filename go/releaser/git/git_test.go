@@ -0,0 +1,103 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/vitessio/vitess-releaser/go/releaser/utils"
+)
+
+func TestIsGeneratedBranch(t *testing.T) {
+	tcs := []struct {
+		branch    string
+		generated bool
+	}{
+		{branch: "release-21.0-code-freeze-1", generated: true},
+		{branch: "release-21.0-rc-create-release-2", generated: true},
+		{branch: "main-snapshot-update-1", generated: true},
+		{branch: "main-bump-main-version-3", generated: true},
+		{branch: "release-2.14-back-to-dev-1", generated: true},
+		{branch: "release-21.0-back-to-dev-mode-12", generated: true},
+		{branch: "release-21.0-go-upgrade-1", generated: true},
+		{branch: "main-release-notes-main-1", generated: true},
+		{branch: "release-21.0-release-notes-release-21.0-1", generated: true},
+
+		// Branches pushed by humans on the same remote
+		{branch: "main-fix-flaky-test-2"},
+		{branch: "release-21.0-backport-12345-1"},
+		{branch: "release-21.0-code-freeze"},
+		{branch: "release-21.0"},
+		{branch: "release-21.0-rc"},
+		{branch: "feature-code-freeze-1"},
+		{branch: "main-release-notes-feature-1"},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.branch, func(t *testing.T) {
+			if got := IsGeneratedBranch(tc.branch); got != tc.generated {
+				t.Fatalf("expected IsGeneratedBranch(%q) to be %t", tc.branch, tc.generated)
+			}
+		})
+	}
+}
+
+func TestRemoteTagTarget(t *testing.T) {
+	newRepo(t)
+
+	commit := func(msg string) string {
+		utils.Exec("git", "commit", "--quiet", "--allow-empty", "-m", msg)
+		return GetSHAForGitRef("HEAD")
+	}
+
+	annotated := commit("release")
+	utils.Exec("git", "tag", "-a", "v21.0.0", "-m", "Release of v21.0.0")
+
+	lightweight := commit("java release")
+	utils.Exec("git", "tag", "java/v21.0.0")
+
+	// The similar tags must not be mistaken for the one we look for
+	commit("next release")
+	utils.Exec("git", "tag", "v21.0.0-rc1")
+
+	// The repository is its own remote, ls-remote lists both the tag object and the peeled commit of annotated tags
+	if out := utils.Exec("git", "ls-remote", "--tags", ".", "refs/tags/v21.0.0", "refs/tags/v21.0.0^{}"); strings.Count(out, "\n") != 2 {
+		t.Fatalf("expected the tag object and the peeled commit, got:\n%s", out)
+	}
+
+	tcs := []struct {
+		tag  string
+		want string
+	}{
+		{tag: "v21.0.0", want: annotated},
+		{tag: "java/v21.0.0", want: lightweight},
+		{tag: "v22.0.0", want: ""},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.tag, func(t *testing.T) {
+			if got := RemoteTagTarget(".", tc.tag); got != tc.want {
+				t.Fatalf("expected %q, got %q", tc.want, got)
+			}
+
+			if got := LocalTagTarget(tc.tag); got != tc.want {
+				t.Fatalf("expected the local tag to target %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/vitessio/vitess-releaser/go/releaser/utils"
+)
+
+// ParseRemoteURL extracts the owner and the name of the GitHub repository from a git remote URL.
+// The following formats are supported:
+//   - git@github.com:owner/repo.git
+//   - ssh://git@github.com/owner/repo.git
+//   - https://github.com/owner/repo.git
+//
+// The ".git" suffix and a trailing slash are optional.
+func ParseRemoteURL(remoteURL string) (owner, repo string, ok bool) {
+	p := remoteURL
+
+	if u, err := url.Parse(remoteURL); err == nil && u.Scheme != "" && u.Host != "" {
+		p = u.Path
+	} else if _, after, found := strings.Cut(remoteURL, ":"); found && !strings.Contains(remoteURL, "://") {
+		// scp-like syntax: [user@]host:owner/repo
+		p = after
+	}
+
+	p = strings.TrimSuffix(strings.Trim(p, "/"), ".git")
+
+	owner, repo, found := strings.Cut(p, "/")
+	if !found || owner == "" || repo == "" || strings.Contains(repo, "/") {
+		return "", "", false
+	}
+
+	return owner, repo, true
+}
+
+// FindRemoteName returns the name of the remote whose URL points to the given repository ("owner/repo").
+// If no remote is found, an empty string is returned.
+func FindRemoteName(repository string) string {
+	out := utils.Exec("git", "remote", "-v")
+
+	for _, line := range strings.Split(out, "\n") {
+		parts := strings.Fields(line)
+		if len(parts) < 2 {
+			continue
+		}
+
+		owner, repo, ok := ParseRemoteURL(parts[1])
+		if ok && strings.EqualFold(owner+"/"+repo, repository) {
+			return parts[0]
+		}
+	}
+
+	return ""
+}
+
+// RemoteRepository returns the owner and the name of the GitHub repository the given remote points to.
+func RemoteRepository(remote string) (owner, repo string) {
+	out, err := utils.ExecWithError("git", "remote", "get-url", remote)
+	if err != nil {
+		utils.BailOut(err, "remote %s not found in %s, got: %s", remote, getWorkingDir(), out)
+	}
+
+	owner, repo, ok := ParseRemoteURL(strings.TrimSpace(out))
+	if !ok {
+		utils.BailOut(nil, "failed to parse the URL of remote %s: %s", remote, out)
+	}
+
+	return owner, repo
+}
+
+// ListRemotes returns the fetch URL of each remote of the current repository, indexed by the name of the remote.
+func ListRemotes() map[string]string {
+	out := utils.Exec("git", "remote", "-v")
+
+	remotes := map[string]string{}
+
+	for _, line := range strings.Split(out, "\n") {
+		parts := strings.Fields(line)
+		if len(parts) < 3 || parts[2] != "(fetch)" {
+			continue
+		}
+
+		remotes[parts[0]] = parts[1]
+	}
+
+	return remotes
+}
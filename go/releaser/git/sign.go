@@ -0,0 +1,112 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import (
+	"os"
+	"strings"
+
+	"github.com/vitessio/vitess-releaser/go/releaser/utils"
+)
+
+const (
+	SignFormatGPG = "openpgp"
+	SignFormatSSH = "ssh"
+)
+
+// signFormat is the format used to sign commits and tags, signing is disabled when empty.
+var signFormat string
+
+// EnableSigning makes all the commits and tags created by the releaser signed using the
+// given format ("gpg" or "ssh"). The key is the one configured with git's user.signingkey.
+// SSH signatures can only be verified against git's gpg.ssh.allowedSignersFile, which must
+// exist so that VerifyPushedTag does not fail once the tag is already pushed.
+func EnableSigning(format string) {
+	switch strings.ToLower(format) {
+	case "":
+		signFormat = ""
+	case "gpg", SignFormatGPG:
+		signFormat = SignFormatGPG
+	case SignFormatSSH:
+		checkAllowedSigners()
+		signFormat = SignFormatSSH
+	default:
+		utils.BailOut(nil, "unknown signing format '%s', expected 'gpg' or 'ssh'", format)
+	}
+}
+
+// checkAllowedSigners makes sure git's gpg.ssh.allowedSignersFile is set and lists at least one key.
+func checkAllowedSigners() {
+	out, err := utils.ExecWithError("git", "config", "--type=path", "--get", "gpg.ssh.allowedSignersFile")
+	file := strings.TrimSpace(out)
+	if err != nil || file == "" {
+		utils.BailOut(err, "signing with ssh requires git's gpg.ssh.allowedSignersFile to be set to verify the signed tags")
+	}
+
+	content, err := os.ReadFile(file)
+	if err != nil {
+		utils.BailOut(err, "failed to read the allowed signers file %s", file)
+	}
+
+	if strings.TrimSpace(string(content)) == "" {
+		utils.BailOut(nil, "the allowed signers file %s is empty, add your signing key to it to verify the signed tags", file)
+	}
+}
+
+func SigningEnabled() bool {
+	return signFormat != ""
+}
+
+// gitArgs prefixes the given git sub-command with the configuration required for signing.
+func gitArgs(args ...string) []string {
+	if !SigningEnabled() {
+		return args
+	}
+
+	return append([]string{"-c", "gpg.format=" + signFormat}, args...)
+}
+
+// VerifyPushedTag makes sure the tag found on the remote is the same tag object as the one we
+// created locally, that it points to the expected commit and, if signing is enabled, that its
+// signature is valid.
+func VerifyPushedTag(remote, tag, expectedTarget string) {
+	out := utils.Exec("git", "ls-remote", "--tags", remote, "refs/tags/"+tag)
+
+	fields := strings.Fields(out)
+	if len(fields) < 2 {
+		utils.BailOut(nil, "tag %s was not found on remote %s", tag, remote)
+	}
+
+	localTagObject := GetSHAForGitRef("refs/tags/" + tag)
+	if fields[0] != localTagObject {
+		utils.BailOut(nil, "tag %s on remote %s (%s) does not match the local tag (%s)", tag, remote, fields[0], localTagObject)
+	}
+
+	target := GetSHAForGitRef(tag + "^{commit}")
+	if target != expectedTarget {
+		utils.BailOut(nil, "tag %s points to %s, expected %s", tag, target, expectedTarget)
+	}
+
+	if !SigningEnabled() {
+		return
+	}
+
+	out, err := utils.ExecWithError("git", gitArgs("verify-tag", tag)...)
+	if err != nil {
+		utils.BailOut(err, "failed to verify the signature of tag %s, got: %s", tag, out)
+	}
+}
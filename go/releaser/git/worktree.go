@@ -0,0 +1,176 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/vitessio/vitess-releaser/go/releaser/utils"
+)
+
+const (
+	worktreePrefix = "vitess-releaser-"
+
+	// worktreeLockReason is the reason with which the worktrees are locked, followed by the PID of the
+	// releaser that owns them, so that a session never removes the worktrees of another live session.
+	worktreeLockReason = "in use by vitess-releaser, pid "
+)
+
+// Worktree is a temporary git worktree in which the releaser makes its changes,
+// this way the user's checkout and current branch are never modified.
+type Worktree struct {
+	Path        string
+	previousDir string
+}
+
+// NewWorktree fetches the branch and creates a temporary worktree detached on remote/branch.
+// The current working directory is moved to the new worktree until Remove is called.
+// The branch is always fetched again: changes are based on its latest commit, even if
+// other people pushed to it since it was last fetched.
+func NewWorktree(remote, branch string) *Worktree {
+	InvalidateFetchCache(remote, branch)
+	FetchBranches(remote, branch)
+
+	return NewWorktreeForRef(remote + "/" + branch)
+}
+
+// NewWorktreeForRef creates a temporary worktree detached on the given ref, i.e. a tag.
+// The current working directory is moved to the new worktree until Remove is called.
+func NewWorktreeForRef(ref string) *Worktree {
+	dir, err := os.MkdirTemp("", worktreePrefix)
+	if err != nil {
+		utils.BailOut(err, "failed to create a temporary directory for the worktree")
+	}
+
+	utils.Exec("git", "worktree", "add", "--detach", "--lock", "--reason", fmt.Sprintf("%s%d", worktreeLockReason, os.Getpid()), dir, ref)
+
+	wt := &Worktree{
+		Path:        dir,
+		previousDir: getWorkingDir(),
+	}
+
+	changeDir(dir)
+
+	return wt
+}
+
+// Remove goes back to the directory we were in before creating the worktree and deletes the worktree.
+func (w *Worktree) Remove() {
+	changeDir(w.previousDir)
+	removeWorktree(w.Path)
+}
+
+// removeWorktree deletes the worktree even if it is locked or has local changes.
+func removeWorktree(dir string) {
+	utils.Exec("git", "worktree", "remove", "--force", "--force", dir)
+}
+
+// PruneWorktrees removes the temporary worktrees left behind by a previous run of the releaser,
+// for instance if it bailed out mid-step before removing them, and the administrative files of
+// the worktrees that no longer exist. The worktrees of the releasers still running are kept.
+func PruneWorktrees() {
+	for _, dir := range leftoverWorktrees() {
+		removeWorktree(dir)
+	}
+
+	utils.Exec("git", "worktree", "prune")
+}
+
+// leftoverWorktrees returns the worktrees of the repository created by NewWorktreeForRef
+// whose owner is no longer running.
+func leftoverWorktrees() []string {
+	tmpDir := resolvePath(os.TempDir())
+
+	var dirs []string
+
+	// Each worktree is a block of lines separated by an empty line, starting with "worktree <path>"
+	for _, block := range strings.Split(utils.Exec("git", "worktree", "list", "--porcelain"), "\n\n") {
+		var dir, reason string
+
+		for _, line := range strings.Split(block, "\n") {
+			if path, ok := strings.CutPrefix(line, "worktree "); ok {
+				dir = path
+			}
+
+			if locked, ok := strings.CutPrefix(line, "locked "); ok {
+				reason = locked
+			}
+		}
+
+		if !strings.HasPrefix(filepath.Base(dir), worktreePrefix) || resolvePath(filepath.Dir(dir)) != tmpDir {
+			continue
+		}
+
+		if pid, ok := worktreeOwner(reason); ok && processRunning(pid) {
+			continue
+		}
+
+		dirs = append(dirs, dir)
+	}
+
+	return dirs
+}
+
+// worktreeOwner returns the PID of the releaser that locked the worktree with the given reason.
+func worktreeOwner(reason string) (int, bool) {
+	pidStr, ok := strings.CutPrefix(reason, worktreeLockReason)
+	if !ok {
+		return 0, false
+	}
+
+	pid, err := strconv.Atoi(pidStr)
+	if err != nil {
+		return 0, false
+	}
+
+	return pid, true
+}
+
+// processRunning returns whether a process with the given PID exists. A process owned by
+// another user cannot be signaled, but it exists.
+func processRunning(pid int) bool {
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+
+	err = process.Signal(syscall.Signal(0))
+
+	return err == nil || errors.Is(err, syscall.EPERM)
+}
+
+// resolvePath follows the symbolic links of the path, i.e. /var and /private/var on macOS.
+func resolvePath(p string) string {
+	if resolved, err := filepath.EvalSymlinks(p); err == nil {
+		return resolved
+	}
+
+	return filepath.Clean(p)
+}
+
+func changeDir(dir string) {
+	err := os.Chdir(dir)
+	if err != nil {
+		utils.BailOut(err, "failed to change directory to %s", dir)
+	}
+}
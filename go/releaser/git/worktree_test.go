@@ -0,0 +1,130 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/vitessio/vitess-releaser/go/releaser/utils"
+)
+
+// newRepo creates a git repository with a single commit and moves to it. The temporary
+// directory used for the worktrees is moved under the test's directory.
+func newRepo(t *testing.T) {
+	t.Helper()
+
+	t.Setenv("TMPDIR", t.TempDir())
+	t.Setenv("GIT_CONFIG_GLOBAL", os.DevNull)
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+
+	for _, who := range []string{"AUTHOR", "COMMITTER"} {
+		t.Setenv("GIT_"+who+"_NAME", "releaser")
+		t.Setenv("GIT_"+who+"_EMAIL", "releaser@vitess.io")
+	}
+
+	t.Chdir(t.TempDir())
+
+	utils.Exec("git", "init", "--quiet")
+	utils.Exec("git", "commit", "--quiet", "--allow-empty", "-m", "initial commit")
+}
+
+// deadPID returns the PID of a process that already exited.
+func deadPID(t *testing.T) int {
+	t.Helper()
+
+	cmd := exec.Command("true")
+	if err := cmd.Run(); err != nil {
+		t.Fatal(err)
+	}
+
+	return cmd.Process.Pid
+}
+
+func TestPruneWorktrees(t *testing.T) {
+	newRepo(t)
+
+	addWorktree := func(lockArgs ...string) string {
+		dir, err := os.MkdirTemp("", worktreePrefix)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		utils.Exec("git", append(append([]string{"worktree", "add", "--detach"}, lockArgs...), dir, "HEAD")...)
+
+		return dir
+	}
+
+	crashed := addWorktree("--lock", "--reason", fmt.Sprintf("%s%d", worktreeLockReason, deadPID(t)))
+	unlocked := addWorktree()
+	live := addWorktree("--lock", "--reason", fmt.Sprintf("%s%d", worktreeLockReason, os.Getpid()))
+
+	// A worktree that is not ours is never removed, even if it is in the temporary directory
+	other, err := os.MkdirTemp("", "other-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	utils.Exec("git", "worktree", "add", "--detach", other, "HEAD")
+
+	PruneWorktrees()
+
+	for dir, kept := range map[string]bool{crashed: false, unlocked: false, live: true, other: true} {
+		if _, err := os.Stat(filepath.Join(dir, ".git")); (err == nil) != kept {
+			t.Fatalf("expected %s to be kept: %t, got %v", dir, kept, err)
+		}
+	}
+
+	// The worktree of the current session is still removed once done with it
+	wt := NewWorktreeForRef("HEAD")
+	if dirs := leftoverWorktrees(); slices.Contains(dirs, wt.Path) {
+		t.Fatalf("expected the worktree of the current session not to be a leftover, got %q", dirs)
+	}
+
+	wt.Remove()
+
+	if _, err := os.Stat(wt.Path); !os.IsNotExist(err) {
+		t.Fatalf("expected the worktree to be removed, got %v", err)
+	}
+}
+
+func TestNewWorktreeFetchesLatest(t *testing.T) {
+	newRepo(t)
+	t.Cleanup(InvalidateAllFetches)
+
+	upstream := t.TempDir()
+	utils.Exec("git", "init", "--quiet", "--initial-branch=main", upstream)
+	utils.Exec("git", "-C", upstream, "commit", "--quiet", "--allow-empty", "-m", "first commit")
+	utils.Exec("git", "remote", "add", "origin", upstream)
+
+	FetchBranches("origin", "main")
+
+	// Someone else pushes to the branch after the tool fetched it
+	utils.Exec("git", "-C", upstream, "commit", "--quiet", "--allow-empty", "-m", "second commit")
+	latest := strings.TrimSpace(utils.Exec("git", "-C", upstream, "rev-parse", "HEAD"))
+
+	wt := NewWorktree("origin", "main")
+	defer wt.Remove()
+
+	if head := GetSHAForGitRef("HEAD"); head != latest {
+		t.Fatalf("expected the worktree to be created from the latest commit %s, got %s", latest, head)
+	}
+}
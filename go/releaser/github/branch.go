@@ -17,16 +17,22 @@ limitations under the License.
 package github
 
 import (
+	"cmp"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"os"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
 
 	"github.com/vitessio/vitess-releaser/go/releaser/utils"
 )
 
 // Types to match what we receive from GitHub when fetching the branch protection rules.
 
-type fetchBranchProtectionRules struct {
+// BranchProtection is the protection of a branch, as returned by the API.
+type BranchProtection struct {
 	AllowDeletions                 fetchEnabled              `json:"allow_deletions"`
 	AllowForcePushes               fetchEnabled              `json:"allow_force_pushes"`
 	AllowForkSyncing               fetchEnabled              `json:"allow_fork_syncing"`
@@ -40,6 +46,9 @@ type fetchBranchProtectionRules struct {
 	RequiredStatusChecks           fetchRequiredStatusChecks `json:"required_status_checks"`
 	Restrictions                   fetchRestrictions         `json:"restrictions"`
 	Url                            string                    `json:"url"`
+
+	// Raw is the protection as returned by the API, including the fields not decoded above.
+	Raw json.RawMessage `json:"-"`
 }
 
 type fetchEnabled struct {
@@ -52,7 +61,7 @@ type fetchEnabledURL struct {
 }
 
 type fetchStatusCheck struct {
-	AppId   int    `json:"app_id"`
+	AppId   *int   `json:"app_id"`
 	Context string `json:"context"`
 }
 
@@ -65,13 +74,13 @@ type fetchRequiredStatusChecks struct {
 }
 
 type fetchRestrictions struct {
-	Apps     []interface{} `json:"apps"`
-	AppsUrl  string        `json:"apps_url"`
-	Teams    []fetchTeam   `json:"teams"`
-	TeamsUrl string        `json:"teams_url"`
-	Url      string        `json:"url"`
-	Users    []interface{} `json:"users"`
-	UsersUrl string        `json:"users_url"`
+	Apps     []fetchApp  `json:"apps"`
+	AppsUrl  string      `json:"apps_url"`
+	Teams    []fetchTeam `json:"teams"`
+	TeamsUrl string      `json:"teams_url"`
+	Url      string      `json:"url"`
+	Users    []fetchUser `json:"users"`
+	UsersUrl string      `json:"users_url"`
 }
 
 type fetchTeam struct {
@@ -91,32 +100,66 @@ type fetchTeam struct {
 }
 
 type fetchPullRequestReviews struct {
-	DismissStaleReviews          bool   `json:"dismiss_stale_reviews"`
-	RequireCodeOwnerReviews      bool   `json:"require_code_owner_reviews"`
-	RequireLastPushApproval      bool   `json:"require_last_push_approval"`
-	RequiredApprovingReviewCount int    `json:"required_approving_review_count"`
-	Url                          string `json:"url"`
+	BypassPullRequestAllowances  fetchBypassAllowances `json:"bypass_pull_request_allowances"`
+	DismissStaleReviews          bool                  `json:"dismiss_stale_reviews"`
+	RequireCodeOwnerReviews      bool                  `json:"require_code_owner_reviews"`
+	RequireLastPushApproval      bool                  `json:"require_last_push_approval"`
+	RequiredApprovingReviewCount int                   `json:"required_approving_review_count"`
+	Url                          string                `json:"url"`
+}
+
+type fetchBypassAllowances struct {
+	Apps  []fetchApp  `json:"apps"`
+	Teams []fetchTeam `json:"teams"`
+	Users []fetchUser `json:"users"`
+}
+
+type fetchApp struct {
+	Slug string `json:"slug"`
+}
+
+type fetchUser struct {
+	Login string `json:"login"`
 }
 
 // Types declaration to match the JSON sent to GitHub to create a new branch protection rule
 
-type updateUpdateBranchProtectionRulesPayload struct {
-	RequiredStatusChecks           updateRequiredStatusChecks       `json:"required_status_checks"`
-	EnforceAdmins                  bool                             `json:"enforce_admins"`
-	RequiredPullRequestReviews     updateRequiredPullRequestReviews `json:"required_pull_request_reviews"`
-	Restrictions                   updateUsersTeamsApps             `json:"restrictions"`
-	RequiredLinearHistory          bool                             `json:"required_linear_history"`
-	AllowForcePushes               bool                             `json:"allow_force_pushes"`
-	AllowDeletions                 bool                             `json:"allow_deletions"`
-	BlockCreations                 bool                             `json:"block_creations"`
-	RequiredConversationResolution bool                             `json:"required_conversation_resolution"`
-	LockBranch                     bool                             `json:"lock_branch"`
-	AllowForkSyncing               bool                             `json:"allow_fork_syncing"`
+// BranchProtectionUpdate is the payload sent to the API to protect a branch.
+// The sections left nil are disabled on the branch.
+type BranchProtectionUpdate struct {
+	RequiredStatusChecks           *updateRequiredStatusChecks       `json:"required_status_checks"`
+	EnforceAdmins                  bool                              `json:"enforce_admins"`
+	RequiredPullRequestReviews     *updateRequiredPullRequestReviews `json:"required_pull_request_reviews"`
+	Restrictions                   *updateUsersTeamsApps             `json:"restrictions"`
+	RequiredLinearHistory          bool                              `json:"required_linear_history"`
+	AllowForcePushes               bool                              `json:"allow_force_pushes"`
+	AllowDeletions                 bool                              `json:"allow_deletions"`
+	BlockCreations                 bool                              `json:"block_creations"`
+	RequiredConversationResolution bool                              `json:"required_conversation_resolution"`
+	LockBranch                     bool                              `json:"lock_branch"`
+	AllowForkSyncing               bool                              `json:"allow_fork_syncing"`
+
+	// RequiredSignatures is not part of the payload of the API, it is set through its own endpoint.
+	RequiredSignatures bool `json:"required_signatures"`
+}
+
+// putBranchProtection is the payload actually sent to the API, without the fields that have their own endpoint.
+type putBranchProtection struct {
+	BranchProtectionUpdate
+
+	RequiredSignatures *bool `json:"required_signatures,omitempty"`
 }
 
 type updateRequiredStatusChecks struct {
-	Strict   bool     `json:"strict"`
-	Contexts []string `json:"contexts"`
+	Strict bool                `json:"strict"`
+	Checks []updateStatusCheck `json:"checks"`
+}
+
+// updateStatusCheck is a required status check, AppId restricts the app that must
+// report it, it is nil when any app can.
+type updateStatusCheck struct {
+	Context string `json:"context"`
+	AppId   *int   `json:"app_id,omitempty"`
 }
 
 type updateUsersTeamsApps struct {
@@ -126,44 +169,232 @@ type updateUsersTeamsApps struct {
 }
 
 type updateRequiredPullRequestReviews struct {
-	DismissStaleReviews          bool `json:"dismiss_stale_reviews"`
-	RequireCodeOwnerReviews      bool `json:"require_code_owner_reviews"`
-	RequiredApprovingReviewCount int  `json:"required_approving_review_count"`
-	RequireLastPushApproval      bool `json:"require_last_push_approval"`
+	DismissStaleReviews          bool                 `json:"dismiss_stale_reviews"`
+	RequireCodeOwnerReviews      bool                 `json:"require_code_owner_reviews"`
+	RequiredApprovingReviewCount int                  `json:"required_approving_review_count"`
+	RequireLastPushApproval      bool                 `json:"require_last_push_approval"`
+	BypassPullRequestAllowances  updateUsersTeamsApps `json:"bypass_pull_request_allowances"`
+}
+
+// CopyBranchProtectionRules copies the protection rules of the source branch to the destination branch,
+// and makes the repository rulesets targeting the source branch target the destination branch too.
+// It returns the differences between the rules of both branches once copied, which are expected to be empty.
+func CopyBranchProtectionRules(repo, source, destination string) []string {
+	sourceRules := transformBranchProtectionRules(getBranchProtectionRules(repo, source))
+	putBranchProtectionRules(sourceRules, repo, destination)
+
+	copyRulesets(repo, source, destination)
+
+	destinationRules := transformBranchProtectionRules(getBranchProtectionRules(repo, destination))
+	diff := diffBranchProtectionRules(sourceRules, destinationRules)
+
+	return append(diff, diffRulesets(repo, source, destination)...)
+}
+
+// diffBranchProtectionRules lists the fields whose values differ between the two protections, as "field: source -> destination".
+// The lists of checks, users, teams and apps are compared as sets, GitHub does not keep their order.
+func diffBranchProtectionRules(source, destination BranchProtectionUpdate) []string {
+	sourceFields := flattenJSON(sortBranchProtectionLists(source))
+	destinationFields := flattenJSON(sortBranchProtectionLists(destination))
+
+	var fields []string
+	for field := range sourceFields {
+		fields = append(fields, field)
+	}
+
+	for field := range destinationFields {
+		if _, ok := sourceFields[field]; !ok {
+			fields = append(fields, field)
+		}
+	}
+
+	slices.Sort(fields)
+
+	var diff []string
+
+	for _, field := range fields {
+		s, d := sourceFields[field], destinationFields[field]
+		if s != d {
+			diff = append(diff, fmt.Sprintf("%s: %s -> %s", field, orNone(s), orNone(d)))
+		}
+	}
+
+	return diff
+}
+
+// sortBranchProtectionLists returns a copy of the protection whose lists are sorted.
+func sortBranchProtectionLists(ubpr BranchProtectionUpdate) BranchProtectionUpdate {
+	sortUsersTeamsApps := func(l updateUsersTeamsApps) updateUsersTeamsApps {
+		return updateUsersTeamsApps{Users: slices.Sorted(slices.Values(l.Users)), Teams: slices.Sorted(slices.Values(l.Teams)), Apps: slices.Sorted(slices.Values(l.Apps))}
+	}
+
+	if checks := ubpr.RequiredStatusChecks; checks != nil {
+		sorted := *checks
+		sorted.Checks = slices.SortedFunc(slices.Values(checks.Checks), func(a, b updateStatusCheck) int {
+			if c := strings.Compare(a.Context, b.Context); c != 0 {
+				return c
+			}
+
+			return cmp.Compare(appID(a.AppId), appID(b.AppId))
+		})
+		ubpr.RequiredStatusChecks = &sorted
+	}
+
+	if reviews := ubpr.RequiredPullRequestReviews; reviews != nil {
+		sorted := *reviews
+		sorted.BypassPullRequestAllowances = sortUsersTeamsApps(reviews.BypassPullRequestAllowances)
+		ubpr.RequiredPullRequestReviews = &sorted
+	}
+
+	if restrictions := ubpr.Restrictions; restrictions != nil {
+		sorted := sortUsersTeamsApps(*restrictions)
+		ubpr.Restrictions = &sorted
+	}
+
+	return ubpr
+}
+
+// appID orders the checks that any app can report first.
+func appID(id *int) int {
+	if id == nil {
+		return -1
+	}
+
+	return *id
 }
 
-func CopyBranchProtectionRules(repo, branch string) {
-	originBranchProtectionRules := getBranchProtectionRules(repo)
-	destinationBranchProtectionRules := transformBranchProtectionRules(originBranchProtectionRules)
-	putBranchProtectionRules(destinationBranchProtectionRules, repo, branch)
+// flattenJSON returns the leaf values of the JSON form of v, indexed by their path (i.e. "required_status_checks.strict").
+func flattenJSON(v any) map[string]string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		utils.BailOut(err, "failed to marshal %v", v)
+	}
+
+	var generic any
+	if err := json.Unmarshal(b, &generic); err != nil {
+		utils.BailOut(err, "failed to unmarshal %s", b)
+	}
+
+	fields := map[string]string{}
+
+	var walk func(prefix string, v any)
+	walk = func(prefix string, v any) {
+		switch v := v.(type) {
+		case map[string]any:
+			for k, child := range v {
+				walk(strings.TrimPrefix(prefix+"."+k, "."), child)
+			}
+		default:
+			b, _ := json.Marshal(v)
+			fields[prefix] = string(b)
+		}
+	}
+	walk("", generic)
+
+	return fields
 }
 
-func getBranchProtectionRules(repo string) fetchBranchProtectionRules {
-	stdOut := execGh("api", fmt.Sprintf("repos/%s/branches/main/protection", repo))
+func orNone(s string) string {
+	if s == "" {
+		return "<none>"
+	}
 
-	var bpr fetchBranchProtectionRules
+	return s
+}
+
+// SnapshotBranchProtection returns the protection of the branch, as returned by the API, to restore it
+// with RestoreBranchProtection. It returns nil if the branch is not protected.
+func SnapshotBranchProtection(repo, branch string) json.RawMessage {
+	bpr, err := getClient().GetBranchProtection(repo, branch)
+	if errors.Is(err, ErrNotFound) {
+		return nil
+	}
 
-	err := json.Unmarshal([]byte(stdOut), &bpr)
 	if err != nil {
-		utils.BailOut(err, "failed to parse the branch protection rules")
+		utils.BailOut(err, "failed to get the branch protection rules of %s", branch)
+	}
+
+	return bpr.Raw
+}
+
+// protectionFromSnapshot derives the payload that puts back the protection of the snapshot.
+func protectionFromSnapshot(snapshot json.RawMessage) (BranchProtectionUpdate, error) {
+	var bpr BranchProtection
+	if err := json.Unmarshal(snapshot, &bpr); err != nil {
+		return BranchProtectionUpdate{}, fmt.Errorf("failed to parse the snapshot of the branch protection: %w", err)
+	}
+
+	return transformBranchProtectionRules(bpr), nil
+}
+
+// BypassBranchProtection applies the snapshot of the branch protection, modified so the given teams and users
+// can bypass the required Pull Request reviews, and so the administrators are not subject to the rules.
+// The required status checks still apply to everyone else.
+func BypassBranchProtection(repo, branch string, snapshot json.RawMessage, teams, users []string) {
+	bypass, err := protectionFromSnapshot(snapshot)
+	if err != nil {
+		utils.BailOut(err, "failed to bypass the branch protection rules of %s", branch)
+	}
+
+	bypass.EnforceAdmins = false
+
+	if reviews := bypass.RequiredPullRequestReviews; reviews != nil {
+		allowances := &reviews.BypassPullRequestAllowances
+		allowances.Teams = appendMissing(allowances.Teams, teams...)
+		allowances.Users = appendMissing(allowances.Users, users...)
+	}
+
+	putBranchProtectionRules(bypass, repo, branch)
+}
+
+// RestoreBranchProtection puts back the snapshot of the branch protection, and verifies
+// that the protection of the branch now matches the snapshot.
+func RestoreBranchProtection(repo, branch string, snapshot json.RawMessage) error {
+	want, err := protectionFromSnapshot(snapshot)
+	if err != nil {
+		return err
+	}
+
+	putBranchProtectionRules(want, repo, branch)
+
+	restored, err := getClient().GetBranchProtection(repo, branch)
+	if errors.Is(err, ErrNotFound) {
+		return fmt.Errorf("branch %s is no longer protected", branch)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if diff := diffBranchProtectionRules(want, transformBranchProtectionRules(restored)); len(diff) > 0 {
+		return fmt.Errorf("the branch protection rules of %s do not match the snapshot once restored: %s", branch, strings.Join(diff, ", "))
+	}
+
+	return nil
+}
+
+func appendMissing(list []string, values ...string) []string {
+	for _, v := range values {
+		if !slices.Contains(list, v) {
+			list = append(list, v)
+		}
+	}
+
+	return list
+}
+
+func getBranchProtectionRules(repo, branch string) BranchProtection {
+	bpr, err := getClient().GetBranchProtection(repo, branch)
+	if err != nil {
+		utils.BailOut(err, "failed to get the branch protection rules of %s", branch)
 	}
 
 	return bpr
 }
 
-func transformBranchProtectionRules(bpr fetchBranchProtectionRules) updateUpdateBranchProtectionRulesPayload {
-	ubpr := updateUpdateBranchProtectionRulesPayload{
-		RequiredStatusChecks: updateRequiredStatusChecks{
-			Strict:   bpr.RequiredStatusChecks.Strict,
-			Contexts: bpr.RequiredStatusChecks.Contexts,
-		},
-		EnforceAdmins: bpr.EnforceAdmins.Enabled,
-		RequiredPullRequestReviews: updateRequiredPullRequestReviews{
-			DismissStaleReviews:          bpr.RequiredPullRequestReviews.DismissStaleReviews,
-			RequireCodeOwnerReviews:      bpr.RequiredPullRequestReviews.RequireCodeOwnerReviews,
-			RequiredApprovingReviewCount: bpr.RequiredPullRequestReviews.RequiredApprovingReviewCount,
-			RequireLastPushApproval:      bpr.RequiredPullRequestReviews.RequireLastPushApproval,
-		},
+func transformBranchProtectionRules(bpr BranchProtection) BranchProtectionUpdate {
+	ubpr := BranchProtectionUpdate{
+		EnforceAdmins:                  bpr.EnforceAdmins.Enabled,
 		RequiredLinearHistory:          bpr.RequiredLinearHistory.Enabled,
 		AllowForcePushes:               bpr.AllowForcePushes.Enabled,
 		AllowDeletions:                 bpr.AllowDeletions.Enabled,
@@ -171,46 +402,124 @@ func transformBranchProtectionRules(bpr fetchBranchProtectionRules) updateUpdate
 		RequiredConversationResolution: bpr.RequiredConversationResolution.Enabled,
 		LockBranch:                     bpr.LockBranch.Enabled,
 		AllowForkSyncing:               bpr.AllowForkSyncing.Enabled,
-		Restrictions: updateUsersTeamsApps{
+		RequiredSignatures:             bpr.RequiredSignatures.Enabled,
+	}
+
+	// The sections that are not enabled on the branch are not returned by the API, they have no URL
+	if bpr.RequiredStatusChecks.Url != "" {
+		// The API does not expect 'nil' instead of slices
+		ubpr.RequiredStatusChecks = &updateRequiredStatusChecks{
+			Strict: bpr.RequiredStatusChecks.Strict,
+			Checks: []updateStatusCheck{},
+		}
+
+		for _, check := range bpr.RequiredStatusChecks.Checks {
+			ubpr.RequiredStatusChecks.Checks = append(ubpr.RequiredStatusChecks.Checks, updateStatusCheck{Context: check.Context, AppId: check.AppId})
+		}
+
+		// Older protections may only list the legacy contexts, they can be reported by any app
+		if len(bpr.RequiredStatusChecks.Checks) == 0 {
+			for _, context := range bpr.RequiredStatusChecks.Contexts {
+				ubpr.RequiredStatusChecks.Checks = append(ubpr.RequiredStatusChecks.Checks, updateStatusCheck{Context: context})
+			}
+		}
+	}
+
+	if bpr.RequiredPullRequestReviews.Url != "" {
+		reviews := bpr.RequiredPullRequestReviews
+		ubpr.RequiredPullRequestReviews = &updateRequiredPullRequestReviews{
+			DismissStaleReviews:          reviews.DismissStaleReviews,
+			RequireCodeOwnerReviews:      reviews.RequireCodeOwnerReviews,
+			RequiredApprovingReviewCount: reviews.RequiredApprovingReviewCount,
+			RequireLastPushApproval:      reviews.RequireLastPushApproval,
+			BypassPullRequestAllowances: updateUsersTeamsApps{
+				Users: []string{},
+				Teams: []string{},
+				Apps:  []string{},
+			},
+		}
+
+		allowances := &ubpr.RequiredPullRequestReviews.BypassPullRequestAllowances
+		for _, user := range reviews.BypassPullRequestAllowances.Users {
+			allowances.Users = append(allowances.Users, user.Login)
+		}
+
+		for _, team := range reviews.BypassPullRequestAllowances.Teams {
+			allowances.Teams = append(allowances.Teams, team.Slug)
+		}
+
+		for _, app := range reviews.BypassPullRequestAllowances.Apps {
+			allowances.Apps = append(allowances.Apps, app.Slug)
+		}
+	}
+
+	if bpr.Restrictions.Url != "" {
+		ubpr.Restrictions = &updateUsersTeamsApps{
 			Users: []string{},
 			Teams: []string{},
 			Apps:  []string{},
-		},
-	}
+		}
 
-	// The API does not expect 'nil' instead of slices
-	if ubpr.RequiredStatusChecks.Contexts == nil {
-		ubpr.RequiredStatusChecks.Contexts = []string{}
-	}
+		for _, user := range bpr.Restrictions.Users {
+			ubpr.Restrictions.Users = append(ubpr.Restrictions.Users, user.Login)
+		}
 
-	for _, team := range bpr.Restrictions.Teams {
-		ubpr.Restrictions.Teams = append(ubpr.Restrictions.Teams, team.Name)
+		for _, team := range bpr.Restrictions.Teams {
+			ubpr.Restrictions.Teams = append(ubpr.Restrictions.Teams, team.Slug)
+		}
+
+		for _, app := range bpr.Restrictions.Apps {
+			ubpr.Restrictions.Apps = append(ubpr.Restrictions.Apps, app.Slug)
+		}
 	}
 
 	return ubpr
 }
 
-func putBranchProtectionRules(ubpr updateUpdateBranchProtectionRulesPayload, repo, branch string) {
-	jsonUbpr, err := json.Marshal(ubpr)
+func putBranchProtectionRules(ubpr BranchProtectionUpdate, repo, branch string) {
+	err := getClient().UpdateBranchProtection(repo, branch, ubpr)
 	if err != nil {
-		utils.BailOut(err, "failed to marshal update branch protection rules")
+		utils.BailOut(err, "failed to update the branch protection rules of %s", branch)
 	}
+}
 
-	f, err := os.CreateTemp("/tmp", "")
+func (c *restClient) GetBranchProtection(repo, branch string) (BranchProtection, error) {
+	var raw json.RawMessage
+
+	_, err := c.do(http.MethodGet, repoPath(repo, "branches", url.PathEscape(branch), "protection"), nil, &raw)
 	if err != nil {
-		utils.BailOut(err, "failed to create a temporary file")
+		return BranchProtection{}, err
 	}
 
-	_, err = f.Write(jsonUbpr)
+	var bpr BranchProtection
+	if err := json.Unmarshal(raw, &bpr); err != nil {
+		return BranchProtection{}, err
+	}
+
+	bpr.Raw = raw
+
+	return bpr, nil
+}
+
+// UpdateBranchProtection replaces the protection of the branch, including the required signatures
+// which are not part of the protection payload.
+func (c *restClient) UpdateBranchProtection(repo, branch string, protection BranchProtectionUpdate) error {
+	p := repoPath(repo, "branches", url.PathEscape(branch), "protection")
+
+	_, err := c.do(http.MethodPut, p, putBranchProtection{BranchProtectionUpdate: protection}, nil)
 	if err != nil {
-		utils.BailOut(err, "failed to write update branch protection rules to the temporary file")
+		return err
+	}
+
+	method := http.MethodDelete
+	if protection.RequiredSignatures {
+		method = http.MethodPost
+	}
+
+	_, err = c.do(method, p+"/required_signatures", nil, nil)
+	if method == http.MethodDelete && errors.Is(err, ErrNotFound) {
+		return nil
 	}
 
-	_ = execGh("api",
-		"--method", "PUT",
-		"-H", "Accept: application/vnd.github+json",
-		"-H", "X-GitHub-Api-Version: 2022-11-28",
-		"--input", f.Name(),
-		fmt.Sprintf("repos/%s/branches/%s/protection", repo, branch),
-	)
+	return err
 }
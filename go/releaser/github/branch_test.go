@@ -0,0 +1,187 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github_test
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/vitessio/vitess-releaser/go/releaser/github"
+	"github.com/vitessio/vitess-releaser/go/releaser/github/githubtest"
+)
+
+// releaseBranchProtection is the protection of a release branch, in the format it is read from the API.
+const releaseBranchProtection = `{
+  "url": "protection",
+  "enforce_admins": {"enabled": true},
+  "required_signatures": {"enabled": true},
+  "required_linear_history": {"enabled": false},
+  "allow_force_pushes": {"enabled": false},
+  "allow_deletions": {"enabled": false},
+  "block_creations": {"enabled": false},
+  "required_conversation_resolution": {"enabled": true},
+  "lock_branch": {"enabled": false},
+  "allow_fork_syncing": {"enabled": false},
+  "required_status_checks": {"url": "checks", "strict": true, "contexts": ["unit_test", "e2e"], "checks": [{"context": "unit_test", "app_id": 15368}, {"context": "e2e"}]},
+  "required_pull_request_reviews": {
+    "url": "reviews",
+    "dismiss_stale_reviews": true,
+    "require_code_owner_reviews": true,
+    "require_last_push_approval": false,
+    "required_approving_review_count": 1,
+    "bypass_pull_request_allowances": {"users": [{"login": "vitess-bot"}], "teams": [], "apps": []}
+  },
+  "restrictions": {"url": "restrictions", "users": [], "teams": [{"slug": "release"}], "apps": []}
+}`
+
+func protectBranch(t *testing.T, s *githubtest.Server, branch string) {
+	t.Helper()
+
+	var p github.BranchProtection
+	if err := json.Unmarshal([]byte(releaseBranchProtection), &p); err != nil {
+		t.Fatal(err)
+	}
+
+	s.SetBranchProtection(testRepo, branch, p)
+}
+
+func TestCopyBranchProtectionRules(t *testing.T) {
+	s := newServer(t)
+
+	protectBranch(t, s, "release-20.0")
+
+	// Only the first ruleset targets the source branch
+	copied := s.AddRuleset(testRepo, github.Ruleset{
+		Name:        "release branches",
+		Target:      "branch",
+		SourceType:  "Repository",
+		Enforcement: "active",
+		Conditions:  &github.RulesetConditions{RefName: github.RulesetRefName{Include: []string{"refs/heads/release-20.0"}, Exclude: []string{}}},
+	})
+	s.AddRuleset(testRepo, github.Ruleset{
+		Name:        "default branch",
+		Target:      "branch",
+		SourceType:  "Repository",
+		Enforcement: "active",
+		Conditions:  &github.RulesetConditions{RefName: github.RulesetRefName{Include: []string{"~DEFAULT_BRANCH"}, Exclude: []string{}}},
+	})
+
+	if diff := github.CopyBranchProtectionRules(testRepo, "release-20.0", "release-21.0"); len(diff) != 0 {
+		t.Fatalf("expected the protections to match once copied, got %q", diff)
+	}
+
+	if !s.RequiredSignatures(testRepo, "release-21.0") {
+		t.Fatal("expected signed commits to be required on release-21.0")
+	}
+
+	// The fake sorted the required checks of the copy, which still matches the source
+	if copy := string(s.BranchProtection(testRepo, "release-21.0")); !strings.Contains(copy, `"contexts":["e2e","unit_test"]`) {
+		t.Fatalf("expected the required checks to be copied, got %s", copy)
+	}
+
+	for _, rs := range s.Rulesets(testRepo) {
+		include := rs.Conditions.RefName.Include
+		if targets := slices.Contains(include, "refs/heads/release-21.0"); targets != (rs.ID == copied) {
+			t.Fatalf("unexpected targets for the ruleset %q: %q", rs.Name, include)
+		}
+	}
+
+	// Copying again changes nothing
+	if diff := github.CopyBranchProtectionRules(testRepo, "release-20.0", "release-21.0"); len(diff) != 0 {
+		t.Fatalf("expected the protections to match, got %q", diff)
+	}
+
+	for _, rs := range s.Rulesets(testRepo) {
+		if rs.ID == copied && len(rs.Conditions.RefName.Include) != 2 {
+			t.Fatalf("expected the release branch to be added once, got %q", rs.Conditions.RefName.Include)
+		}
+	}
+}
+
+func TestBypassAndRestoreBranchProtection(t *testing.T) {
+	s := newServer(t)
+
+	if snapshot := github.SnapshotBranchProtection(testRepo, "release-21.0"); snapshot != nil {
+		t.Fatalf("expected no snapshot for an unprotected branch, got %s", snapshot)
+	}
+
+	protectBranch(t, s, "release-21.0")
+
+	snapshot := github.SnapshotBranchProtection(testRepo, "release-21.0")
+	if snapshot == nil {
+		t.Fatal("expected a snapshot of the protected branch")
+	}
+
+	github.BypassBranchProtection(testRepo, "release-21.0", snapshot, []string{"release"}, []string{"release-manager"})
+
+	bypassed := string(s.BranchProtection(testRepo, "release-21.0"))
+	for _, want := range []string{`"enforce_admins":{"enabled":false}`, `"slug":"release"`, `"login":"release-manager"`, `"login":"vitess-bot"`} {
+		if !strings.Contains(bypassed, want) {
+			t.Fatalf("expected the bypassed protection to contain %s, got %s", want, bypassed)
+		}
+	}
+
+	if err := github.RestoreBranchProtection(testRepo, "release-21.0", snapshot); err != nil {
+		t.Fatal(err)
+	}
+
+	restored := string(s.BranchProtection(testRepo, "release-21.0"))
+	for _, unwanted := range []string{`"enforce_admins":{"enabled":false}`, `"login":"release-manager"`} {
+		if strings.Contains(restored, unwanted) {
+			t.Fatalf("expected the restored protection not to contain %s, got %s", unwanted, restored)
+		}
+	}
+
+	if !s.RequiredSignatures(testRepo, "release-21.0") {
+		t.Fatal("expected signed commits to still be required")
+	}
+}
+
+func TestRestoreBranchProtectionFromRawSnapshot(t *testing.T) {
+	s := newServer(t)
+
+	protectBranch(t, s, "release-21.0")
+
+	// The snapshot is stored as given by the API, with the lists in an order the fake does not keep
+	// and fields the tool does not decode
+	snapshot := json.RawMessage(releaseBranchProtection)
+
+	github.BypassBranchProtection(testRepo, "release-21.0", snapshot, []string{"release"}, []string{"release-manager", "alice"})
+
+	if err := github.RestoreBranchProtection(testRepo, "release-21.0", snapshot); err != nil {
+		t.Fatalf("expected the lists to be compared as sets, got %v", err)
+	}
+
+	var restored struct {
+		RequiredStatusChecks struct {
+			Contexts []string `json:"contexts"`
+		} `json:"required_status_checks"`
+	}
+	if err := json.Unmarshal(s.BranchProtection(testRepo, "release-21.0"), &restored); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := restored.RequiredStatusChecks.Contexts; !slices.Equal(got, []string{"e2e", "unit_test"}) {
+		t.Fatalf("expected the fake to sort the required checks, got %q", got)
+	}
+
+	if err := github.RestoreBranchProtection(testRepo, "release-21.0", json.RawMessage("{")); err == nil {
+		t.Fatal("expected an invalid snapshot to be refused")
+	}
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// bulkWorkers is the maximum number of concurrent requests sent by the bulk operations. It is kept low
+// as GitHub's secondary rate limits punish bursts of concurrent mutations.
+const bulkWorkers = 4
+
+// BulkProgress is called after each item of a bulk operation, err is the error of the item, if any.
+// The calls are serialized.
+type BulkProgress func(done, total, nb int, err error)
+
+// BulkError collects the failures of a bulk operation, by issue or Pull Request number.
+type BulkError struct {
+	Total    int
+	Failures map[int]error
+}
+
+func (e *BulkError) Error() string {
+	var failures []string
+	for _, nb := range slices.Sorted(maps.Keys(e.Failures)) {
+		failures = append(failures, fmt.Sprintf("#%d: %s", nb, e.Failures[nb]))
+	}
+
+	return fmt.Sprintf("%d of %d item(s) failed: %s", len(e.Failures), e.Total, strings.Join(failures, ", "))
+}
+
+// runBulk calls fn for each number with at most bulkWorkers concurrent calls. Failures do not stop the
+// other calls, they are returned together as a *BulkError once all the numbers were processed.
+// When the remaining core rate limit budget is too low for all the calls, they are made one at a time,
+// and the rate limited calls wait for the reset as usual.
+func runBulk(numbers []int, progress BulkProgress, fn func(nb int) error) error {
+	workers := min(bulkWorkers, len(numbers))
+	if rl, ok := coreRateLimit(); ok && rl.Remaining < len(numbers) {
+		workers = 1
+	}
+
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		done     int
+		failures = map[int]error{}
+		work     = make(chan int)
+	)
+
+	for range workers {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for nb := range work {
+				err := fn(nb)
+
+				mu.Lock()
+				done++
+				if err != nil {
+					failures[nb] = err
+				}
+				if progress != nil {
+					progress(done, len(numbers), nb, err)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for _, nb := range numbers {
+		work <- nb
+	}
+
+	close(work)
+	wg.Wait()
+
+	if len(failures) > 0 {
+		return &BulkError{Total: len(numbers), Failures: failures}
+	}
+
+	return nil
+}
+
+// concurrently runs the functions at the same time and waits for all of them.
+func concurrently(fns ...func()) {
+	var wg sync.WaitGroup
+
+	for _, fn := range fns {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			fn()
+		}()
+	}
+
+	wg.Wait()
+}
+
+func coreRateLimit() (RateLimit, bool) {
+	rateLimitsMu.Lock()
+	defer rateLimitsMu.Unlock()
+
+	rl, ok := rateLimits["core"]
+
+	return rl, ok
+}
@@ -0,0 +1,228 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// CheckState is the outcome of a CI check, as shown in the TUI.
+type CheckState string
+
+const (
+	CheckPassed  CheckState = "passed"
+	CheckFailed  CheckState = "failed"
+	CheckPending CheckState = "pending"
+	CheckSkipped CheckState = "skipped"
+)
+
+// Check is a check run or a commit status of a commit.
+type Check struct {
+	Name  string
+	State CheckState
+	URL   string
+
+	// JobID is the ID of the GitHub Actions job behind the check run, whose logs can be
+	// read with GetJobLogTail. It is zero for commit statuses and the checks of other apps.
+	JobID int64
+}
+
+// CheckSummary aggregates the checks of the head commit of a Pull Request, along with its review decision.
+type CheckSummary struct {
+	Checks                  []Check
+	Passed, Failed, Pending int
+
+	// ReviewDecision is APPROVED, CHANGES_REQUESTED or REVIEW_REQUIRED, empty if no review is required.
+	ReviewDecision string
+}
+
+func (s CheckSummary) String() string {
+	checks := "no checks"
+	if len(s.Checks) > 0 {
+		checks = fmt.Sprintf("checks: %d passed, %d failed, %d pending", s.Passed, s.Failed, s.Pending)
+	}
+
+	if s.ReviewDecision == "" {
+		return checks
+	}
+
+	return fmt.Sprintf("%s, %s", strings.ReplaceAll(strings.ToLower(s.ReviewDecision), "_", " "), checks)
+}
+
+// FailedChecks returns the checks that failed.
+func (s CheckSummary) FailedChecks() []Check {
+	var failed []Check
+
+	for _, c := range s.Checks {
+		if c.State == CheckFailed {
+			failed = append(failed, c)
+		}
+	}
+
+	return failed
+}
+
+// GetPRChecks returns the Pull Request, the checks of its head commit and its review decision. Unlike most functions of
+// this package it does not bail out on errors, as it is only used to display the checks in the TUI.
+func GetPRChecks(repo string, nb int) (PR, CheckSummary, error) {
+	pr, err := getClient().GetPR(repo, nb)
+	if err != nil {
+		return PR{}, CheckSummary{}, err
+	}
+
+	checks, err := getClient().ListChecks(repo, pr.HeadSHA)
+	if err != nil {
+		return pr, CheckSummary{}, err
+	}
+
+	status, err := getClient().GetPRStatus(repo, nb)
+	if err != nil {
+		return pr, CheckSummary{}, err
+	}
+
+	summary := CheckSummary{Checks: checks, ReviewDecision: status.ReviewDecision}
+
+	for _, c := range checks {
+		switch c.State {
+		case CheckPassed:
+			summary.Passed++
+		case CheckFailed:
+			summary.Failed++
+		case CheckPending:
+			summary.Pending++
+		}
+	}
+
+	return pr, summary, nil
+}
+
+var logTimestampRegexp = regexp.MustCompile(`(?m)^\d{4}-\d{2}-\d{2}T[\d:.]+Z `)
+
+// GetJobLogTail returns the last lines of the logs of a GitHub Actions job, without their timestamps.
+// The logs are not kept forever, if they cannot be read the error is returned in place of the logs.
+func GetJobLogTail(repo string, jobID int64, lines int) string {
+	logs, err := getClient().GetJobLogs(repo, jobID)
+	if err != nil {
+		return fmt.Sprintf("failed to read the logs: %s", err)
+	}
+
+	all := strings.Split(strings.TrimRight(logTimestampRegexp.ReplaceAllString(logs, ""), "\n"), "\n")
+	if len(all) > lines {
+		all = all[len(all)-lines:]
+	}
+
+	return strings.Join(all, "\n")
+}
+
+// ListChecks returns the latest check runs and commit statuses of the commit.
+func (c *restClient) ListChecks(repo, ref string) ([]Check, error) {
+	var checks []Check
+
+	err := c.paginate(repoPath(repo, "commits", ref, "check-runs"), url.Values{}, NoLimit, func(page []byte) (int, error) {
+		var result struct {
+			CheckRuns []struct {
+				ID         int64  `json:"id"`
+				Name       string `json:"name"`
+				Status     string `json:"status"`
+				Conclusion string `json:"conclusion"`
+				HTMLURL    string `json:"html_url"`
+				App        struct {
+					Slug string `json:"slug"`
+				} `json:"app"`
+			} `json:"check_runs"`
+		}
+
+		if err := json.Unmarshal(page, &result); err != nil {
+			return 0, err
+		}
+
+		for _, run := range result.CheckRuns {
+			check := Check{Name: run.Name, State: checkRunState(run.Status, run.Conclusion), URL: run.HTMLURL}
+
+			// the check runs of GitHub Actions share their ID with the job
+			if run.App.Slug == "github-actions" {
+				check.JobID = run.ID
+			}
+
+			checks = append(checks, check)
+		}
+
+		return len(result.CheckRuns), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var combined struct {
+		Statuses []struct {
+			Context   string `json:"context"`
+			State     string `json:"state"`
+			TargetURL string `json:"target_url"`
+		} `json:"statuses"`
+	}
+
+	if _, err := c.do(http.MethodGet, repoPath(repo, "commits", ref, "status"), nil, &combined); err != nil {
+		return nil, err
+	}
+
+	for _, status := range combined.Statuses {
+		checks = append(checks, Check{Name: status.Context, State: commitStatusState(status.State), URL: status.TargetURL})
+	}
+
+	return checks, nil
+}
+
+func checkRunState(status, conclusion string) CheckState {
+	if status != "completed" {
+		return CheckPending
+	}
+
+	switch conclusion {
+	case "success", "neutral":
+		return CheckPassed
+	case "skipped":
+		return CheckSkipped
+	}
+
+	return CheckFailed
+}
+
+func commitStatusState(state string) CheckState {
+	switch state {
+	case "success":
+		return CheckPassed
+	case "pending":
+		return CheckPending
+	}
+
+	return CheckFailed
+}
+
+// GetJobLogs returns the logs of a GitHub Actions job, the API redirects to a plain text file.
+func (c *restClient) GetJobLogs(repo string, jobID int64) (string, error) {
+	var logs []byte
+
+	_, err := c.do(http.MethodGet, repoPath(repo, "actions", "jobs", strconv.FormatInt(jobID, 10), "logs"), nil, &logs)
+
+	return string(logs), err
+}
@@ -0,0 +1,366 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/cli/go-gh/v2/pkg/auth"
+
+	"github.com/vitessio/vitess-releaser/go/releaser/utils"
+)
+
+// Client is the set of GitHub operations used by the releaser.
+type Client interface {
+	CurrentUser() (string, error)
+
+	CreateIssue(repo string, issue Issue) (Issue, error)
+	GetIssue(repo string, nb int) (Issue, error)
+	UpdateIssueBody(repo string, nb int, body string) (Issue, error)
+	CloseIssue(repo string, nb int, comment string) error
+	CreateComment(repo string, nb int, body string) error
+	ListIssues(repo string, opts ListOptions) ([]Issue, error)
+	SearchIssues(repo, query string, limit int) ([]Issue, error)
+	SetMilestone(repo string, nb, milestone int) error
+
+	CreatePR(repo string, pr PR) (PR, error)
+	GetPR(repo string, nb int) (PR, error)
+	GetPRStatus(repo string, nb int) (PRStatus, error)
+	GetTrackedItem(repo string, nb int) (TrackedItem, error)
+	EnableAutoMerge(repo string, nb int, method MergeMethod) error
+	MergePR(repo string, nb int, method MergeMethod) error
+	GetCommit(repo, sha string) (Commit, error)
+	ListPRCommits(repo string, nb int) ([]Commit, error)
+	ListChecks(repo, ref string) ([]Check, error)
+	GetJobLogs(repo string, jobID int64) (string, error)
+	ListPRs(repo string, opts ListOptions) ([]PR, error)
+	SearchPRs(repo, query string, limit int) ([]PR, error)
+	ListMilestonePRs(repo string, milestone int, states ...string) ([]PR, error)
+	RequestReviewers(repo string, nb int, users, teams []string) error
+
+	CreateOrUpdateLabel(repo string, label Label) error
+	ListLabels(repo string) ([]Label, error)
+	RenameLabel(repo, name, newName string) error
+	RemoveLabel(repo string, nb int, label string) error
+
+	ListMilestones(repo, state string) ([]Milestone, error)
+	CreateMilestone(repo string, milestone Milestone) (Milestone, error)
+	UpdateMilestone(repo string, nb int, milestone Milestone) (Milestone, error)
+
+	CreateRelease(repo string, release Release) (Release, error)
+	GetReleaseByTag(repo, tag string) (Release, error)
+	GetLatestRelease(repo string) (Release, error)
+	UpdateRelease(repo string, id int64, release Release) (Release, error)
+	ListReleaseAssets(repo string, id int64) ([]ReleaseAsset, error)
+	UploadReleaseAsset(release Release, name string, data []byte) (ReleaseAsset, error)
+	DownloadReleaseAsset(repo string, id int64) ([]byte, error)
+	DeleteReleaseAsset(repo string, id int64) error
+
+	GetBranchProtection(repo, branch string) (BranchProtection, error)
+	UpdateBranchProtection(repo, branch string, protection BranchProtectionUpdate) error
+	ListRulesets(repo string) ([]Ruleset, error)
+	GetRuleset(repo string, id int64) (Ruleset, error)
+	UpdateRulesetConditions(repo string, id int64, conditions RulesetConditions) error
+	GetDefaultBranch(repo string) (string, error)
+}
+
+// ListOptions filters the issues and Pull Requests returned by the list operations.
+// Head and Base only apply to Pull Requests, Milestone, the number of a milestone, only applies to issues. Limit defaults to defaultListLimit, use NoLimit to read all the pages.
+type ListOptions struct {
+	State     string
+	Labels    []string
+	Head      string
+	Base      string
+	Milestone int
+	Limit     int
+}
+
+// NoLimit makes the list and search operations read all the pages. The search API
+// never returns more than 1000 results, whatever the limit.
+const NoLimit = -1
+
+const (
+	defaultListLimit = 30
+	maxPerPage       = 100
+	apiVersion       = "2022-11-28"
+)
+
+var (
+	clientMu sync.Mutex
+	client   Client
+)
+
+// SetClient replaces the client used by the functions of this package,
+// for instance with the client of a githubtest.Server.
+func SetClient(c Client) {
+	clientMu.Lock()
+	defer clientMu.Unlock()
+
+	client = c
+}
+
+func getClient() Client {
+	clientMu.Lock()
+	defer clientMu.Unlock()
+
+	if client == nil {
+		c, err := DefaultClient()
+		if err != nil {
+			utils.BailOut(err, "failed to create the GitHub client")
+		}
+
+		client = c
+	}
+
+	return client
+}
+
+// DefaultClient returns a client for gh's default host, authenticated the same way gh is:
+// with GH_TOKEN, or the token stored by `gh auth login`.
+func DefaultClient() (Client, error) {
+	host, _ := auth.DefaultHost()
+
+	token, _ := auth.TokenForHost(host)
+	if token == "" {
+		return nil, fmt.Errorf("not authenticated on %s, please run `gh auth login` or set GH_TOKEN", host)
+	}
+
+	httpClient, err := api.NewHTTPClient(api.ClientOptions{
+		Host:      host,
+		AuthToken: token,
+		Headers: map[string]string{
+			"Accept":               "application/vnd.github+json",
+			"X-GitHub-Api-Version": apiVersion,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return NewClient(apiBaseURL(host), httpClient, DefaultRetryPolicy), nil
+}
+
+// NewClient returns a client of the GitHub REST API at baseURL. Authentication is left to httpClient.
+func NewClient(baseURL string, httpClient *http.Client, retry RetryPolicy) Client {
+	return &restClient{
+		http:    httpClient,
+		baseURL: strings.TrimSuffix(baseURL, "/") + "/",
+		retry:   retry,
+	}
+}
+
+func apiBaseURL(host string) string {
+	host = auth.NormalizeHostname(host)
+	if auth.IsEnterprise(host) {
+		return fmt.Sprintf("https://%s/api/v3/", host)
+	}
+
+	return fmt.Sprintf("https://api.%s/", host)
+}
+
+// restClient implements Client using the GitHub REST API.
+type restClient struct {
+	http    *http.Client
+	baseURL string
+	retry   RetryPolicy
+}
+
+// do sends a request to the API and decodes the JSON response into out, if not nil. If out is a *[]byte it
+// receives the raw response body instead, and if it is a *binaryContent it receives the downloaded file.
+// The path is relative to the base URL, unless it is an absolute URL. Failed requests
+// are retried according to the retry policy, see RetryPolicy.
+func (c *restClient) do(method, path string, body, out any) (*http.Response, error) {
+	return c.send(method, path, body, out, isIdempotent(method))
+}
+
+// binaryContent receives the content of a file served by the API, such as a release asset,
+// which is requested with the application/octet-stream media type instead of JSON.
+type binaryContent []byte
+
+// rawBody is a request body sent as is, instead of being encoded in JSON.
+type rawBody struct {
+	contentType string
+	data        []byte
+}
+
+func (c *restClient) send(method, path string, body, out any, idempotent bool) (*http.Response, error) {
+	var reqBody []byte
+
+	contentType := "application/json"
+
+	switch b := body.(type) {
+	case nil:
+	case rawBody:
+		reqBody = b.data
+		contentType = b.contentType
+	default:
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+
+		reqBody = data
+	}
+
+	u := path
+	if !strings.HasPrefix(path, "https://") && !strings.HasPrefix(path, "http://") {
+		u = c.baseURL + strings.TrimPrefix(path, "/")
+	}
+
+	for attempt := 1; ; attempt++ {
+		resp, err := c.sendOnce(method, u, contentType, reqBody, out)
+
+		wait, retry := c.retry.shouldRetry(attempt, idempotent, err)
+		if !retry {
+			return resp, err
+		}
+
+		time.Sleep(wait)
+	}
+}
+
+func (c *restClient) sendOnce(method, u, contentType string, body []byte, out any) (*http.Response, error) {
+	var reqBody io.Reader
+	if body != nil {
+		reqBody = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequest(method, u, reqBody)
+	if err != nil {
+		return nil, err
+	}
+
+	if body != nil {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	if _, ok := out.(*binaryContent); ok {
+		req.Header.Set("Accept", "application/octet-stream")
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, &networkError{err: err}
+	}
+	defer resp.Body.Close()
+
+	recordRateLimit(resp.Header)
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return resp, &networkError{err: err}
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp, newAPIError(method, req.URL.Path, resp, respBody)
+	}
+
+	switch raw := out.(type) {
+	case *[]byte:
+		*raw = respBody
+		return resp, nil
+	case *binaryContent:
+		*raw = respBody
+		return resp, nil
+	}
+
+	if out != nil && len(respBody) > 0 {
+		if err := json.Unmarshal(respBody, out); err != nil {
+			return resp, fmt.Errorf("failed to parse the response of %s %s: %w", method, req.URL.Path, err)
+		}
+	}
+
+	return resp, nil
+}
+
+var linkNextRegexp = regexp.MustCompile(`<([^>]+)>;\s*rel="next"`)
+
+// paginate GETs the pages of a list endpoint, following the Link headers, until limit items
+// were read or there are no more pages. decode parses one page and returns the number of items on it.
+func (c *restClient) paginate(path string, params url.Values, limit int, decode func([]byte) (int, error)) error {
+	if limit == 0 {
+		limit = defaultListLimit
+	}
+
+	perPage := maxPerPage
+	if limit > 0 {
+		perPage = min(limit, maxPerPage)
+	}
+
+	params.Set("per_page", strconv.Itoa(perPage))
+	next := path + "?" + params.Encode()
+
+	for read := 0; next != "" && (limit < 0 || read < limit); {
+		var page json.RawMessage
+
+		resp, err := c.do(http.MethodGet, next, nil, &page)
+		if err != nil {
+			return err
+		}
+
+		n, err := decode(page)
+		if err != nil {
+			return fmt.Errorf("failed to parse the response of GET %s: %w", next, err)
+		}
+
+		read += n
+		next = ""
+
+		if m := linkNextRegexp.FindStringSubmatch(resp.Header.Get("Link")); n > 0 && len(m) == 2 {
+			next = m[1]
+		}
+	}
+
+	return nil
+}
+
+// listAll returns the items of a list endpoint whose pages are JSON arrays.
+func listAll[T any](c *restClient, path string, params url.Values, limit int) ([]T, error) {
+	var items []T
+
+	err := c.paginate(path, params, limit, func(page []byte) (int, error) {
+		var pageItems []T
+		if err := json.Unmarshal(page, &pageItems); err != nil {
+			return 0, err
+		}
+
+		items = append(items, pageItems...)
+
+		return len(pageItems), nil
+	})
+
+	if limit > 0 && len(items) > limit {
+		items = items[:limit]
+	}
+
+	return items, err
+}
+
+func repoPath(repo string, elems ...string) string {
+	return "repos/" + repo + "/" + strings.Join(elems, "/")
+}
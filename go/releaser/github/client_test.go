@@ -0,0 +1,84 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/vitessio/vitess-releaser/go/releaser/github"
+	"github.com/vitessio/vitess-releaser/go/releaser/github/githubtest"
+)
+
+const testRepo = "vitessio/vitess"
+
+// newServer starts a fake GitHub API and makes the functions of the github package use it.
+func newServer(t *testing.T) *githubtest.Server {
+	t.Helper()
+
+	s := githubtest.NewServer()
+	github.SetClient(s.Client())
+
+	t.Cleanup(func() {
+		github.SetClient(nil)
+		s.Close()
+	})
+
+	return s
+}
+
+func TestErrors(t *testing.T) {
+	s := newServer(t)
+	c := s.Client()
+
+	_, err := c.GetIssue(testRepo, 42)
+	if !errors.Is(err, github.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for a missing issue, got %v", err)
+	}
+
+	var apiErr *github.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound || apiErr.Method != http.MethodGet {
+		t.Fatalf("expected a GET APIError with a 404 status, got %#v", err)
+	}
+
+	_, err = c.GetPRStatus(testRepo, 42)
+	if !errors.Is(err, github.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for a missing Pull Request through GraphQL, got %v", err)
+	}
+
+	s.Fail(githubtest.Failure{Path: "/user", Status: http.StatusUnauthorized, Message: "Bad credentials"})
+
+	_, err = c.CurrentUser()
+	if !errors.Is(err, github.ErrUnauthorized) {
+		t.Fatalf("expected ErrUnauthorized, got %v", err)
+	}
+
+	if err := c.CreateOrUpdateLabel(testRepo, github.Label{Name: "Type: Release", Color: "ffffff"}); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = c.CreateMilestone(testRepo, github.Milestone{Title: "v21.0.0"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = c.CreateMilestone(testRepo, github.Milestone{Title: "v21.0.0"})
+	if !errors.Is(err, github.ErrAlreadyExists) || !errors.Is(err, github.ErrValidation) {
+		t.Fatalf("expected ErrAlreadyExists for a duplicated milestone, got %v", err)
+	}
+}
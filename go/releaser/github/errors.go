@@ -0,0 +1,133 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+var (
+	ErrNotFound      = errors.New("not found")
+	ErrUnauthorized  = errors.New("unauthorized")
+	ErrValidation    = errors.New("validation failed")
+	ErrAlreadyExists = errors.New("already exists")
+	ErrRateLimited   = errors.New("rate limited")
+)
+
+// APIError is returned when the GitHub API answers with an error status code.
+// Use errors.Is with ErrNotFound, ErrUnauthorized, ErrValidation, ErrAlreadyExists or ErrRateLimited to classify it.
+type APIError struct {
+	Method     string
+	Path       string
+	StatusCode int
+	Message    string
+	Details    []APIErrorDetail
+
+	// RateLimited is set when the request was rejected by the primary or the secondary rate limit,
+	// RetryAfter is then how long GitHub asks us to wait, if it told us.
+	RateLimited bool
+	RetryAfter  time.Duration
+}
+
+// APIErrorDetail describes one of the validation errors of an APIError.
+type APIErrorDetail struct {
+	Resource string `json:"resource"`
+	Field    string `json:"field"`
+	Code     string `json:"code"`
+	Message  string `json:"message"`
+}
+
+func newAPIError(method, path string, resp *http.Response, body []byte) *APIError {
+	e := &APIError{Method: method, Path: path, StatusCode: resp.StatusCode}
+
+	var payload struct {
+		Message string           `json:"message"`
+		Errors  []APIErrorDetail `json:"errors"`
+	}
+
+	if err := json.Unmarshal(body, &payload); err == nil {
+		e.Message = payload.Message
+		e.Details = payload.Errors
+	} else {
+		e.Message = strings.TrimSpace(string(body))
+	}
+
+	e.RateLimited, e.RetryAfter = rateLimitFromResponse(resp, e.Message)
+
+	return e
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("%s %s: HTTP %d", e.Method, e.Path, e.StatusCode)
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+
+	for _, d := range e.Details {
+		switch {
+		case d.Message != "":
+			msg += fmt.Sprintf(" (%s)", d.Message)
+		case d.Code != "":
+			msg += fmt.Sprintf(" (%s %s %s)", d.Resource, d.Field, d.Code)
+		}
+	}
+
+	return msg
+}
+
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrUnauthorized:
+		return !e.RateLimited && (e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden)
+	case ErrRateLimited:
+		return e.RateLimited
+	case ErrValidation:
+		return e.StatusCode == http.StatusUnprocessableEntity
+	case ErrAlreadyExists:
+		if e.StatusCode != http.StatusUnprocessableEntity {
+			return false
+		}
+
+		for _, d := range e.Details {
+			if d.Code == "already_exists" {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// networkError is returned when the request could not be sent or the response could not be read.
+type networkError struct {
+	err error
+}
+
+func (e *networkError) Error() string {
+	return e.err.Error()
+}
+
+func (e *networkError) Unwrap() error {
+	return e.err
+}
@@ -0,0 +1,275 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package githubtest
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// graphql answers the GraphQL queries of the github package: the milestone Pull Requests, the Pull Request
+// status and the tracked item queries, recognized by their text.
+func (s *Server) graphql(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Query     string `json:"query"`
+		Variables struct {
+			Owner  string   `json:"owner"`
+			Name   string   `json:"name"`
+			Number int      `json:"number"`
+			States []string `json:"states"`
+			First  int      `json:"first"`
+			After  string   `json:"after"`
+			ID     string   `json:"id"`
+			Method string   `json:"method"`
+		} `json:"variables"`
+	}
+
+	if !decodeBody(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	v := req.Variables
+	name := v.Owner + "/" + v.Name
+	repo := s.repo(name)
+
+	switch {
+	case strings.Contains(req.Query, "enablePullRequestAutoMerge"):
+		s.enableAutoMerge(w, v.ID, v.Method)
+	case strings.Contains(req.Query, "milestone(number: $number)"):
+		s.milestonePRs(w, name, repo, v.Number, v.States, v.First, v.After)
+	case strings.Contains(req.Query, "issueOrPullRequest(number: $number)"):
+		s.trackedItem(w, name, repo, v.Number)
+	case strings.Contains(req.Query, "pullRequest(number: $number)"):
+		s.prStatus(w, repo, v.Number)
+	default:
+		writeGraphQLError(w, "", "unsupported query")
+	}
+}
+
+// enableAutoMerge enables auto-merge on the Pull Request, like GitHub it refuses to if the
+// Pull Request is approved and green, as it can be merged right away.
+func (s *Server) enableAutoMerge(w http.ResponseWriter, id, method string) {
+	name, nbStr, _ := strings.Cut(strings.TrimPrefix(id, "PR_"), "#")
+	nb, _ := strconv.Atoi(nbStr)
+
+	repo := s.repo(name)
+	if nb <= 0 || nb > len(repo.items) || !repo.items[nb-1].isPR {
+		writeGraphQLError(w, "NOT_FOUND", fmt.Sprintf("Could not resolve to a node with the global id of '%s'", id))
+		return
+	}
+
+	it := repo.items[nb-1]
+	if it.reviewDecision == "APPROVED" && it.checks == "SUCCESS" {
+		writeGraphQLError(w, "UNPROCESSABLE", "Pull request Pull request is in clean status")
+		return
+	}
+
+	if it.autoMerge != "" {
+		writeGraphQLError(w, "UNPROCESSABLE", "Pull request auto merge is already enabled")
+		return
+	}
+
+	it.autoMerge = method
+
+	writeJSON(w, http.StatusOK, map[string]any{"data": map[string]any{"enablePullRequestAutoMerge": map[string]any{"clientMutationId": nil}}})
+}
+
+func (s *Server) prStatus(w http.ResponseWriter, repo *repository, nb int) {
+	if nb <= 0 || nb > len(repo.items) || !repo.items[nb-1].isPR {
+		writeGraphQLError(w, "NOT_FOUND", fmt.Sprintf("Could not resolve to a PullRequest with the number of %d.", nb))
+		return
+	}
+
+	it := repo.items[nb-1]
+
+	state := strings.ToUpper(it.state)
+	if it.merged {
+		state = "MERGED"
+	}
+
+	var rollup any
+	if it.checks != "" {
+		rollup = map[string]any{"state": it.checks}
+	}
+
+	var reviewDecision any
+	if it.reviewDecision != "" {
+		reviewDecision = it.reviewDecision
+	}
+
+	// the fake has no branch protection, a Pull Request can be merged once approved and green
+	mergeStateStatus := "BLOCKED"
+	if state == "OPEN" && it.reviewDecision == "APPROVED" && it.checks == "SUCCESS" {
+		mergeStateStatus = "CLEAN"
+	}
+
+	var autoMergeRequest any
+	if it.autoMerge != "" && state == "OPEN" {
+		autoMergeRequest = map[string]any{"mergeMethod": it.autoMerge}
+	}
+
+	pr := map[string]any{
+		"state":            state,
+		"reviewDecision":   reviewDecision,
+		"mergeStateStatus": mergeStateStatus,
+		"autoMergeRequest": autoMergeRequest,
+		"commits": map[string]any{
+			"nodes": []any{map[string]any{"commit": map[string]any{"statusCheckRollup": rollup}}},
+		},
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"data": map[string]any{"repository": map[string]any{"pullRequest": pr}}})
+}
+
+// trackedItem renders the issue or the Pull Request with its state, the Pull Requests are all created
+// at the same time and are always mergeable, as the fake does not track commits.
+func (s *Server) trackedItem(w http.ResponseWriter, name string, repo *repository, nb int) {
+	if nb <= 0 || nb > len(repo.items) {
+		writeGraphQLError(w, "NOT_FOUND", fmt.Sprintf("Could not resolve to an issue or pull request with the number of %d.", nb))
+		return
+	}
+
+	it := repo.items[nb-1]
+	rest := s.renderIssue(name, repo, it)
+
+	author := map[string]any{"__typename": "User", "login": it.author}
+	if login, ok := strings.CutPrefix(it.author, "app/"); ok {
+		author = map[string]any{"__typename": "Bot", "login": login}
+	}
+
+	out := map[string]any{
+		"__typename": "Issue",
+		"number":     it.number,
+		"title":      it.title,
+		"url":        rest["html_url"],
+		"state":      strings.ToUpper(it.state),
+		"createdAt":  "2024-01-01T00:00:00Z",
+		"author":     author,
+		"labels":     map[string]any{"nodes": s.renderLabels(repo, it.labels)},
+	}
+
+	if it.isPR {
+		var rollup any
+		if it.checks != "" {
+			rollup = map[string]any{"state": it.checks}
+		}
+
+		var reviewDecision any
+		if it.reviewDecision != "" {
+			reviewDecision = it.reviewDecision
+		}
+
+		out["__typename"] = "PullRequest"
+		out["baseRefName"] = it.base
+		out["reviewDecision"] = reviewDecision
+		out["mergeable"] = "MERGEABLE"
+		out["commits"] = map[string]any{
+			"nodes": []any{map[string]any{"commit": map[string]any{"statusCheckRollup": rollup}}},
+		}
+
+		if it.merged {
+			out["state"] = "MERGED"
+		}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"data": map[string]any{"repository": map[string]any{"issueOrPullRequest": out}}})
+}
+
+func (s *Server) milestonePRs(w http.ResponseWriter, name string, repo *repository, number int, states []string, first int, after string) {
+
+	if number <= 0 || number > len(repo.milestones) {
+		writeJSON(w, http.StatusOK, map[string]any{"data": map[string]any{"repository": map[string]any{"milestone": nil}}})
+		return
+	}
+
+	var nodes []map[string]any
+
+	for _, it := range slices.Backward(repo.items) {
+		if !it.isPR || it.milestone != number {
+			continue
+		}
+
+		state := strings.ToUpper(it.state)
+		if it.merged {
+			state = "MERGED"
+		}
+
+		if len(states) > 0 && !slices.Contains(states, state) {
+			continue
+		}
+
+		nodes = append(nodes, s.renderGraphQLPR(name, repo, it, state))
+	}
+
+	start, _ := strconv.Atoi(after)
+	start = min(start, len(nodes))
+	end := min(start+max(first, 1), len(nodes))
+
+	connection := map[string]any{
+		"pageInfo": map[string]any{"hasNextPage": end < len(nodes), "endCursor": strconv.Itoa(end)},
+		"nodes":    nodes[start:end],
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"data": map[string]any{
+			"repository": map[string]any{
+				"milestone": map[string]any{"pullRequests": connection},
+			},
+		},
+	})
+}
+
+func (s *Server) renderGraphQLPR(repoName string, repo *repository, it *item, state string) map[string]any {
+	rest := s.renderPR(repoName, repo, it)
+
+	author := map[string]any{"__typename": "User", "login": it.author}
+	if name, ok := strings.CutPrefix(it.author, "app/"); ok {
+		author = map[string]any{"__typename": "Bot", "login": name}
+	}
+
+	out := map[string]any{
+		"number":      it.number,
+		"title":       it.title,
+		"body":        it.body,
+		"url":         rest["html_url"],
+		"state":       state,
+		"baseRefName": it.base,
+		"headRefName": rest["head"].(map[string]any)["ref"],
+		"mergeCommit": nil,
+		"author":      author,
+		"labels":      map[string]any{"nodes": s.renderLabels(repo, it.labels)},
+	}
+
+	if it.merged {
+		out["mergeCommit"] = map[string]any{"oid": it.mergeCommit}
+	}
+
+	return out
+}
+
+func writeGraphQLError(w http.ResponseWriter, errType, message string) {
+	writeJSON(w, http.StatusOK, map[string]any{
+		"data":   nil,
+		"errors": []map[string]any{{"type": errType, "message": message}},
+	})
+}
@@ -0,0 +1,1356 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package githubtest
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/vitessio/vitess-releaser/go/releaser/github"
+)
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string, details ...github.APIErrorDetail) {
+	writeJSON(w, status, map[string]any{"message": message, "errors": details})
+}
+
+// writePage writes the requested page of items, with a Link header pointing to the next page.
+func writePage[T any](w http.ResponseWriter, r *http.Request, items []T, wrap func([]T) any) {
+	perPage, _ := strconv.Atoi(r.URL.Query().Get("per_page"))
+	if perPage <= 0 {
+		perPage = 30
+	}
+
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	if page <= 0 {
+		page = 1
+	}
+
+	start := min((page-1)*perPage, len(items))
+	end := min(start+perPage, len(items))
+
+	if end < len(items) {
+		q := r.URL.Query()
+		q.Set("page", strconv.Itoa(page+1))
+
+		next := url.URL{Scheme: "http", Host: r.Host, Path: r.URL.Path, RawQuery: q.Encode()}
+		w.Header().Set("Link", fmt.Sprintf(`<%s>; rel="next"`, next.String()))
+	}
+
+	if wrap == nil {
+		writeJSON(w, http.StatusOK, items[start:end])
+		return
+	}
+
+	writeJSON(w, http.StatusOK, wrap(items[start:end]))
+}
+
+func decodeBody(w http.ResponseWriter, r *http.Request, v any) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, "Problems parsing JSON")
+		return false
+	}
+
+	return true
+}
+
+func repoName(r *http.Request) string {
+	return r.PathValue("owner") + "/" + r.PathValue("repo")
+}
+
+// lookupItem returns the issue or Pull Request of the request, or writes a 404.
+func (s *Server) lookupItem(w http.ResponseWriter, r *http.Request, repo *repository, wantPR bool) *item {
+	nb, _ := strconv.Atoi(r.PathValue("nb"))
+
+	it := repo.item(nb)
+	if it == nil || (wantPR && !it.isPR) {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return nil
+	}
+
+	return it
+}
+
+func (s *Server) renderLabels(repo *repository, names []string) []github.Label {
+	labels := make([]github.Label, 0, len(names))
+	for _, name := range names {
+		l, ok := repo.labels[name]
+		if !ok {
+			l = github.Label{Name: name}
+		}
+
+		labels = append(labels, l)
+	}
+
+	return labels
+}
+
+func renderUser(login string) map[string]any {
+	if name, ok := strings.CutPrefix(login, "app/"); ok {
+		return map[string]any{"login": name + "[bot]", "type": "Bot"}
+	}
+
+	return map[string]any{"login": login, "type": "User"}
+}
+
+func (s *Server) renderIssue(repoName string, repo *repository, it *item) map[string]any {
+	kind := "issues"
+	if it.isPR {
+		kind = "pull"
+	}
+
+	out := map[string]any{
+		"number":   it.number,
+		"title":    it.title,
+		"body":     it.body,
+		"state":    it.state,
+		"html_url": fmt.Sprintf("https://github.com/%s/%s/%d", repoName, kind, it.number),
+		"labels":   s.renderLabels(repo, it.labels),
+		"user":     renderUser(it.author),
+		"assignee": nil,
+	}
+
+	if it.assignee != "" {
+		out["assignee"] = renderUser(it.assignee)
+	}
+
+	if it.isPR {
+		out["pull_request"] = map[string]any{}
+	}
+
+	return out
+}
+
+func (s *Server) renderPR(repoName string, repo *repository, it *item) map[string]any {
+	out := s.renderIssue(repoName, repo, it)
+	delete(out, "pull_request")
+
+	owner := strings.Split(repoName, "/")[0]
+	headOwner, headRef, found := strings.Cut(it.head, ":")
+
+	if !found {
+		headOwner, headRef = owner, it.head
+	}
+
+	out["head"] = map[string]any{"ref": headRef, "label": headOwner + ":" + headRef, "sha": HeadSHA(it.number)}
+	out["base"] = map[string]any{"ref": it.base}
+	out["node_id"] = prNodeID(repoName, it.number)
+	out["merged_at"] = nil
+	out["merge_commit_sha"] = nil
+
+	if it.merged {
+		out["merged_at"] = "2024-01-01T00:00:00Z"
+		out["merge_commit_sha"] = it.mergeCommit
+	}
+
+	return out
+}
+
+func (s *Server) getUser(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, renderUser(s.User))
+}
+
+func (s *Server) createIssue(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Title     string   `json:"title"`
+		Body      string   `json:"body"`
+		Labels    []string `json:"labels"`
+		Assignees []string `json:"assignees"`
+	}
+
+	if !decodeBody(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	repo := s.repo(repoName(r))
+	it := &item{title: req.Title, body: req.Body, state: "open", labels: req.Labels, author: s.User}
+
+	if len(req.Assignees) > 0 {
+		it.assignee = req.Assignees[0]
+	}
+
+	repo.add(it)
+	writeJSON(w, http.StatusCreated, s.renderIssue(repoName(r), repo, it))
+}
+
+func (s *Server) listIssues(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	repo := s.repo(repoName(r))
+	state := r.URL.Query().Get("state")
+
+	var labels []string
+	if l := r.URL.Query().Get("labels"); l != "" {
+		labels = strings.Split(l, ",")
+	}
+
+	var out []map[string]any
+
+	ms, _ := strconv.Atoi(r.URL.Query().Get("milestone"))
+
+	for _, it := range slices.Backward(repo.items) {
+		if !matchState(it, state) || !hasLabels(it, labels) || (ms != 0 && it.milestone != ms) {
+			continue
+		}
+
+		out = append(out, s.renderIssue(repoName(r), repo, it))
+	}
+
+	writePage(w, r, out, nil)
+}
+
+func (s *Server) getIssue(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	repo := s.repo(repoName(r))
+	if it := s.lookupItem(w, r, repo, false); it != nil {
+		writeJSON(w, http.StatusOK, s.renderIssue(repoName(r), repo, it))
+	}
+}
+
+func (s *Server) editIssue(w http.ResponseWriter, r *http.Request) {
+	var req map[string]any
+	if !decodeBody(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	repo := s.repo(repoName(r))
+
+	it := s.lookupItem(w, r, repo, false)
+	if it == nil {
+		return
+	}
+
+	if v, ok := req["body"].(string); ok {
+		it.body = v
+	}
+
+	if v, ok := req["title"].(string); ok {
+		it.title = v
+	}
+
+	if v, ok := req["state"].(string); ok {
+		it.state = v
+	}
+
+	if v, ok := req["milestone"].(float64); ok {
+		if int(v) < 1 || int(v) > len(repo.milestones) {
+			writeError(w, http.StatusUnprocessableEntity, "Validation Failed", github.APIErrorDetail{Resource: "Issue", Field: "milestone", Code: "invalid"})
+			return
+		}
+
+		it.milestone = int(v)
+	}
+
+	writeJSON(w, http.StatusOK, s.renderIssue(repoName(r), repo, it))
+}
+
+func (s *Server) createComment(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Body string `json:"body"`
+	}
+
+	if !decodeBody(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if it := s.lookupItem(w, r, s.repo(repoName(r)), false); it != nil {
+		it.comments = append(it.comments, req.Body)
+		writeJSON(w, http.StatusCreated, map[string]any{"body": req.Body})
+	}
+}
+
+func (s *Server) addLabels(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Labels []string `json:"labels"`
+	}
+
+	if !decodeBody(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	repo := s.repo(repoName(r))
+
+	it := s.lookupItem(w, r, repo, false)
+	if it == nil {
+		return
+	}
+
+	for _, l := range req.Labels {
+		if !slices.Contains(it.labels, l) {
+			it.labels = append(it.labels, l)
+		}
+	}
+
+	writeJSON(w, http.StatusOK, s.renderLabels(repo, it.labels))
+}
+
+func (s *Server) removeLabel(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	repo := s.repo(repoName(r))
+
+	it := s.lookupItem(w, r, repo, false)
+	if it == nil {
+		return
+	}
+
+	i := slices.Index(it.labels, r.PathValue("name"))
+	if i < 0 {
+		writeError(w, http.StatusNotFound, "Label does not exist")
+		return
+	}
+
+	it.labels = slices.Delete(it.labels, i, i+1)
+
+	writeJSON(w, http.StatusOK, s.renderLabels(repo, it.labels))
+}
+
+func (s *Server) createPR(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Title string `json:"title"`
+		Body  string `json:"body"`
+		Head  string `json:"head"`
+		Base  string `json:"base"`
+	}
+
+	if !decodeBody(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	repo := s.repo(repoName(r))
+
+	for _, it := range repo.items {
+		if it.isPR && it.state == "open" && it.head == req.Head && it.base == req.Base {
+			writeError(w, http.StatusUnprocessableEntity, "Validation Failed", github.APIErrorDetail{
+				Resource: "PullRequest", Code: "custom", Message: "A pull request already exists for " + req.Head + ".",
+			})
+
+			return
+		}
+	}
+
+	it := &item{title: req.Title, body: req.Body, state: "open", author: s.User, isPR: true, head: req.Head, base: req.Base}
+	repo.add(it)
+
+	writeJSON(w, http.StatusCreated, s.renderPR(repoName(r), repo, it))
+}
+
+func (s *Server) listPRs(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	name := repoName(r)
+	repo := s.repo(name)
+	q := r.URL.Query()
+
+	var out []map[string]any
+
+	for _, it := range slices.Backward(repo.items) {
+		if !it.isPR || !matchState(it, q.Get("state")) {
+			continue
+		}
+
+		if base := q.Get("base"); base != "" && it.base != base {
+			continue
+		}
+
+		if head := q.Get("head"); head != "" && qualifiedHead(name, it.head) != head {
+			continue
+		}
+
+		out = append(out, s.renderPR(name, repo, it))
+	}
+
+	writePage(w, r, out, nil)
+}
+
+func (s *Server) getPR(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	repo := s.repo(repoName(r))
+	if it := s.lookupItem(w, r, repo, true); it != nil {
+		writeJSON(w, http.StatusOK, s.renderPR(repoName(r), repo, it))
+	}
+}
+
+func (s *Server) requestReviewers(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Reviewers     []string `json:"reviewers"`
+		TeamReviewers []string `json:"team_reviewers"`
+	}
+	if !decodeBody(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	repo := s.repo(repoName(r))
+
+	it := s.lookupItem(w, r, repo, true)
+	if it == nil {
+		return
+	}
+
+	if slices.Contains(req.Reviewers, it.author) {
+		writeError(w, http.StatusUnprocessableEntity, "Review cannot be requested from pull request author.")
+		return
+	}
+
+	it.reviewers = append(it.reviewers, req.Reviewers...)
+	it.teamReviewers = append(it.teamReviewers, req.TeamReviewers...)
+
+	writeJSON(w, http.StatusCreated, s.renderPR(repoName(r), repo, it))
+}
+
+func (s *Server) searchIssues(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var (
+		repoFilter, state, milestoneTitle string
+		onlyPR, onlyIssues, merged        bool
+		words, labels                     []string
+	)
+
+	for _, term := range searchTerms(r.URL.Query().Get("q")) {
+		switch {
+		case strings.HasPrefix(term, "repo:"):
+			repoFilter = strings.TrimPrefix(term, "repo:")
+		case term == "is:pr":
+			onlyPR = true
+		case term == "is:issue":
+			onlyIssues = true
+		case term == "is:open" || term == "is:closed":
+			state = strings.TrimPrefix(term, "is:")
+		case term == "is:merged":
+			merged = true
+		case strings.HasPrefix(term, "milestone:"):
+			milestoneTitle = strings.Trim(strings.TrimPrefix(term, "milestone:"), `"`)
+		case strings.HasPrefix(term, "label:"):
+			labels = append(labels, strings.Trim(strings.TrimPrefix(term, "label:"), `"`))
+		default:
+			words = append(words, strings.ToLower(term))
+		}
+	}
+
+	repo := s.repo(repoFilter)
+
+	var out []map[string]any
+
+	for _, it := range slices.Backward(repo.items) {
+		switch {
+		case onlyPR && !it.isPR, onlyIssues && it.isPR:
+			continue
+		case state != "" && it.state != state, merged && !it.merged:
+			continue
+		case milestoneTitle != "" && (it.milestone == 0 || repo.milestones[it.milestone-1].Title != milestoneTitle):
+			continue
+		case !hasLabels(it, labels):
+			continue
+		}
+
+		title := strings.ToLower(it.title)
+		if slices.ContainsFunc(words, func(w string) bool { return !strings.Contains(title, w) }) {
+			continue
+		}
+
+		out = append(out, s.renderIssue(repoFilter, repo, it))
+	}
+
+	total := len(out)
+	out = out[:min(total, maxSearchResults)]
+
+	writePage(w, r, out, func(items []map[string]any) any {
+		return map[string]any{"total_count": total, "items": items}
+	})
+}
+
+// maxSearchResults is the number of results the search API gives access to, whatever the total count.
+const maxSearchResults = 1000
+
+// searchTerms splits a search query on spaces, except in double quotes: label:"Backport to: release-21.0".
+func searchTerms(q string) []string {
+	var (
+		terms   []string
+		current strings.Builder
+		quoted  bool
+	)
+
+	for _, c := range q {
+		switch {
+		case c == '"':
+			quoted = !quoted
+			current.WriteRune(c)
+		case c == ' ' && !quoted:
+			if current.Len() > 0 {
+				terms = append(terms, current.String())
+				current.Reset()
+			}
+		default:
+			current.WriteRune(c)
+		}
+	}
+
+	if current.Len() > 0 {
+		terms = append(terms, current.String())
+	}
+
+	return terms
+}
+
+func (s *Server) createLabel(w http.ResponseWriter, r *http.Request) {
+	var label github.Label
+	if !decodeBody(w, r, &label) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	repo := s.repo(repoName(r))
+
+	if _, ok := repo.labels[label.Name]; ok {
+		writeError(w, http.StatusUnprocessableEntity, "Validation Failed", github.APIErrorDetail{Resource: "Label", Field: "name", Code: "already_exists"})
+		return
+	}
+
+	repo.labels[label.Name] = label
+	writeJSON(w, http.StatusCreated, label)
+}
+
+func (s *Server) listLabels(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	labels := []github.Label{}
+	for _, l := range s.repo(repoName(r)).labels {
+		labels = append(labels, l)
+	}
+
+	slices.SortFunc(labels, func(a, b github.Label) int { return strings.Compare(a.Name, b.Name) })
+
+	writePage(w, r, labels, nil)
+}
+
+func (s *Server) editLabel(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		github.Label
+
+		NewName string `json:"new_name"`
+	}
+	if !decodeBody(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	repo := s.repo(repoName(r))
+
+	name := r.PathValue("name")
+
+	label, ok := repo.labels[name]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	if req.Color != "" {
+		label.Color = req.Color
+	}
+
+	if req.Description != "" {
+		label.Description = req.Description
+	}
+
+	if req.NewName != "" {
+		label.Name = req.NewName
+
+		// the issues and Pull Requests keep the label under its new name
+		for _, it := range repo.items {
+			if i := slices.Index(it.labels, name); i != -1 {
+				it.labels[i] = req.NewName
+			}
+		}
+	}
+
+	delete(repo.labels, name)
+	repo.labels[label.Name] = label
+	writeJSON(w, http.StatusOK, label)
+}
+
+func (s *Server) listMilestones(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state := r.URL.Query().Get("state")
+
+	var out []milestone
+
+	repo := s.repo(repoName(r))
+
+	for _, m := range repo.milestones {
+		if state == "all" || m.State == state || (state == "" && m.State == "open") {
+			out = append(out, repo.renderMilestone(m))
+		}
+	}
+
+	writePage(w, r, out, nil)
+}
+
+func (s *Server) createMilestone(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Title       string  `json:"title"`
+		Description string  `json:"description"`
+		DueOn       *string `json:"due_on"`
+	}
+
+	if !decodeBody(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	repo := s.repo(repoName(r))
+
+	for _, m := range repo.milestones {
+		if m.Title == req.Title {
+			writeError(w, http.StatusUnprocessableEntity, "Validation Failed", github.APIErrorDetail{Resource: "Milestone", Field: "title", Code: "already_exists"})
+			return
+		}
+	}
+
+	m := repo.addMilestone(repoName(r), req.Title)
+	m.Description = req.Description
+	m.DueOn = req.DueOn
+
+	writeJSON(w, http.StatusCreated, repo.renderMilestone(m))
+}
+
+func (s *Server) editMilestone(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		State       string  `json:"state"`
+		Description string  `json:"description"`
+		DueOn       *string `json:"due_on"`
+	}
+
+	if !decodeBody(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	repo := s.repo(repoName(r))
+
+	nb, _ := strconv.Atoi(r.PathValue("nb"))
+	if nb < 1 || nb > len(repo.milestones) {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	m := repo.milestones[nb-1]
+	if req.State != "" {
+		m.State = req.State
+	}
+
+	if req.Description != "" {
+		m.Description = req.Description
+	}
+
+	if req.DueOn != nil {
+		m.DueOn = req.DueOn
+	}
+
+	writeJSON(w, http.StatusOK, repo.renderMilestone(m))
+}
+
+func (s *Server) getTag(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tag := r.PathValue("tag")
+
+	sha, ok := s.repo(repoName(r)).tags[tag]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"ref": "refs/tags/" + tag, "object": map[string]any{"sha": sha}})
+}
+
+func (s *Server) createRelease(w http.ResponseWriter, r *http.Request) {
+	var req map[string]any
+	if !decodeBody(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	repo := s.repo(repoName(r))
+
+	for _, rel := range repo.releases {
+		if rel["tag_name"] == req["tag_name"] {
+			writeError(w, http.StatusUnprocessableEntity, "Validation Failed", github.APIErrorDetail{Resource: "Release", Field: "tag_name", Code: "already_exists"})
+			return
+		}
+	}
+
+	id := len(repo.releases) + 1
+	req["id"] = id
+	req["html_url"] = fmt.Sprintf("https://github.com/%s/releases/tag/%v", repoName(r), req["tag_name"])
+	req["upload_url"] = fmt.Sprintf("http://%s/repos/%s/releases/%d/assets{?name,label}", r.Host, repoName(r), id)
+	repo.releases = append(repo.releases, req)
+	repo.setLatest(req)
+
+	writeJSON(w, http.StatusCreated, req)
+}
+
+// setLatest marks the release as the latest one if it is asked to and is not a prerelease.
+func (repo *repository) setLatest(rel map[string]any) {
+	if rel["make_latest"] == "true" && rel["prerelease"] != true {
+		repo.latestRelease = rel["id"].(int)
+	}
+
+	delete(rel, "make_latest")
+}
+
+// release returns the release with the given id, from the path, or nil.
+func (repo *repository) release(id string) map[string]any {
+	for _, rel := range repo.releases {
+		if strconv.Itoa(rel["id"].(int)) == id {
+			return rel
+		}
+	}
+
+	return nil
+}
+
+// getReleaseSubresource serves releases/tags/{tag} and releases/{id}/assets, whose patterns conflict.
+func (s *Server) getReleaseSubresource(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.PathValue("id") == "tags":
+		s.getReleaseByTag(w, r, r.PathValue("sub"))
+	case r.PathValue("sub") == "assets":
+		s.listReleaseAssets(w, r)
+	default:
+		writeError(w, http.StatusNotFound, "Not Found")
+	}
+}
+
+func (s *Server) getReleaseByTag(w http.ResponseWriter, r *http.Request, tag string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, rel := range s.repo(repoName(r)).releases {
+		if rel["tag_name"] == tag {
+			writeJSON(w, http.StatusOK, rel)
+			return
+		}
+	}
+
+	writeError(w, http.StatusNotFound, "Not Found")
+}
+
+func (s *Server) getLatestRelease(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	repo := s.repo(repoName(r))
+
+	rel := repo.release(strconv.Itoa(repo.latestRelease))
+	if rel == nil {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, rel)
+}
+
+func (s *Server) editRelease(w http.ResponseWriter, r *http.Request) {
+	var req map[string]any
+	if !decodeBody(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	repo := s.repo(repoName(r))
+
+	rel := repo.release(r.PathValue("id"))
+	if rel == nil {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	for _, field := range []string{"tag_name", "target_commitish", "name", "body", "prerelease", "make_latest"} {
+		if v, ok := req[field]; ok {
+			rel[field] = v
+		}
+	}
+
+	if rel["prerelease"] == true && repo.latestRelease == rel["id"] {
+		repo.latestRelease = 0
+	}
+
+	repo.setLatest(rel)
+
+	writeJSON(w, http.StatusOK, rel)
+}
+
+func (s *Server) listReleaseAssets(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	assets := s.repo(repoName(r)).assets[r.PathValue("id")]
+	if assets == nil {
+		assets = []github.ReleaseAsset{}
+	}
+
+	writePage(w, r, assets, nil)
+}
+
+func (s *Server) uploadReleaseAsset(w http.ResponseWriter, r *http.Request) {
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	repo := s.repo(repoName(r))
+	id := r.PathValue("id")
+	name := r.URL.Query().Get("name")
+
+	if repo.release(id) == nil {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	if slices.ContainsFunc(repo.assets[id], func(a github.ReleaseAsset) bool { return a.Name == name }) {
+		writeError(w, http.StatusUnprocessableEntity, "Validation Failed", github.APIErrorDetail{Resource: "ReleaseAsset", Field: "name", Code: "already_exists"})
+		return
+	}
+
+	repo.lastAssetID++
+	asset := github.ReleaseAsset{
+		ID:     repo.lastAssetID,
+		Name:   name,
+		Size:   int64(len(data)),
+		Digest: fmt.Sprintf("sha256:%x", sha256.Sum256(data)),
+		URL:    fmt.Sprintf("https://github.com/%s/releases/download/%v/%s", repoName(r), repo.release(id)["tag_name"], name),
+	}
+
+	if repo.assets == nil {
+		repo.assets = map[string][]github.ReleaseAsset{}
+	}
+
+	repo.assets[id] = append(repo.assets[id], asset)
+
+	if repo.assetData == nil {
+		repo.assetData = map[int64][]byte{}
+	}
+
+	repo.assetData[asset.ID] = data
+
+	writeJSON(w, http.StatusCreated, asset)
+}
+
+// downloadReleaseAsset returns the metadata of the asset, or its content when asked for application/octet-stream.
+func (s *Server) downloadReleaseAsset(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	repo := s.repo(repoName(r))
+	id, _ := strconv.ParseInt(r.PathValue("id"), 10, 64)
+
+	for _, assets := range repo.assets {
+		i := slices.IndexFunc(assets, func(a github.ReleaseAsset) bool { return a.ID == id })
+		if i < 0 {
+			continue
+		}
+
+		if r.Header.Get("Accept") != "application/octet-stream" {
+			writeJSON(w, http.StatusOK, assets[i])
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/octet-stream")
+		_, _ = w.Write(repo.assetData[id])
+
+		return
+	}
+
+	writeError(w, http.StatusNotFound, "Not Found")
+}
+
+func (s *Server) deleteReleaseAsset(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	repo := s.repo(repoName(r))
+	id, _ := strconv.ParseInt(r.PathValue("id"), 10, 64)
+
+	for release, assets := range repo.assets {
+		if i := slices.IndexFunc(assets, func(a github.ReleaseAsset) bool { return a.ID == id }); i >= 0 {
+			repo.assets[release] = slices.Delete(assets, i, i+1)
+			delete(repo.assetData, id)
+			w.WriteHeader(http.StatusNoContent)
+
+			return
+		}
+	}
+
+	writeError(w, http.StatusNotFound, "Not Found")
+}
+
+func (s *Server) getProtection(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, ok := s.repo(repoName(r)).protections[r.PathValue("branch")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Branch not protected")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, p)
+}
+
+func (s *Server) putProtection(w http.ResponseWriter, r *http.Request) {
+	var req github.BranchProtectionUpdate
+	if !decodeBody(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	repo := s.repo(repoName(r))
+	branch := r.PathValue("branch")
+	p := renderProtection(req, repo.signatures[branch])
+
+	repo.protections[branch], _ = json.Marshal(p)
+	writeJSON(w, http.StatusOK, p)
+}
+
+// renderProtection returns the protection set by an update, in the format it is read from the API.
+// Like GitHub, it does not keep the order in which the lists were given: they are sorted.
+func renderProtection(u github.BranchProtectionUpdate, signatures bool) map[string]any {
+	enabled := func(b bool) map[string]any { return map[string]any{"enabled": b} }
+	logins := func(names []string, key string) []map[string]any {
+		l := []map[string]any{}
+		for _, n := range slices.Sorted(slices.Values(names)) {
+			l = append(l, map[string]any{key: n})
+		}
+
+		return l
+	}
+
+	p := map[string]any{
+		"url":                              "protection",
+		"enforce_admins":                   enabled(u.EnforceAdmins),
+		"required_signatures":              enabled(signatures),
+		"required_linear_history":          enabled(u.RequiredLinearHistory),
+		"allow_force_pushes":               enabled(u.AllowForcePushes),
+		"allow_deletions":                  enabled(u.AllowDeletions),
+		"block_creations":                  enabled(u.BlockCreations),
+		"required_conversation_resolution": enabled(u.RequiredConversationResolution),
+		"lock_branch":                      enabled(u.LockBranch),
+		"allow_fork_syncing":               enabled(u.AllowForkSyncing),
+	}
+
+	if c := u.RequiredStatusChecks; c != nil {
+		checks := slices.Clone(c.Checks)
+		sort.SliceStable(checks, func(i, j int) bool { return checks[i].Context < checks[j].Context })
+
+		contexts := []string{}
+		for _, check := range checks {
+			contexts = append(contexts, check.Context)
+		}
+
+		p["required_status_checks"] = map[string]any{"url": "checks", "strict": c.Strict, "checks": checks, "contexts": contexts}
+	}
+
+	if rv := u.RequiredPullRequestReviews; rv != nil {
+		p["required_pull_request_reviews"] = map[string]any{
+			"url":                             "reviews",
+			"dismiss_stale_reviews":           rv.DismissStaleReviews,
+			"require_code_owner_reviews":      rv.RequireCodeOwnerReviews,
+			"require_last_push_approval":      rv.RequireLastPushApproval,
+			"required_approving_review_count": rv.RequiredApprovingReviewCount,
+			"bypass_pull_request_allowances": map[string]any{
+				"users": logins(rv.BypassPullRequestAllowances.Users, "login"),
+				"teams": logins(rv.BypassPullRequestAllowances.Teams, "slug"),
+				"apps":  logins(rv.BypassPullRequestAllowances.Apps, "slug"),
+			},
+		}
+	}
+
+	if rs := u.Restrictions; rs != nil {
+		p["restrictions"] = map[string]any{
+			"url":   "restrictions",
+			"users": logins(rs.Users, "login"),
+			"teams": logins(rs.Teams, "slug"),
+			"apps":  logins(rs.Apps, "slug"),
+		}
+	}
+
+	return p
+}
+
+func (s *Server) setSignatures(enabled bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		repo := s.repo(repoName(r))
+		if _, ok := repo.protections[r.PathValue("branch")]; !ok {
+			writeError(w, http.StatusNotFound, "Branch not protected")
+			return
+		}
+
+		repo.signatures[r.PathValue("branch")] = enabled
+
+		var p map[string]any
+		_ = json.Unmarshal(repo.protections[r.PathValue("branch")], &p)
+		p["required_signatures"] = map[string]any{"enabled": enabled}
+		repo.protections[r.PathValue("branch")], _ = json.Marshal(p)
+
+		if enabled {
+			writeJSON(w, http.StatusOK, map[string]any{"enabled": true})
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (s *Server) getRepo(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{"full_name": repoName(r), "default_branch": "main"})
+}
+
+func (s *Server) listRulesets(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rulesets := []github.Ruleset{}
+
+	for _, rs := range s.repo(repoName(r)).rulesets {
+		rs.Conditions = nil
+		rulesets = append(rulesets, rs)
+	}
+
+	writePage(w, r, rulesets, nil)
+}
+
+func (s *Server) lookupRuleset(w http.ResponseWriter, r *http.Request) *github.Ruleset {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+
+	repo := s.repo(repoName(r))
+	if err != nil || id < 1 || id > int64(len(repo.rulesets)) {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return nil
+	}
+
+	return &repo.rulesets[id-1]
+}
+
+func (s *Server) getRuleset(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if rs := s.lookupRuleset(w, r); rs != nil {
+		writeJSON(w, http.StatusOK, rs)
+	}
+}
+
+func (s *Server) putRuleset(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Conditions *github.RulesetConditions `json:"conditions"`
+	}
+	if !decodeBody(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rs := s.lookupRuleset(w, r)
+	if rs == nil {
+		return
+	}
+
+	if req.Conditions != nil {
+		rs.Conditions = req.Conditions
+	}
+
+	writeJSON(w, http.StatusOK, rs)
+}
+
+func matchState(it *item, state string) bool {
+	switch state {
+	case "all":
+		return true
+	case "", "open":
+		return it.state == "open"
+	default:
+		return it.state == state
+	}
+}
+
+func hasLabels(it *item, labels []string) bool {
+	for _, l := range labels {
+		if !slices.Contains(it.labels, l) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func qualifiedHead(repo, head string) string {
+	if strings.Contains(head, ":") {
+		return head
+	}
+
+	return strings.Split(repo, "/")[0] + ":" + head
+}
+
+func prNodeID(repoName string, nb int) string {
+	return fmt.Sprintf("PR_%s#%d", repoName, nb)
+}
+
+// mergePR merges the Pull Request, the merge commit depends on the merge method.
+func (s *Server) mergePR(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		MergeMethod string `json:"merge_method"`
+	}
+
+	if !decodeBody(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	repo := s.repo(repoName(r))
+
+	it := s.lookupItem(w, r, repo, true)
+	if it == nil {
+		return
+	}
+
+	if it.state != "open" {
+		writeError(w, http.StatusMethodNotAllowed, "Pull Request is not mergeable")
+		return
+	}
+
+	s.merge(repo, it, req.MergeMethod)
+
+	writeJSON(w, http.StatusOK, map[string]any{"sha": it.mergeCommit, "merged": true})
+}
+
+// merge merges the Pull Request with the given method: merge, squash or rebase. A rebase copies each commit
+// of the Pull Request on top of the base branch, the merge commit is the copy of the last one.
+func (s *Server) merge(repo *repository, it *item, method string) {
+	newSHA := func() string { return fmt.Sprintf("%040x", len(repo.commits)+1) }
+
+	// The base branch stands for its tip commit, which has to be readable as the parent of the merge
+	parent := it.base
+	if _, ok := repo.commits[parent]; !ok {
+		repo.commits[parent] = commit{message: "Tip of " + it.base}
+	}
+
+	switch method {
+	case "", "merge":
+		parent = newSHA()
+		repo.commits[parent] = commit{message: fmt.Sprintf("Merge pull request #%d from %s\n\n%s", it.number, it.head, it.title), parents: []string{it.base, it.head}}
+	case "rebase":
+		for _, message := range it.headCommits() {
+			sha := newSHA()
+			repo.commits[sha] = commit{message: message, parents: []string{parent}}
+			parent = sha
+		}
+	default:
+		var body []string
+		for _, message := range it.headCommits() {
+			body = append(body, "* "+message)
+		}
+
+		sha := newSHA()
+		repo.commits[sha] = commit{message: fmt.Sprintf("%s (#%d)\n\n%s", it.title, it.number, strings.Join(body, "\n")), parents: []string{it.base}}
+		parent = sha
+	}
+
+	it.state, it.merged, it.mergeCommit = "closed", true, parent
+}
+
+func (it *item) headCommits() []string {
+	if len(it.commits) == 0 {
+		return []string{it.title}
+	}
+
+	return it.commits
+}
+
+// listPRCommits lists the commits of the Pull Request, the last one being its head commit.
+func (s *Server) listPRCommits(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	it := s.lookupItem(w, r, s.repo(repoName(r)), true)
+	if it == nil {
+		return
+	}
+
+	messages := it.headCommits()
+	commits := make([]map[string]any, 0, len(messages))
+
+	for i, message := range messages {
+		sha := fmt.Sprintf("%040x", 0xeeee0000+it.number*1000+i)
+		if i == len(messages)-1 {
+			sha = HeadSHA(it.number)
+		}
+
+		parent := it.base
+		if i > 0 {
+			parent = commits[i-1]["sha"].(string)
+		}
+
+		commits = append(commits, map[string]any{
+			"sha":     sha,
+			"commit":  map[string]any{"message": message},
+			"parents": []map[string]any{{"sha": parent}},
+		})
+	}
+
+	writePage(w, r, commits, nil)
+}
+
+func (s *Server) getCommit(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sha := r.PathValue("sha")
+
+	c, ok := s.repo(repoName(r)).commits[sha]
+	if !ok {
+		writeError(w, http.StatusNotFound, "No commit found for SHA: "+sha)
+		return
+	}
+
+	parents := make([]map[string]any, 0, len(c.parents))
+	for _, p := range c.parents {
+		parents = append(parents, map[string]any{"sha": p})
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"sha":     sha,
+		"commit":  map[string]any{"message": c.message},
+		"parents": parents,
+	})
+}
+
+func (s *Server) listCheckRuns(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	runs := s.repo(repoName(r)).checkRuns[r.PathValue("sha")]
+
+	writePage(w, r, runs, func(page []checkRun) any {
+		return map[string]any{"total_count": len(runs), "check_runs": page}
+	})
+}
+
+func (s *Server) getCombinedStatus(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	statuses := s.repo(repoName(r)).statuses[r.PathValue("sha")]
+	if statuses == nil {
+		statuses = []map[string]any{}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"sha": r.PathValue("sha"), "statuses": statuses})
+}
+
+// getJobLogs redirects to the logs, like GitHub which redirects to a temporary URL.
+func (s *Server) getJobLogs(w http.ResponseWriter, r *http.Request) {
+	u := fmt.Sprintf("/raw/logs/%s/%s", url.PathEscape(repoName(r)), r.PathValue("id"))
+	http.Redirect(w, r, u, http.StatusFound)
+}
+
+func (s *Server) getRawLogs(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id, _ := strconv.ParseInt(r.PathValue("id"), 10, 64)
+
+	for _, runs := range s.repo(r.PathValue("repo")).checkRuns {
+		for _, run := range runs {
+			if run.ID == id {
+				w.Header().Set("Content-Type", "text/plain")
+				_, _ = w.Write([]byte(run.logs))
+
+				return
+			}
+		}
+	}
+
+	writeError(w, http.StatusNotFound, "Not Found")
+}
@@ -0,0 +1,634 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package githubtest provides an in-memory fake of the GitHub REST API, so the
+// github package and the code using it can be tested offline.
+package githubtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/vitessio/vitess-releaser/go/releaser/github"
+)
+
+// Server is a fake GitHub API. Repositories are created on first use.
+type Server struct {
+	*httptest.Server
+
+	// User is the login of the authenticated user.
+	User string
+
+	mu       sync.Mutex
+	repos    map[string]*repository
+	failures []*Failure
+	requests map[string]int
+	budget   map[string]int
+}
+
+// Failure makes the server answer the next Count requests matching Method and Path with Status,
+// instead of handling them. An empty Method or Path matches any request.
+type Failure struct {
+	Method  string
+	Path    string
+	Status  int
+	Message string
+	Header  http.Header
+	Count   int
+}
+
+// rateLimit is the fake budget of every rate limit resource.
+var rateLimit = map[string]int{"core": 5000, "search": 30, "graphql": 5000}
+
+type repository struct {
+	items       []*item
+	labels      map[string]github.Label
+	milestones  []*milestone
+	releases    []map[string]any
+	assets      map[string][]github.ReleaseAsset
+	assetData   map[int64][]byte
+	lastAssetID int64
+	tags        map[string]string
+	protections map[string]json.RawMessage
+	signatures  map[string]bool
+	rulesets    []github.Ruleset
+	commits     map[string]commit
+	checkRuns   map[string][]checkRun
+	statuses    map[string][]map[string]any
+
+	// latestRelease is the id of the release marked as the latest one, 0 if none.
+	latestRelease int
+}
+
+type checkRun struct {
+	ID         int64  `json:"id"`
+	Name       string `json:"name"`
+	Status     string `json:"status"`
+	Conclusion string `json:"conclusion"`
+	HTMLURL    string `json:"html_url"`
+	App        struct {
+		Slug string `json:"slug"`
+	} `json:"app"`
+
+	logs string
+}
+
+type commit struct {
+	message string
+	parents []string
+}
+
+// item is an issue or a Pull Request, both share the same numbering.
+type item struct {
+	number    int
+	title     string
+	body      string
+	state     string
+	labels    []string
+	author    string
+	assignee  string
+	milestone int
+	comments  []string
+
+	isPR        bool
+	head        string
+	base        string
+	merged      bool
+	mergeCommit string
+
+	reviewDecision string
+	reviewers      []string
+	teamReviewers  []string
+	checks         string
+	autoMerge      string
+
+	// commits are the messages of the commits of a Pull Request, a single commit titled after it by default.
+	commits []string
+}
+
+type milestone struct {
+	Number       int     `json:"number"`
+	Title        string  `json:"title"`
+	State        string  `json:"state"`
+	Description  string  `json:"description"`
+	DueOn        *string `json:"due_on"`
+	URL          string  `json:"html_url"`
+	OpenIssues   int     `json:"open_issues"`
+	ClosedIssues int     `json:"closed_issues"`
+}
+
+// NewServer starts a fake GitHub API, it must be closed with Close.
+func NewServer() *Server {
+	s := &Server{
+		User:     "release-manager",
+		repos:    map[string]*repository{},
+		requests: map[string]int{},
+		budget:   map[string]int{},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /user", s.getUser)
+	mux.HandleFunc("GET /search/issues", s.searchIssues)
+	mux.HandleFunc("POST /graphql", s.graphql)
+	mux.HandleFunc("POST /repos/{owner}/{repo}/issues", s.createIssue)
+	mux.HandleFunc("GET /repos/{owner}/{repo}/issues", s.listIssues)
+	mux.HandleFunc("GET /repos/{owner}/{repo}/issues/{nb}", s.getIssue)
+	mux.HandleFunc("PATCH /repos/{owner}/{repo}/issues/{nb}", s.editIssue)
+	mux.HandleFunc("POST /repos/{owner}/{repo}/issues/{nb}/comments", s.createComment)
+	mux.HandleFunc("POST /repos/{owner}/{repo}/issues/{nb}/labels", s.addLabels)
+	mux.HandleFunc("POST /repos/{owner}/{repo}/pulls", s.createPR)
+	mux.HandleFunc("GET /repos/{owner}/{repo}/pulls", s.listPRs)
+	mux.HandleFunc("GET /repos/{owner}/{repo}/pulls/{nb}", s.getPR)
+	mux.HandleFunc("PUT /repos/{owner}/{repo}/pulls/{nb}/merge", s.mergePR)
+	mux.HandleFunc("GET /repos/{owner}/{repo}/pulls/{nb}/commits", s.listPRCommits)
+	mux.HandleFunc("POST /repos/{owner}/{repo}/pulls/{nb}/requested_reviewers", s.requestReviewers)
+	mux.HandleFunc("DELETE /repos/{owner}/{repo}/issues/{nb}/labels/{name}", s.removeLabel)
+	mux.HandleFunc("GET /repos/{owner}/{repo}/commits/{sha}", s.getCommit)
+	mux.HandleFunc("GET /repos/{owner}/{repo}/commits/{sha}/check-runs", s.listCheckRuns)
+	mux.HandleFunc("GET /repos/{owner}/{repo}/commits/{sha}/status", s.getCombinedStatus)
+	mux.HandleFunc("GET /repos/{owner}/{repo}/actions/jobs/{id}/logs", s.getJobLogs)
+	mux.HandleFunc("GET /raw/logs/{repo}/{id}", s.getRawLogs)
+	mux.HandleFunc("GET /repos/{owner}/{repo}/labels", s.listLabels)
+	mux.HandleFunc("POST /repos/{owner}/{repo}/labels", s.createLabel)
+	mux.HandleFunc("PATCH /repos/{owner}/{repo}/labels/{name}", s.editLabel)
+	mux.HandleFunc("GET /repos/{owner}/{repo}/milestones", s.listMilestones)
+	mux.HandleFunc("POST /repos/{owner}/{repo}/milestones", s.createMilestone)
+	mux.HandleFunc("PATCH /repos/{owner}/{repo}/milestones/{nb}", s.editMilestone)
+	mux.HandleFunc("GET /repos/{owner}/{repo}/git/ref/tags/{tag}", s.getTag)
+	mux.HandleFunc("POST /repos/{owner}/{repo}/releases", s.createRelease)
+	mux.HandleFunc("GET /repos/{owner}/{repo}/releases/latest", s.getLatestRelease)
+	mux.HandleFunc("PATCH /repos/{owner}/{repo}/releases/{id}", s.editRelease)
+	mux.HandleFunc("GET /repos/{owner}/{repo}/releases/{id}/{sub}", s.getReleaseSubresource)
+	mux.HandleFunc("POST /repos/{owner}/{repo}/releases/{id}/assets", s.uploadReleaseAsset)
+	mux.HandleFunc("GET /repos/{owner}/{repo}/releases/assets/{id}", s.downloadReleaseAsset)
+	mux.HandleFunc("DELETE /repos/{owner}/{repo}/releases/assets/{id}", s.deleteReleaseAsset)
+	mux.HandleFunc("GET /repos/{owner}/{repo}/branches/{branch}/protection", s.getProtection)
+	mux.HandleFunc("PUT /repos/{owner}/{repo}/branches/{branch}/protection", s.putProtection)
+	mux.HandleFunc("POST /repos/{owner}/{repo}/branches/{branch}/protection/required_signatures", s.setSignatures(true))
+	mux.HandleFunc("DELETE /repos/{owner}/{repo}/branches/{branch}/protection/required_signatures", s.setSignatures(false))
+	mux.HandleFunc("GET /repos/{owner}/{repo}", s.getRepo)
+	mux.HandleFunc("GET /repos/{owner}/{repo}/rulesets", s.listRulesets)
+	mux.HandleFunc("GET /repos/{owner}/{repo}/rulesets/{id}", s.getRuleset)
+	mux.HandleFunc("PUT /repos/{owner}/{repo}/rulesets/{id}", s.putRuleset)
+
+	s.Server = httptest.NewServer(s.middleware(mux))
+
+	return s
+}
+
+// RetryPolicy is the retry policy of the clients returned by Client, it keeps tests fast.
+var RetryPolicy = github.RetryPolicy{
+	MaxAttempts:      3,
+	BaseDelay:        time.Millisecond,
+	MaxDelay:         10 * time.Millisecond,
+	MaxRateLimitWait: time.Second,
+}
+
+// Client returns a github.Client talking to the fake server.
+func (s *Server) Client() github.Client {
+	return github.NewClient(s.URL, s.Server.Client(), RetryPolicy)
+}
+
+// Fail registers a failure to inject in the next matching requests.
+func (s *Server) Fail(f Failure) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if f.Count == 0 {
+		f.Count = 1
+	}
+
+	s.failures = append(s.failures, &f)
+}
+
+// Requests returns how many requests were received for the method and the path.
+func (s *Server) Requests(method, path string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.requests[method+" "+path]
+}
+
+// middleware counts the requests, sets the rate limit headers and injects the registered failures.
+func (s *Server) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resource := "core"
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/search/"):
+			resource = "search"
+		case r.URL.Path == "/graphql":
+			resource = "graphql"
+		}
+
+		s.mu.Lock()
+		s.requests[r.Method+" "+r.URL.Path]++
+		s.budget[resource]++
+		used := s.budget[resource]
+		f := s.nextFailure(r)
+		s.mu.Unlock()
+
+		h := w.Header()
+		h.Set("X-RateLimit-Limit", strconv.Itoa(rateLimit[resource]))
+		h.Set("X-RateLimit-Remaining", strconv.Itoa(max(rateLimit[resource]-used, 0)))
+		h.Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10))
+		h.Set("X-RateLimit-Resource", resource)
+
+		if f == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		for k, v := range f.Header {
+			h[k] = v
+		}
+
+		writeError(w, f.Status, f.Message)
+	})
+}
+
+func (s *Server) nextFailure(r *http.Request) *Failure {
+	for i, f := range s.failures {
+		if (f.Method != "" && f.Method != r.Method) || (f.Path != "" && f.Path != r.URL.Path) {
+			continue
+		}
+
+		f.Count--
+		if f.Count == 0 {
+			s.failures = slices.Delete(s.failures, i, i+1)
+		}
+
+		return f
+	}
+
+	return nil
+}
+
+// AddIssue adds an issue to the repository and returns its number.
+func (s *Server) AddIssue(repo string, issue github.Issue) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	it := &item{title: issue.Title, body: issue.Body, state: "open", author: s.User, assignee: issue.Assignee}
+	if issue.State != "" {
+		it.state = strings.ToLower(issue.State)
+	}
+
+	for _, l := range issue.Labels {
+		it.labels = append(it.labels, l.Name)
+	}
+
+	return s.repo(repo).add(it)
+}
+
+// AddPR adds an opened Pull Request to the repository and returns its number.
+func (s *Server) AddPR(repo string, pr github.PR) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	it := &item{title: pr.Title, body: pr.Body, state: "open", author: pr.Author.Login, isPR: true, head: pr.Branch, base: pr.Base}
+	if it.author == "" {
+		it.author = s.User
+	}
+
+	for _, l := range pr.Labels {
+		it.labels = append(it.labels, l.Name)
+	}
+
+	return s.repo(repo).add(it)
+}
+
+// MergePR marks the Pull Request as merged with the given merge commit.
+func (s *Server) MergePR(repo string, nb int, mergeCommit string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r := s.repo(repo)
+	it := r.item(nb)
+	it.state, it.merged, it.mergeCommit = "closed", true, mergeCommit
+	r.commits[mergeCommit] = commit{
+		message: fmt.Sprintf("Merge pull request #%d from %s\n\n%s", nb, it.head, it.title),
+		parents: []string{it.base, it.head},
+	}
+}
+
+// SetPRCommits sets the messages of the commits of the Pull Request, oldest first.
+func (s *Server) SetPRCommits(repo string, nb int, messages ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.repo(repo).item(nb).commits = messages
+}
+
+// MergePRWithMethod merges the Pull Request like GitHub does with the given method: merge, squash or rebase.
+// A non-empty title replaces the default title of the squashed commit, as when it is edited before merging.
+func (s *Server) MergePRWithMethod(repo string, nb int, method, title string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r := s.repo(repo)
+	s.merge(r, r.item(nb), method)
+
+	if title != "" && method == "squash" {
+		c := r.commits[r.item(nb).mergeCommit]
+		c.message = title
+		r.commits[r.item(nb).mergeCommit] = c
+	}
+}
+
+// HeadSHA is the SHA of the head commit of the Pull Request with the given number, the fake does not
+// track commits so it is derived from the number.
+func HeadSHA(nb int) string {
+	return fmt.Sprintf("%040x", 0xffff0000+nb)
+}
+
+// AddCheckRun adds a GitHub Actions check run on the commit, conclusion is empty while it is not completed.
+// The logs are served by the job logs endpoint.
+func (s *Server) AddCheckRun(repo, sha, name, conclusion, logs string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r := s.repo(repo)
+
+	run := checkRun{Name: name, Status: "completed", Conclusion: conclusion, logs: logs}
+	if conclusion == "" {
+		run.Status = "in_progress"
+	}
+
+	run.ID = 1
+	for _, runs := range r.checkRuns {
+		run.ID += int64(len(runs))
+	}
+
+	run.HTMLURL = fmt.Sprintf("https://github.com/%s/actions/runs/1/job/%d", repo, run.ID)
+	run.App.Slug = "github-actions"
+
+	r.checkRuns[sha] = append(r.checkRuns[sha], run)
+}
+
+// AddCommitStatus adds a commit status on the commit, state is success, pending, failure or error.
+func (s *Server) AddCommitStatus(repo, sha, context, state string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r := s.repo(repo)
+	r.statuses[sha] = append(r.statuses[sha], map[string]any{"context": context, "state": state, "target_url": "https://ci.example.com/" + context})
+}
+
+// AutoMergeMethod returns the merge method of the auto-merge enabled on the Pull Request, if any.
+func (s *Server) AutoMergeMethod(repo string, nb int) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.repo(repo).item(nb).autoMerge
+}
+
+// SetMilestone sets the milestone of an issue or a Pull Request, the milestone is created if needed.
+func (s *Server) SetMilestone(repo string, nb int, title string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r := s.repo(repo)
+
+	for _, m := range r.milestones {
+		if m.Title == title {
+			r.item(nb).milestone = m.Number
+			return
+		}
+	}
+
+	r.item(nb).milestone = r.addMilestone(repo, title).Number
+}
+
+// SetPRStatus sets the review decision and the combined checks state of a Pull Request.
+// If auto-merge is enabled and the Pull Request is now approved and green, it is merged.
+func (s *Server) SetPRStatus(repo string, nb int, reviewDecision, checks string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r := s.repo(repo)
+	it := r.items[nb-1]
+	it.reviewDecision = reviewDecision
+	it.checks = checks
+
+	if it.autoMerge != "" && it.state == "open" && reviewDecision == "APPROVED" && checks == "SUCCESS" {
+		s.merge(r, it, strings.ToLower(it.autoMerge))
+	}
+}
+
+// AddTag creates a tag pointing to the given commit.
+func (s *Server) AddTag(repo, tag, sha string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.repo(repo).tags[tag] = sha
+}
+
+// SetBranchProtection sets the protection returned for the branch.
+func (s *Server) SetBranchProtection(repo, branch string, protection github.BranchProtection) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b, _ := json.Marshal(protection)
+	s.repo(repo).protections[branch] = b
+}
+
+// BranchProtection returns the last protection set on the branch, as raw JSON.
+func (s *Server) BranchProtection(repo, branch string) json.RawMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.repo(repo).protections[branch]
+}
+
+// RequiredSignatures tells whether signed commits are required on the branch.
+func (s *Server) RequiredSignatures(repo, branch string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.repo(repo).signatures[branch]
+}
+
+// AddRuleset adds a ruleset to the repository, its ID is assigned by the server.
+func (s *Server) AddRuleset(repo string, rs github.Ruleset) int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r := s.repo(repo)
+	rs.ID = int64(len(r.rulesets) + 1)
+	r.rulesets = append(r.rulesets, rs)
+
+	return rs.ID
+}
+
+// Rulesets returns the rulesets of the repository.
+func (s *Server) Rulesets(repo string) []github.Ruleset {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return slices.Clone(s.repo(repo).rulesets)
+}
+
+// Labels returns the labels of the repository.
+func (s *Server) Labels(repo string) []github.Label {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var labels []github.Label
+	for _, l := range s.repo(repo).labels {
+		labels = append(labels, l)
+	}
+
+	slices.SortFunc(labels, func(a, b github.Label) int { return strings.Compare(a.Name, b.Name) })
+
+	return labels
+}
+
+// Comments returns the comments posted on an issue or a Pull Request.
+func (s *Server) Comments(repo string, nb int) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return slices.Clone(s.repo(repo).item(nb).comments)
+}
+
+// RequestedReviewers returns the users and the teams whose review was requested on a Pull Request.
+func (s *Server) RequestedReviewers(repo string, nb int) (users, teams []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	it := s.repo(repo).item(nb)
+
+	return slices.Clone(it.reviewers), slices.Clone(it.teamReviewers)
+}
+
+// Releases returns the releases created on the repository, as decoded JSON objects.
+func (s *Server) Releases(repo string) []map[string]any {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return slices.Clone(s.repo(repo).releases)
+}
+
+// ReleaseAssets returns the assets attached to the release of the tag.
+func (s *Server) ReleaseAssets(repo, tag string) []github.ReleaseAsset {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r := s.repo(repo)
+
+	for _, rel := range r.releases {
+		if rel["tag_name"] == tag {
+			return slices.Clone(r.assets[strconv.Itoa(rel["id"].(int))])
+		}
+	}
+
+	return nil
+}
+
+// ClearAssetDigests removes the digest of the assets of the release of the tag, like the
+// assets uploaded before GitHub started computing them.
+func (s *Server) ClearAssetDigests(repo, tag string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r := s.repo(repo)
+
+	for _, rel := range r.releases {
+		if rel["tag_name"] == tag {
+			for i := range r.assets[strconv.Itoa(rel["id"].(int))] {
+				r.assets[strconv.Itoa(rel["id"].(int))][i].Digest = ""
+			}
+		}
+	}
+}
+
+// LatestRelease returns the tag of the release marked as the latest one, or an empty string.
+func (s *Server) LatestRelease(repo string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r := s.repo(repo)
+	if rel := r.release(strconv.Itoa(r.latestRelease)); rel != nil {
+		return rel["tag_name"].(string)
+	}
+
+	return ""
+}
+
+func (s *Server) repo(name string) *repository {
+	r, ok := s.repos[name]
+	if !ok {
+		r = &repository{
+			labels:      map[string]github.Label{},
+			tags:        map[string]string{},
+			protections: map[string]json.RawMessage{},
+			signatures:  map[string]bool{},
+			commits:     map[string]commit{},
+			checkRuns:   map[string][]checkRun{},
+			statuses:    map[string][]map[string]any{},
+		}
+		s.repos[name] = r
+	}
+
+	return r
+}
+
+func (r *repository) add(it *item) int {
+	it.number = len(r.items) + 1
+	r.items = append(r.items, it)
+
+	return it.number
+}
+
+func (r *repository) item(nb int) *item {
+	if nb < 1 || nb > len(r.items) {
+		return nil
+	}
+
+	return r.items[nb-1]
+}
+
+// renderMilestone returns the milestone with the number of open and closed items carrying it.
+func (r *repository) renderMilestone(m *milestone) milestone {
+	out := *m
+
+	for _, it := range r.items {
+		switch {
+		case it.milestone != m.Number:
+		case it.state == "open":
+			out.OpenIssues++
+		default:
+			out.ClosedIssues++
+		}
+	}
+
+	return out
+}
+
+func (r *repository) addMilestone(repo, title string) *milestone {
+	m := &milestone{Number: len(r.milestones) + 1, Title: title, State: "open"}
+	m.URL = fmt.Sprintf("https://github.com/%s/milestone/%d", repo, m.Number)
+	r.milestones = append(r.milestones, m)
+
+	return m
+}
@@ -0,0 +1,255 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// graphQLError is one of the errors returned in the body of a GraphQL response.
+type graphQLError struct {
+	Type    string `json:"type"`
+	Message string `json:"message"`
+}
+
+// graphql runs a query against the GraphQL API and decodes its data into out.
+// Queries do not modify anything, so they are retried like the idempotent REST calls.
+func (c *restClient) graphql(query string, variables map[string]any, out any) error {
+	return c.graphqlRequest(query, variables, out, true)
+}
+
+// graphqlMutation runs a mutation against the GraphQL API and decodes its data into out. Unlike queries,
+// mutations are not retried on transient failures, as they may have been applied even if the request failed.
+func (c *restClient) graphqlMutation(mutation string, variables map[string]any, out any) error {
+	return c.graphqlRequest(mutation, variables, out, false)
+}
+
+func (c *restClient) graphqlRequest(query string, variables map[string]any, out any, idempotent bool) error {
+	req := map[string]any{"query": query, "variables": variables}
+
+	var resp struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []graphQLError  `json:"errors"`
+	}
+
+	if _, err := c.send(http.MethodPost, c.graphqlURL(), req, &resp, idempotent); err != nil {
+		return err
+	}
+
+	if len(resp.Errors) > 0 {
+		msgs := make([]string, 0, len(resp.Errors))
+		for _, e := range resp.Errors {
+			msgs = append(msgs, e.Message)
+		}
+
+		err := errors.New("GraphQL: " + strings.Join(msgs, ", "))
+		if resp.Errors[0].Type == "NOT_FOUND" {
+			err = errors.Join(ErrNotFound, err)
+		}
+
+		return err
+	}
+
+	return json.Unmarshal(resp.Data, out)
+}
+
+// graphqlURL returns the GraphQL endpoint, which lives next to the REST API.
+func (c *restClient) graphqlURL() string {
+	if base, ok := strings.CutSuffix(c.baseURL, "/api/v3/"); ok {
+		return base + "/api/graphql"
+	}
+
+	return c.baseURL + "graphql"
+}
+
+const milestonePRsQuery = `
+query($owner: String!, $name: String!, $number: Int!, $states: [PullRequestState!], $first: Int!, $after: String) {
+  repository(owner: $owner, name: $name) {
+    milestone(number: $number) {
+      pullRequests(first: $first, after: $after, states: $states, orderBy: {field: CREATED_AT, direction: DESC}) {
+        pageInfo { hasNextPage endCursor }
+        nodes {
+          number title body url state baseRefName headRefName
+          mergeCommit { oid }
+          author { __typename login }
+          labels(first: 100) { nodes { name color description } }
+        }
+      }
+    }
+  }
+}`
+
+// graphQLPR is a Pull Request as returned by milestonePRsQuery.
+type graphQLPR struct {
+	Number      int    `json:"number"`
+	Title       string `json:"title"`
+	Body        string `json:"body"`
+	URL         string `json:"url"`
+	State       string `json:"state"`
+	BaseRefName string `json:"baseRefName"`
+	HeadRefName string `json:"headRefName"`
+	MergeCommit *struct {
+		OID string `json:"oid"`
+	} `json:"mergeCommit"`
+	Author *struct {
+		Typename string `json:"__typename"`
+		Login    string `json:"login"`
+	} `json:"author"`
+	Labels struct {
+		Nodes []Label `json:"nodes"`
+	} `json:"labels"`
+}
+
+func (gp graphQLPR) toPR() PR {
+	pr := PR{
+		Title:  gp.Title,
+		Body:   gp.Body,
+		Branch: gp.HeadRefName,
+		Base:   gp.BaseRefName,
+		URL:    gp.URL,
+		Labels: gp.Labels.Nodes,
+		Author: Author{Login: "ghost"},
+		Number: gp.Number,
+		State:  gp.State,
+	}
+
+	if gp.Author != nil {
+		pr.Author = restUser{Login: gp.Author.Login, Type: gp.Author.Typename}.toAuthor()
+	}
+
+	if gp.MergeCommit != nil {
+		pr.MergeCommitSHA = gp.MergeCommit.OID
+	}
+
+	return pr
+}
+
+// ListMilestonePRs returns all the Pull Requests of the milestone, walking the GraphQL connection,
+// which unlike the search API is not capped to 1000 results. states are OPEN, CLOSED or MERGED,
+// no states means all of them.
+func (c *restClient) ListMilestonePRs(repo string, milestone int, states ...string) ([]PR, error) {
+	owner, name, _ := strings.Cut(repo, "/")
+
+	variables := map[string]any{
+		"owner":  owner,
+		"name":   name,
+		"number": milestone,
+		"first":  maxPerPage,
+	}
+
+	if len(states) > 0 {
+		variables["states"] = states
+	}
+
+	var prs []PR
+
+	for {
+		var data struct {
+			Repository struct {
+				Milestone *struct {
+					PullRequests struct {
+						PageInfo struct {
+							HasNextPage bool   `json:"hasNextPage"`
+							EndCursor   string `json:"endCursor"`
+						} `json:"pageInfo"`
+						Nodes []graphQLPR `json:"nodes"`
+					} `json:"pullRequests"`
+				} `json:"milestone"`
+			} `json:"repository"`
+		}
+
+		if err := c.graphql(milestonePRsQuery, variables, &data); err != nil {
+			return prs, err
+		}
+
+		ms := data.Repository.Milestone
+		if ms == nil {
+			return prs, ErrNotFound
+		}
+
+		for _, gp := range ms.PullRequests.Nodes {
+			prs = append(prs, gp.toPR())
+		}
+
+		if !ms.PullRequests.PageInfo.HasNextPage {
+			return prs, nil
+		}
+
+		variables["after"] = ms.PullRequests.PageInfo.EndCursor
+	}
+}
+
+const prStatusQuery = `
+query($owner: String!, $name: String!, $number: Int!) {
+  repository(owner: $owner, name: $name) {
+    pullRequest(number: $number) {
+      state reviewDecision mergeStateStatus
+      autoMergeRequest { mergeMethod }
+      commits(last: 1) { nodes { commit { statusCheckRollup { state } } } }
+    }
+  }
+}`
+
+func (c *restClient) GetPRStatus(repo string, nb int) (PRStatus, error) {
+	owner, name, _ := strings.Cut(repo, "/")
+
+	var data struct {
+		Repository struct {
+			PullRequest *struct {
+				State            string `json:"state"`
+				ReviewDecision   string `json:"reviewDecision"`
+				MergeStateStatus string `json:"mergeStateStatus"`
+				AutoMergeRequest *struct {
+					MergeMethod MergeMethod `json:"mergeMethod"`
+				} `json:"autoMergeRequest"`
+				Commits struct {
+					Nodes []struct {
+						Commit struct {
+							StatusCheckRollup *struct {
+								State string `json:"state"`
+							} `json:"statusCheckRollup"`
+						} `json:"commit"`
+					} `json:"nodes"`
+				} `json:"commits"`
+			} `json:"pullRequest"`
+		} `json:"repository"`
+	}
+
+	err := c.graphql(prStatusQuery, map[string]any{"owner": owner, "name": name, "number": nb}, &data)
+	if err != nil {
+		return PRStatus{}, err
+	}
+
+	pr := data.Repository.PullRequest
+	if pr == nil {
+		return PRStatus{}, ErrNotFound
+	}
+
+	status := PRStatus{State: pr.State, ReviewDecision: pr.ReviewDecision, MergeStateStatus: pr.MergeStateStatus}
+	if commits := pr.Commits.Nodes; len(commits) > 0 && commits[0].Commit.StatusCheckRollup != nil {
+		status.Checks = commits[0].Commit.StatusCheckRollup.State
+	}
+
+	if pr.AutoMergeRequest != nil {
+		status.AutoMergeMethod = pr.AutoMergeRequest.MergeMethod
+	}
+
+	return status, nil
+}
@@ -19,35 +19,14 @@ package github
 import (
 	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
 
-	gh "github.com/cli/go-gh/v2"
-
-	"github.com/vitessio/vitess-releaser/go/releaser/git"
 	"github.com/vitessio/vitess-releaser/go/releaser/utils"
 )
 
-func execGh(args ...string) string {
-	stdOut, stdErr, err := gh.Exec(args...)
-	if err != nil {
-		cmd := append([]string{"gh"}, strings.Join(args, " "))
-		utils.BailOut(err, "failed to execute: %s, got: %s", strings.Join(cmd, " "), stdOut.String()+stdErr.String())
-	}
-
-	return stdOut.String()
-}
-
-func execGhWithError(args ...string) (string, error) {
-	stdOut, stdErr, err := gh.Exec(args...)
-	if err != nil {
-		cmd := append([]string{"gh"}, strings.Join(args, " "))
-		return stdOut.String(), fmt.Errorf("%w: failed to execute: %s, got: %s", err, strings.Join(cmd, " "), stdOut.String()+stdErr.String())
-	}
-
-	return stdOut.String(), nil
-}
-
 type Issue struct {
 	Title    string  `json:"title"`
 	Body     string  `json:"body"`
@@ -55,83 +34,55 @@ type Issue struct {
 	Labels   []Label `json:"labels"`
 	Assignee string  `json:"assignee"`
 	Number   int     `json:"number"`
+	State    string  `json:"state"`
 }
 
 func CloseReleaseIssue(repo string, nb int) {
-	execGh(
-		"issue", "close",
-		"--repo", repo,
-		strconv.Itoa(nb),
-		"--reason", "completed",
-		"--comment", "Release completed.",
-	)
+	err := getClient().CloseIssue(repo, nb, "Release completed.")
+	if err != nil {
+		utils.BailOut(err, "failed to close the issue %d", nb)
+	}
 }
 
 // Create will open the issue on GitHub and return the link of the newly created issue.
+// The "@me" assignee stands for the current user.
 func (i *Issue) Create(repo string) string {
-	var labels []string
-	for _, label := range i.Labels {
-		labels = append(labels, label.Name)
+	issue := *i
+	if issue.Assignee == "@me" {
+		issue.Assignee = CurrentUser()
 	}
 
-	stdOut := execGh(
-		"issue", "create",
-		"--repo", repo,
-		"--title", i.Title,
-		"--body", i.Body,
-		"--label", strings.Join(labels, ","),
-		"--assignee", i.Assignee,
-	)
+	created, err := getClient().CreateIssue(repo, issue)
+	if err != nil {
+		utils.BailOut(err, "failed to create the issue '%s'", i.Title)
+	}
 
-	return strings.ReplaceAll(stdOut, "\n", "")
+	return created.URL
 }
 
 func (i *Issue) UpdateBody(repo string) string {
-	stdOut := execGh(
-		"issue", "edit",
-		"--repo", repo,
-		strconv.Itoa(i.Number), "-b", i.Body,
-	)
+	updated, err := getClient().UpdateIssueBody(repo, i.Number, i.Body)
+	if err != nil {
+		utils.BailOut(err, "failed to update the body of the issue %d", i.Number)
+	}
 
-	return strings.ReplaceAll(stdOut, "\n", "")
+	return updated.URL
 }
 
 func GetIssueTitleAndBody(repo string, nb int) (string, string) {
-	stdOut := execGh(
-		"issue", "view",
-		strconv.Itoa(nb),
-		"--repo", repo,
-		"--json",
-		"title,body",
-	)
-
-	var i Issue
-
-	err := json.Unmarshal([]byte(stdOut), &i)
+	i, err := getClient().GetIssue(repo, nb)
 	if err != nil {
-		utils.BailOut(err, "failed to parse the issue number %d, got: %s", nb, stdOut)
+		utils.BailOut(err, "failed to get the issue number %d", nb)
 	}
 
 	return i.Title, i.Body
 }
 
 func GetReleaseIssue(repo, release string, rcIncrement int) (string, string) {
-	stdOut := execGh(
-		"issue", "list",
-		"-l", "Type: Release",
-		"--json", "title,url",
-		"--repo", repo,
-	)
-
-	var issues []map[string]string
-
-	err := json.Unmarshal([]byte(stdOut), &issues)
-	if err != nil {
-		utils.BailOut(err, "failed to parse the release issue, got: %s", stdOut)
-	}
+	issues := listIssues(repo, ListOptions{State: "open", Labels: []string{"Type: Release"}, Limit: NoLimit})
 
 	for _, issue := range issues {
-		title := issue["title"]
+		title := issue.Title
 		prefix := "Release of `v"
 
 		if strings.HasPrefix(title, fmt.Sprintf("%s%s", prefix, release)) {
@@ -140,13 +91,47 @@ func GetReleaseIssue(repo, release string, rcIncrement int) (string, string) {
 				continue
 			}
 
-			return issue["url"], strings.ReplaceAll(title[len(prefix):], "`", "")
+			return issue.URL, strings.ReplaceAll(title[len(prefix):], "`", "")
 		}
 	}
 
 	return "", ""
 }
 
+// ReleaseIssue is a release issue found on GitHub, Release is parsed from the
+// title of the issue, i.e. "21.0.0-RC1".
+type ReleaseIssue struct {
+	Release string
+	URL     string
+	Number  int
+	Closed  bool
+}
+
+// GetReleaseIssuesForMajor returns all the release issues, opened or closed,
+// that were created for the given major release.
+func GetReleaseIssuesForMajor(repo, majorRelease string) []ReleaseIssue {
+	issues := listIssues(repo, ListOptions{State: "all", Labels: []string{"Type: Release"}, Limit: 200})
+
+	prefix := fmt.Sprintf("Release of `v%s.", majorRelease)
+
+	var ris []ReleaseIssue
+
+	for _, issue := range issues {
+		if !strings.HasPrefix(issue.Title, prefix) {
+			continue
+		}
+
+		ris = append(ris, ReleaseIssue{
+			Release: strings.ReplaceAll(issue.Title[len("Release of `v"):], "`", ""),
+			URL:     issue.URL,
+			Number:  issue.Number,
+			Closed:  issue.State == "closed",
+		})
+	}
+
+	return ris
+}
+
 func GetReleaseIssueInfo(repo, majorRelease string, rcIncrement int) (nb int, url, release string) {
 	url, release = GetReleaseIssue(repo, majorRelease, rcIncrement)
 	if url == "" {
@@ -179,57 +164,243 @@ func FormatIssues(issues []Issue) []string {
 	return prFmt
 }
 
-func CheckReleaseBlockerIssues(repo, majorRelease string) map[string]any {
-	git.CorrectCleanRepo(repo)
+func LoadKnownIssues(repo, majorRelease string) []Issue {
+	label := fmt.Sprintf("Known issue: %s", majorRelease)
 
-	stdOut := execGh("issue", "list", "--json", "title,url,labels", "--repo", repo)
+	return listIssues(repo, ListOptions{State: "open", Labels: []string{label}, Limit: NoLimit})
+}
 
-	var issues []Issue
+// GetOpenedIssuesByMilestone returns the open issues of the milestone, Pull Requests excluded.
+func GetOpenedIssuesByMilestone(repo, milestone string) []Issue {
+	ms := GetMilestonesByName(repo, milestone)
+	if len(ms) == 0 {
+		return nil
+	}
+
+	return listIssues(repo, ListOptions{State: "open", Milestone: ms[0].Number, Limit: NoLimit})
+}
+
+// AssignMilestoneToIssues sets the milestone on the issues, a few of them at a time. The failures are
+// returned as a *BulkError once all the issues were processed.
+func AssignMilestoneToIssues(repo, milestone string, issues []Issue, progress BulkProgress) error {
+	ms := GetMilestone(repo, milestone)
+
+	numbers := make([]int, 0, len(issues))
+	for _, issue := range issues {
+		numbers = append(numbers, issue.Number)
+	}
+
+	return runBulk(numbers, progress, func(nb int) error {
+		return getClient().SetMilestone(repo, nb, ms.Number)
+	})
+}
+
+func listIssues(repo string, opts ListOptions) []Issue {
+	issues, err := getClient().ListIssues(repo, opts)
+	if err != nil {
+		utils.BailOut(err, "failed to list the issues of %s", repo)
+	}
+
+	return issues
+}
 
-	err := json.Unmarshal([]byte(stdOut), &issues)
+func searchIssues(repo, query string, limit int) []Issue {
+	issues, err := getClient().SearchIssues(repo, query, limit)
 	if err != nil {
-		utils.BailOut(err, "failed to parse the release blocker issue, got: %s", stdOut)
+		utils.BailOut(err, "failed to search the issues of %s matching '%s'", repo, query)
+	}
+
+	return issues
+}
+
+// restIssue is an issue, or a Pull Request, as returned by the issues and search endpoints.
+type restIssue struct {
+	Number      int       `json:"number"`
+	Title       string    `json:"title"`
+	Body        string    `json:"body"`
+	State       string    `json:"state"`
+	HTMLURL     string    `json:"html_url"`
+	Labels      []Label   `json:"labels"`
+	User        restUser  `json:"user"`
+	Assignee    *restUser `json:"assignee"`
+	PullRequest *struct{} `json:"pull_request"`
+}
+
+func (ri restIssue) toIssue() Issue {
+	i := Issue{
+		Title:  ri.Title,
+		Body:   ri.Body,
+		URL:    ri.HTMLURL,
+		Labels: ri.Labels,
+		Number: ri.Number,
+		State:  ri.State,
+	}
+
+	if ri.Assignee != nil {
+		i.Assignee = ri.Assignee.Login
+	}
+
+	return i
+}
+
+func (c *restClient) CreateIssue(repo string, issue Issue) (Issue, error) {
+	req := map[string]any{
+		"title":  issue.Title,
+		"body":   issue.Body,
+		"labels": labelNames(issue.Labels),
+	}
+
+	if issue.Assignee != "" {
+		req["assignees"] = []string{issue.Assignee}
+	}
+
+	var ri restIssue
+
+	_, err := c.do(http.MethodPost, repoPath(repo, "issues"), req, &ri)
+
+	return ri.toIssue(), err
+}
+
+func (c *restClient) GetIssue(repo string, nb int) (Issue, error) {
+	var ri restIssue
+
+	_, err := c.do(http.MethodGet, repoPath(repo, "issues", strconv.Itoa(nb)), nil, &ri)
+
+	return ri.toIssue(), err
+}
+
+func (c *restClient) UpdateIssueBody(repo string, nb int, body string) (Issue, error) {
+	var ri restIssue
+
+	_, err := c.do(http.MethodPatch, repoPath(repo, "issues", strconv.Itoa(nb)), map[string]any{"body": body}, &ri)
+
+	return ri.toIssue(), err
+}
+
+// CreateComment comments on an issue or a Pull Request.
+func (c *restClient) CreateComment(repo string, nb int, body string) error {
+	_, err := c.do(http.MethodPost, repoPath(repo, "issues", strconv.Itoa(nb), "comments"), map[string]any{"body": body}, nil)
+
+	return err
+}
+
+// CloseIssue comments on the issue, if comment is not empty, and closes it as completed.
+func (c *restClient) CloseIssue(repo string, nb int, comment string) error {
+	if comment != "" {
+		err := c.CreateComment(repo, nb, comment)
+		if err != nil {
+			return err
+		}
+	}
+
+	req := map[string]any{"state": "closed", "state_reason": "completed"}
+	_, err := c.do(http.MethodPatch, repoPath(repo, "issues", strconv.Itoa(nb)), req, nil)
+
+	return err
+}
+
+// ListIssues lists the issues of the repository, Pull Requests are excluded.
+func (c *restClient) ListIssues(repo string, opts ListOptions) ([]Issue, error) {
+	params := url.Values{}
+	params.Set("state", listState(opts.State))
+
+	if len(opts.Labels) > 0 {
+		params.Set("labels", strings.Join(opts.Labels, ","))
+	}
+
+	if opts.Milestone != 0 {
+		params.Set("milestone", strconv.Itoa(opts.Milestone))
+	}
+
+	limit := opts.Limit
+	if limit == 0 {
+		limit = defaultListLimit
 	}
 
-	var mustClose []Issue
+	var issues []Issue
 
-	branchName := fmt.Sprintf("release-%s.0", majorRelease)
+	err := c.paginate(repoPath(repo, "issues"), params, limit, func(page []byte) (int, error) {
+		var ris []restIssue
+		if err := json.Unmarshal(page, &ris); err != nil {
+			return 0, err
+		}
 
-	for _, i := range issues {
-		for _, l := range i.Labels {
-			if strings.HasPrefix(l.Name, "Release Blocker: ") && strings.Contains(l.Name, branchName) {
-				mustClose = append(mustClose, i)
+		for _, ri := range ris {
+			if ri.PullRequest == nil {
+				issues = append(issues, ri.toIssue())
 			}
 		}
+
+		return len(ris), nil
+	})
+
+	if limit > 0 && len(issues) > limit {
+		issues = issues[:limit]
 	}
 
-	m := make(map[string]any, len(mustClose))
+	return issues, err
+}
+
+// SearchIssues returns the issues of the repository matching the search query, i.e. "is:open label:bug".
+func (c *restClient) SearchIssues(repo, query string, limit int) ([]Issue, error) {
+	ris, err := c.search(fmt.Sprintf("repo:%s is:issue %s", repo, query), limit)
 
-	for _, pr := range mustClose {
-		nb := pr.URL[strings.LastIndex(pr.URL, "/")+1:]
-		markdownURL := fmt.Sprintf("#%s", nb)
-		m[markdownURL] = nil
+	issues := make([]Issue, 0, len(ris))
+	for _, ri := range ris {
+		issues = append(issues, ri.toIssue())
 	}
 
-	return m
+	return issues, err
 }
 
-func LoadKnownIssues(repo, majorRelease string) []Issue {
-	label := fmt.Sprintf("Known issue: %s", majorRelease)
+// search returns the issues and Pull Requests matching the query.
+func (c *restClient) search(query string, limit int) ([]restIssue, error) {
+	params := url.Values{}
+	params.Set("q", query)
 
-	stdOut := execGh(
-		"issue", "list",
-		"--repo", repo,
-		"--label", label,
-		"--json", "title,number",
-	)
+	var ris []restIssue
 
-	var knownIssues []Issue
+	err := c.paginate("search/issues", params, limit, func(page []byte) (int, error) {
+		var result struct {
+			Items []restIssue `json:"items"`
+		}
 
-	err := json.Unmarshal([]byte(stdOut), &knownIssues)
-	if err != nil {
-		utils.BailOut(err, "failed to parse known issues, got: %s", stdOut)
+		if err := json.Unmarshal(page, &result); err != nil {
+			return 0, err
+		}
+
+		ris = append(ris, result.Items...)
+
+		return len(result.Items), nil
+	})
+
+	if limit > 0 && len(ris) > limit {
+		ris = ris[:limit]
+	}
+
+	return ris, err
+}
+
+// SetMilestone sets the milestone of an issue or a Pull Request.
+func (c *restClient) SetMilestone(repo string, nb, milestone int) error {
+	_, err := c.do(http.MethodPatch, repoPath(repo, "issues", strconv.Itoa(nb)), map[string]any{"milestone": milestone}, nil)
+
+	return err
+}
+
+func listState(state string) string {
+	if state == "" {
+		return "open"
+	}
+
+	return strings.ToLower(state)
+}
+
+func labelNames(labels []Label) []string {
+	names := make([]string, 0, len(labels))
+	for _, l := range labels {
+		names = append(names, l.Name)
 	}
 
-	return knownIssues
+	return names
 }
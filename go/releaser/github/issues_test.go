@@ -0,0 +1,76 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/vitessio/vitess-releaser/go/releaser/github"
+)
+
+func TestListIssuesPagination(t *testing.T) {
+	s := newServer(t)
+	c := s.Client()
+
+	for i := range 250 {
+		s.AddIssue(testRepo, github.Issue{Title: fmt.Sprintf("issue %d", i)})
+	}
+
+	path := fmt.Sprintf("/repos/%s/issues", testRepo)
+
+	tcs := []struct {
+		name     string
+		limit    int
+		want     int
+		requests int
+	}{
+		{name: "default limit", limit: 0, want: 30, requests: 1},
+		{name: "limit on one page", limit: 50, want: 50, requests: 1},
+		{name: "limit across pages", limit: 150, want: 150, requests: 2},
+		{name: "no limit", limit: github.NoLimit, want: 250, requests: 3},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			before := s.Requests(http.MethodGet, path)
+
+			issues, err := c.ListIssues(testRepo, github.ListOptions{State: "all", Limit: tc.limit})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(issues) != tc.want {
+				t.Fatalf("expected %d issues, got %d", tc.want, len(issues))
+			}
+
+			seen := map[int]bool{}
+			for _, issue := range issues {
+				if seen[issue.Number] {
+					t.Fatalf("issue %d listed twice", issue.Number)
+				}
+
+				seen[issue.Number] = true
+			}
+
+			if n := s.Requests(http.MethodGet, path) - before; n != tc.requests {
+				t.Fatalf("expected %d requests, got %d", tc.requests, n)
+			}
+		})
+	}
+}
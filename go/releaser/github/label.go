@@ -16,14 +16,79 @@ limitations under the License.
 
 package github
 
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/vitessio/vitess-releaser/go/releaser/utils"
+)
+
 func CreateLabel(repo, label, color, desc string) {
-	_ = execGh(
-		"label",
-		"create",
-		label,
-		"--repo", repo,
-		"--color", color,
-		"--description", desc,
-		"--force",
-	)
+	err := getClient().CreateOrUpdateLabel(repo, Label{Name: label, Color: color, Description: desc})
+	if err != nil {
+		utils.BailOut(err, "failed to create the label %s", label)
+	}
+}
+
+// RemoveLabel removes the label from the issue or Pull Request, if it has it.
+func RemoveLabel(repo string, nb int, label string) {
+	err := getClient().RemoveLabel(repo, nb, label)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		utils.BailOut(err, "failed to remove the label %s from #%d", label, nb)
+	}
+}
+
+// ListLabels returns all the labels of the repository.
+func ListLabels(repo string) []Label {
+	labels, err := getClient().ListLabels(repo)
+	if err != nil {
+		utils.BailOut(err, "failed to list the labels of %s", repo)
+	}
+
+	return labels
+}
+
+// RenameLabel renames the label, the issues and Pull Requests carrying it keep it under its new name.
+func RenameLabel(repo, name, newName string) {
+	err := getClient().RenameLabel(repo, name, newName)
+	if err != nil {
+		utils.BailOut(err, "failed to rename the label %s to %s", name, newName)
+	}
+}
+
+// ListOpenIssuesAndPRs returns the open issues and Pull Requests of the repository.
+func ListOpenIssuesAndPRs(repo string) ([]Issue, []PR) {
+	return listIssues(repo, ListOptions{State: "open", Limit: NoLimit}), listPRs(repo, ListOptions{State: "open", Limit: NoLimit})
+}
+
+// CreateOrUpdateLabel creates the label, or updates its color and description if it already exists.
+func (c *restClient) CreateOrUpdateLabel(repo string, label Label) error {
+	_, err := c.do(http.MethodPost, repoPath(repo, "labels"), label, nil)
+	if !errors.Is(err, ErrAlreadyExists) {
+		return err
+	}
+
+	_, err = c.do(http.MethodPatch, repoPath(repo, "labels", url.PathEscape(label.Name)), label, nil)
+
+	return err
+}
+
+func (c *restClient) RemoveLabel(repo string, nb int, label string) error {
+	_, err := c.do(http.MethodDelete, repoPath(repo, "issues", strconv.Itoa(nb), "labels", url.PathEscape(label)), nil, nil)
+
+	return err
+}
+
+func (c *restClient) ListLabels(repo string) ([]Label, error) {
+	return listAll[Label](c, repoPath(repo, "labels"), url.Values{}, NoLimit)
+}
+
+func (c *restClient) RenameLabel(repo, name, newName string) error {
+	body := map[string]string{"new_name": newName}
+
+	_, err := c.do(http.MethodPatch, repoPath(repo, "labels", url.PathEscape(name)), body, nil)
+
+	return err
 }
@@ -0,0 +1,209 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/vitessio/vitess-releaser/go/releaser/utils"
+)
+
+// MergeMethod is how a Pull Request is merged, using the values of the GraphQL API.
+type MergeMethod string
+
+const (
+	MergeMethodMerge  MergeMethod = "MERGE"
+	MergeMethodSquash MergeMethod = "SQUASH"
+	MergeMethodRebase MergeMethod = "REBASE"
+)
+
+// Commit is a commit of a repository, as returned by the commits endpoint.
+type Commit struct {
+	SHA     string
+	Message string
+	Parents []string
+}
+
+// AutoMergePR enables auto-merge on the Pull Request, so GitHub merges it with the given method once
+// it is approved and its checks pass. If it can already be merged, it is merged right away. Nothing is
+// done if the Pull Request is already merged, or if auto-merge is already enabled with the same method.
+func AutoMergePR(repo string, nb int, method MergeMethod) {
+	status, err := getClient().GetPRStatus(repo, nb)
+	if err != nil {
+		utils.BailOut(err, "failed to get the status of the Pull Request %d", nb)
+	}
+
+	if autoMerged(status, method) {
+		return
+	}
+
+	// GitHub refuses to enable auto-merge on Pull Requests that can be merged right now
+	if status.MergeStateStatus == "CLEAN" {
+		err = getClient().MergePR(repo, nb, method)
+	} else {
+		err = getClient().EnableAutoMerge(repo, nb, method)
+	}
+
+	if err == nil {
+		return
+	}
+
+	// the Pull Request may have been merged, or auto-merge enabled, since we read its status
+	if status, statusErr := getClient().GetPRStatus(repo, nb); statusErr == nil && autoMerged(status, method) {
+		return
+	}
+
+	utils.BailOut(err, "failed to enable auto-merge on the Pull Request %d, is auto-merge allowed in the settings of %s?", nb, repo)
+}
+
+func autoMerged(status PRStatus, method MergeMethod) bool {
+	return status.State == "MERGED" || status.AutoMergeMethod == method
+}
+
+// VerifyMergeMethod checks that the merged Pull Request was merged with the given method, by looking
+// at its merge commit: a merge commit has two parents, a squashed or rebased commit has one.
+func VerifyMergeMethod(repo string, nb int, method MergeMethod) error {
+	pr := getPR(repo, nb)
+	if pr.State != "MERGED" {
+		return fmt.Errorf("the Pull Request %d is not merged", nb)
+	}
+
+	commit, err := getClient().GetCommit(repo, pr.MergeCommitSHA)
+	if err != nil {
+		return err
+	}
+
+	used := MergeMethodMerge
+	if len(commit.Parents) == 1 {
+		used, err = squashOrRebase(repo, nb, commit)
+		if err != nil {
+			return err
+		}
+	}
+
+	if used != method {
+		return fmt.Errorf("the Pull Request %d was merged with the %s method instead of %s, its merge commit %s cannot be used for the release",
+			nb, strings.ToLower(string(used)), strings.ToLower(string(method)), pr.MergeCommitSHA)
+	}
+
+	return nil
+}
+
+// squashOrRebase tells a squash merge from a rebase merge, as the title of a squashed commit can be edited
+// when merging. A rebase replays the commits of the Pull Request on the base branch: the merge commit and its
+// parent are then copies of the last two commits of the Pull Request. A squash creates a single new commit,
+// on top of the base branch. With a single commit in the Pull Request, both methods give the same history.
+func squashOrRebase(repo string, nb int, merge Commit) (MergeMethod, error) {
+	headCommits, err := getClient().ListPRCommits(repo, nb)
+	if err != nil {
+		return "", err
+	}
+
+	n := len(headCommits)
+	if n < 2 || !sameMessage(merge, headCommits[n-1]) {
+		return MergeMethodSquash, nil
+	}
+
+	parent, err := getClient().GetCommit(repo, merge.Parents[0])
+	if err != nil {
+		return "", err
+	}
+
+	if sameMessage(parent, headCommits[n-2]) {
+		return MergeMethodRebase, nil
+	}
+
+	return MergeMethodSquash, nil
+}
+
+func sameMessage(a, b Commit) bool {
+	return strings.TrimSpace(a.Message) == strings.TrimSpace(b.Message)
+}
+
+const enableAutoMergeMutation = `
+mutation($id: ID!, $method: PullRequestMergeMethod!) {
+  enablePullRequestAutoMerge(input: {pullRequestId: $id, mergeMethod: $method}) { clientMutationId }
+}`
+
+func (c *restClient) EnableAutoMerge(repo string, nb int, method MergeMethod) error {
+	var rp restPR
+
+	if _, err := c.do(http.MethodGet, repoPath(repo, "pulls", strconv.Itoa(nb)), nil, &rp); err != nil {
+		return err
+	}
+
+	var data json.RawMessage
+
+	return c.graphqlMutation(enableAutoMergeMutation, map[string]any{"id": rp.NodeID, "method": method}, &data)
+}
+
+// MergePR merges the Pull Request right away. It is not retried: the merge may have
+// happened even if the request failed.
+func (c *restClient) MergePR(repo string, nb int, method MergeMethod) error {
+	req := map[string]any{"merge_method": strings.ToLower(string(method))}
+
+	_, err := c.send(http.MethodPut, repoPath(repo, "pulls", strconv.Itoa(nb), "merge"), req, nil, false)
+
+	return err
+}
+
+// restCommit is a commit as returned by the REST API.
+type restCommit struct {
+	SHA    string `json:"sha"`
+	Commit struct {
+		Message string `json:"message"`
+	} `json:"commit"`
+	Parents []struct {
+		SHA string `json:"sha"`
+	} `json:"parents"`
+}
+
+func (rc restCommit) toCommit() Commit {
+	commit := Commit{SHA: rc.SHA, Message: rc.Commit.Message}
+	for _, p := range rc.Parents {
+		commit.Parents = append(commit.Parents, p.SHA)
+	}
+
+	return commit
+}
+
+func (c *restClient) GetCommit(repo, sha string) (Commit, error) {
+	var rc restCommit
+
+	if _, err := c.do(http.MethodGet, repoPath(repo, "commits", sha), nil, &rc); err != nil {
+		return Commit{}, err
+	}
+
+	return rc.toCommit(), nil
+}
+
+// ListPRCommits returns the commits of the Pull Request, oldest first. GitHub lists at most 250 of them.
+func (c *restClient) ListPRCommits(repo string, nb int) ([]Commit, error) {
+	rcs, err := listAll[restCommit](c, repoPath(repo, "pulls", strconv.Itoa(nb), "commits"), url.Values{}, NoLimit)
+
+	commits := make([]Commit, 0, len(rcs))
+	for _, rc := range rcs {
+		commits = append(commits, rc.toCommit())
+	}
+
+	return commits, err
+}
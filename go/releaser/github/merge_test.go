@@ -0,0 +1,121 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/vitessio/vitess-releaser/go/releaser/github"
+)
+
+func TestVerifyMergeMethod(t *testing.T) {
+	tcs := []struct {
+		name    string
+		commits []string
+		method  string
+		title   string
+		want    github.MergeMethod
+	}{
+		{name: "merge", commits: []string{"first", "second"}, method: "merge", want: github.MergeMethodMerge},
+		{name: "squash", commits: []string{"first", "second"}, method: "squash", want: github.MergeMethodSquash},
+		{name: "squash with an edited title", commits: []string{"first", "second"}, method: "squash", title: "second", want: github.MergeMethodSquash},
+		{name: "rebase", commits: []string{"first", "second"}, method: "rebase", want: github.MergeMethodRebase},
+		{name: "rebase of a single commit", commits: []string{"first"}, method: "rebase", want: github.MergeMethodSquash},
+	}
+
+	methods := []github.MergeMethod{github.MergeMethodMerge, github.MergeMethodSquash, github.MergeMethodRebase}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			s := newServer(t)
+
+			nb := s.AddPR(testRepo, github.PR{Title: "Release of v21.0.0", Branch: "release-21.0-release", Base: "release-21.0"})
+			s.SetPRCommits(testRepo, nb, tc.commits...)
+			s.MergePRWithMethod(testRepo, nb, tc.method, tc.title)
+
+			for _, m := range methods {
+				err := github.VerifyMergeMethod(testRepo, nb, m)
+				if (err == nil) != (m == tc.want) {
+					t.Fatalf("unexpected result when expecting the %s method: %v", m, err)
+				}
+			}
+		})
+	}
+}
+
+func TestVerifyMergeMethodNotMerged(t *testing.T) {
+	s := newServer(t)
+
+	nb := s.AddPR(testRepo, github.PR{Title: "Release of v21.0.0", Branch: "release-21.0-release", Base: "release-21.0"})
+
+	if err := github.VerifyMergeMethod(testRepo, nb, github.MergeMethodSquash); err == nil {
+		t.Fatal("expected an open Pull Request to fail the verification")
+	}
+}
+
+func TestAutoMergePR(t *testing.T) {
+	s := newServer(t)
+
+	blocked := s.AddPR(testRepo, github.PR{Title: "Release of v21.0.0", Branch: "release-21.0-release", Base: "release-21.0"})
+	s.SetPRStatus(testRepo, blocked, "REVIEW_REQUIRED", "PENDING")
+
+	clean := s.AddPR(testRepo, github.PR{Title: "Back to dev mode", Branch: "back-to-dev", Base: "release-21.0"})
+	s.SetPRStatus(testRepo, clean, "APPROVED", "SUCCESS")
+
+	// A Pull Request that cannot be merged yet is merged by GitHub once approved and green
+	github.AutoMergePR(testRepo, blocked, github.MergeMethodSquash)
+
+	if github.IsPRMerged(testRepo, blocked) {
+		t.Fatal("expected the blocked Pull Request not to be merged yet")
+	}
+
+	if method := s.AutoMergeMethod(testRepo, blocked); method != string(github.MergeMethodSquash) {
+		t.Fatalf("expected auto-merge to be enabled with the squash method, got %q", method)
+	}
+
+	// Enabling it again, i.e. when resuming the step, is a no-op
+	github.AutoMergePR(testRepo, blocked, github.MergeMethodSquash)
+
+	if status := github.GetPRStatus(testRepo, blocked); status.AutoMergeMethod != github.MergeMethodSquash {
+		t.Fatalf("expected auto-merge to still be enabled with the squash method, got %+v", status)
+	}
+
+	s.SetPRStatus(testRepo, blocked, "APPROVED", "SUCCESS")
+
+	if err := github.VerifyMergeMethod(testRepo, blocked, github.MergeMethodSquash); err != nil {
+		t.Fatal(err)
+	}
+
+	// One that can be merged right away is merged
+	github.AutoMergePR(testRepo, clean, github.MergeMethodSquash)
+
+	if err := github.VerifyMergeMethod(testRepo, clean, github.MergeMethodSquash); err != nil {
+		t.Fatal(err)
+	}
+
+	// A merged Pull Request is left as is
+	mergePath := fmt.Sprintf("/repos/%s/pulls/%d/merge", testRepo, clean)
+	merges := s.Requests(http.MethodPut, mergePath)
+
+	github.AutoMergePR(testRepo, clean, github.MergeMethodSquash)
+
+	if n := s.Requests(http.MethodPut, mergePath); n != merges {
+		t.Fatalf("expected no new merge of the merged Pull Request, got %d requests instead of %d", n, merges)
+	}
+}
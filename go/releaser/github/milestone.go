@@ -17,66 +17,144 @@ limitations under the License.
 package github
 
 import (
-	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/url"
 	"strconv"
-	"strings"
+	"time"
 
 	"github.com/vitessio/vitess-releaser/go/releaser/utils"
 )
 
 type Milestone struct {
-	URL    string `json:"url"`
-	Number int    `json:"number"`
+	Title       string     `json:"title"`
+	State       string     `json:"state"`
+	Description string     `json:"description"`
+	DueOn       *time.Time `json:"due_on"`
+	URL         string     `json:"html_url"`
+	Number      int        `json:"number"`
+
+	// OpenIssues and ClosedIssues count both the issues and the Pull Requests of the milestone.
+	OpenIssues   int `json:"open_issues"`
+	ClosedIssues int `json:"closed_issues"`
 }
 
-func GetMilestonesByName(repo, name string) []Milestone {
-	str := execGh(
-		"milestone", "list",
-		"--query", name,
-		"--repo", repo,
-		"--json", "url,number",
-		"--state", "all",
-	)
+func (m Milestone) String() string {
+	return fmt.Sprintf("%s: %d open, %d closed", m.Title, m.OpenIssues, m.ClosedIssues)
+}
 
-	str = str[strings.Index(str, "]")+1:]
+// body returns the fields of the milestone sent when creating or updating it, the empty fields are left out.
+func (m Milestone) body() map[string]any {
+	body := map[string]any{}
 
-	var ms []Milestone
+	if m.Title != "" {
+		body["title"] = m.Title
+	}
 
-	err := json.Unmarshal([]byte(str), &ms)
+	if m.State != "" {
+		body["state"] = m.State
+	}
+
+	if m.Description != "" {
+		body["description"] = m.Description
+	}
+
+	if m.DueOn != nil {
+		body["due_on"] = m.DueOn.UTC().Format(time.RFC3339)
+	}
+
+	return body
+}
+
+// GetMilestonesByName returns the opened and closed milestones with the given title.
+func GetMilestonesByName(repo, name string) []Milestone {
+	all, err := getClient().ListMilestones(repo, "all")
 	if err != nil {
-		utils.BailOut(err, "failed to parse milestone, got: %s", str)
+		utils.BailOut(err, "failed to list the milestones of %s", repo)
+	}
+
+	var ms []Milestone
+
+	for _, m := range all {
+		if m.Title == name {
+			ms = append(ms, m)
+		}
 	}
 
 	return ms
 }
 
-func CreateNewMilestone(repo, name string) string {
-	stdOut := execGh(
-		"milestone", "create",
-		"--repo", repo,
-		"--title", name,
-	)
-	out := strings.ReplaceAll(stdOut, "\n", "")
-	idx := strings.LastIndex(out, fmt.Sprintf("https://github.com/%s/milestone/", repo))
+// GetMilestone returns the milestone with the given title, it bails out if there is not exactly one.
+func GetMilestone(repo, name string) Milestone {
+	ms := GetMilestonesByName(repo, name)
+	if len(ms) != 1 {
+		utils.BailOut(nil, "expected to find one milestone named %s, found %d", name, len(ms))
+	}
+
+	return ms[0]
+}
+
+// CreateNewMilestone creates the milestone with the given description and due date, dueOn can be the zero time.
+func CreateNewMilestone(repo, name, description string, dueOn time.Time) string {
+	m, err := getClient().CreateMilestone(repo, Milestone{Title: name, Description: description, DueOn: dueDate(dueOn)})
+	if err != nil {
+		utils.BailOut(err, "failed to create the milestone %s", name)
+	}
 
-	return out[idx:]
+	return m.URL
+}
+
+// UpdateMilestoneDetails sets the description and the due date of the milestone.
+func UpdateMilestoneDetails(repo, name, description string, dueOn time.Time) string {
+	ms := GetMilestone(repo, name)
+
+	m, err := getClient().UpdateMilestone(repo, ms.Number, Milestone{Description: description, DueOn: dueDate(dueOn)})
+	if err != nil {
+		utils.BailOut(err, "failed to update the milestone %s", name)
+	}
+
+	return m.URL
 }
 
 func CloseMilestone(repo, name string) string {
-	ms := GetMilestonesByName(repo, name)
-	if len(ms) != 1 {
-		utils.BailOut(nil, "expected to find one milestone found %d", len(ms))
+	ms := GetMilestone(repo, name)
+
+	m, err := getClient().UpdateMilestone(repo, ms.Number, Milestone{State: "closed"})
+	if err != nil {
+		utils.BailOut(err, "failed to close the milestone %s", name)
+	}
+
+	return m.URL
+}
+
+func dueDate(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
 	}
 
-	stdOut := execGh(
-		"milestone", "edit",
-		strconv.Itoa(ms[0].Number),
-		"--repo", repo,
-		"--state", "closed",
-	)
-	out := strings.ReplaceAll(stdOut, "\n", "")
-	idx := strings.LastIndex(out, fmt.Sprintf("https://github.com/%s/milestone/", repo))
+	return &t
+}
+
+func (c *restClient) ListMilestones(repo, state string) ([]Milestone, error) {
+	params := url.Values{}
+	params.Set("state", listState(state))
+
+	return listAll[Milestone](c, repoPath(repo, "milestones"), params, NoLimit)
+}
+
+func (c *restClient) CreateMilestone(repo string, milestone Milestone) (Milestone, error) {
+	var m Milestone
+
+	_, err := c.do(http.MethodPost, repoPath(repo, "milestones"), milestone.body(), &m)
+
+	return m, err
+}
+
+// UpdateMilestone updates the non-empty fields of milestone.
+func (c *restClient) UpdateMilestone(repo string, nb int, milestone Milestone) (Milestone, error) {
+	var m Milestone
+
+	_, err := c.do(http.MethodPatch, repoPath(repo, "milestones", strconv.Itoa(nb)), milestone.body(), &m)
 
-	return out[idx:]
+	return m, err
 }
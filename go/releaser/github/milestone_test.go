@@ -0,0 +1,153 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/vitessio/vitess-releaser/go/releaser/github"
+	"github.com/vitessio/vitess-releaser/go/releaser/github/githubtest"
+)
+
+func TestMilestoneLifecycle(t *testing.T) {
+	newServer(t)
+
+	dueOn := time.Date(2024, time.October, 30, 0, 0, 0, 0, time.UTC)
+
+	github.CreateNewMilestone(testRepo, "v21.0.0", "", time.Time{})
+
+	ms := github.GetMilestone(testRepo, "v21.0.0")
+	if ms.State != "open" || ms.DueOn != nil || ms.Description != "" {
+		t.Fatalf("unexpected milestone %+v", ms)
+	}
+
+	github.UpdateMilestoneDetails(testRepo, "v21.0.0", "Release of v21.0.0", dueOn)
+
+	ms = github.GetMilestone(testRepo, "v21.0.0")
+	if ms.Description != "Release of v21.0.0" || ms.DueOn == nil || !ms.DueOn.Equal(dueOn) {
+		t.Fatalf("expected the description and the due date to be set, got %+v", ms)
+	}
+
+	github.CloseMilestone(testRepo, "v21.0.0")
+
+	ms = github.GetMilestone(testRepo, "v21.0.0")
+	if ms.State != "closed" || ms.Description != "Release of v21.0.0" {
+		t.Fatalf("expected the milestone to be closed and its details kept, got %+v", ms)
+	}
+
+	if ms := github.GetMilestonesByName(testRepo, "v22.0.0"); len(ms) != 0 {
+		t.Fatalf("expected no milestone, got %+v", ms)
+	}
+}
+
+func TestMilestonePRs(t *testing.T) {
+	s := newServer(t)
+
+	github.CreateNewMilestone(testRepo, "v21.0.0", "", time.Time{})
+
+	// More Pull Requests than a page of the GraphQL connection
+	var merged []int
+	for i := range 120 {
+		nb := s.AddPR(testRepo, github.PR{Title: fmt.Sprintf("pr %d", i), Author: github.Author{Login: fmt.Sprintf("author-%d", i%3)}})
+		s.SetMilestone(testRepo, nb, "v21.0.0")
+
+		if i%2 == 0 {
+			s.MergePR(testRepo, nb, fmt.Sprintf("%040x", nb))
+			merged = append(merged, nb)
+		}
+	}
+
+	prs, authors := github.GetMergedPRsAndAuthorsByMilestone(testRepo, "v21.0.0")
+	if len(prs) != len(merged) {
+		t.Fatalf("expected %d merged Pull Requests, got %d", len(merged), len(prs))
+	}
+
+	for _, pr := range prs {
+		if !slices.Contains(merged, pr.Number) {
+			t.Fatalf("unexpected Pull Request %d", pr.Number)
+		}
+	}
+
+	if want := []string{"author-0", "author-1", "author-2"}; !slices.Equal(authors, want) {
+		t.Fatalf("expected the authors %q, got %q", want, authors)
+	}
+
+	opened := github.GetOpenedPRsByMilestone(testRepo, "v21.0.0")
+	if len(opened) != 120-len(merged) {
+		t.Fatalf("expected %d open Pull Requests, got %d", 120-len(merged), len(opened))
+	}
+
+	if prs := github.GetOpenedPRsByMilestone(testRepo, "v22.0.0"); prs != nil {
+		t.Fatalf("expected no Pull Request for a missing milestone, got %d", len(prs))
+	}
+}
+
+func TestAssignMilestoneToPRs(t *testing.T) {
+	s := newServer(t)
+
+	github.CreateNewMilestone(testRepo, "v21.0.0", "", time.Time{})
+	github.CreateNewMilestone(testRepo, "v22.0.0", "", time.Time{})
+
+	var prs []github.PR
+	for i := range 5 {
+		nb := s.AddPR(testRepo, github.PR{Title: fmt.Sprintf("pr %d", i)})
+		s.SetMilestone(testRepo, nb, "v21.0.0")
+		prs = append(prs, github.PR{Number: nb})
+	}
+
+	failed := prs[3].Number
+	s.Fail(githubtest.Failure{
+		Method: http.MethodPatch,
+		Path:   fmt.Sprintf("/repos/%s/issues/%d", testRepo, failed),
+		Status: http.StatusUnprocessableEntity,
+	})
+
+	err := github.AssignMilestoneToPRs(testRepo, "v22.0.0", prs, nil)
+
+	var bulkErr *github.BulkError
+	if !errors.As(err, &bulkErr) {
+		t.Fatalf("expected a BulkError, got %v", err)
+	}
+
+	if len(bulkErr.Failures) != 1 || bulkErr.Failures[failed] == nil {
+		t.Fatalf("expected only the Pull Request %d to fail, got %v", failed, bulkErr)
+	}
+
+	moved := github.GetOpenedPRsByMilestone(testRepo, "v22.0.0")
+	if len(moved) != 4 {
+		t.Fatalf("expected 4 Pull Requests to be moved, got %d", len(moved))
+	}
+
+	// The failed Pull Request is moved on the next attempt
+	left := github.GetOpenedPRsByMilestone(testRepo, "v21.0.0")
+	if len(left) != 1 || left[0].Number != failed {
+		t.Fatalf("expected the Pull Request %d to be left on the milestone, got %+v", failed, left)
+	}
+
+	if err := github.AssignMilestoneToPRs(testRepo, "v22.0.0", left, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if left := github.GetOpenedPRsByMilestone(testRepo, "v21.0.0"); len(left) != 0 {
+		t.Fatalf("expected all the Pull Requests to be moved, got %+v", left)
+	}
+}
@@ -17,8 +17,10 @@ limitations under the License.
 package github
 
 import (
-	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -28,7 +30,9 @@ import (
 )
 
 type Label struct {
-	Name string `json:"name"`
+	Name        string `json:"name"`
+	Color       string `json:"color,omitempty"`
+	Description string `json:"description,omitempty"`
 }
 
 type Author struct {
@@ -44,138 +48,145 @@ type PR struct {
 	Labels []Label `json:"labels"`
 	Author Author  `json:"author"`
 	Number int     `json:"number"`
+
+	// State is OPEN, CLOSED or MERGED.
+	State          string `json:"state,omitempty"`
+	MergeCommitSHA string `json:"mergeCommitSHA,omitempty"`
+	HeadSHA        string `json:"headRefOid,omitempty"`
 }
 
-func (p *PR) Create(issueLink string, repo string) (nb int, url string) {
-	var labels []string
-	for _, label := range p.Labels {
-		labels = append(labels, label.Name)
+// Create opens the Pull Request and requests the review of the reviewers set with RequestReviewsFrom,
+// who are also mentioned in its body. The review request may fail, i.e. if a reviewer is not a collaborator
+// of the repository, without failing the creation: its error is returned as reviewErr, to be shown as a warning.
+func (p *PR) Create(issueLink string, repo string) (nb int, url string, reviewErr error) {
+	p.Body = fmt.Sprintf("%s\n\n> This Pull Request is part of %s", p.Body, issueLink)
+
+	users, teams, mentions := prReviewers(repo, CurrentUser())
+	if mention := reviewersMention(mentions); mention != "" {
+		p.Body = fmt.Sprintf("%s\n%s", p.Body, mention)
 	}
 
-	p.Body = fmt.Sprintf("%s\n\n> This Pull Request is part of %s", p.Body, issueLink)
+	created, err := getClient().CreatePR(repo, *p)
+	if err != nil {
+		utils.BailOut(err, "failed to create the Pull Request '%s'", p.Title)
+	}
 
-	stdOut := execGh(
-		"pr", "create",
-		"--repo", repo,
-		"--title", p.Title,
-		"--body", p.Body,
-		"--label", strings.Join(labels, ","),
-		"--head", p.Branch,
-		"--base", p.Base,
-	)
-	url = strings.ReplaceAll(stdOut, "\n", "")
-	nb = URLToNb(url)
+	if len(users) > 0 || len(teams) > 0 {
+		if err := getClient().RequestReviewers(repo, created.Number, users, teams); err != nil {
+			reviewErr = fmt.Errorf("failed to request the review of the Pull Request %d, the reviewers are only notified by the mention: %w", created.Number, err)
+		}
+	}
 
-	return nb, url
+	return created.Number, created.URL, reviewErr
 }
 
 func IsPRMerged(repo string, nb int) bool {
-	stdOut := execGh(
-		"pr", "view", strconv.Itoa(nb),
-		"--repo", repo,
-		"--json", "mergedAt",
-	)
-
-	// If the PR is not merged, the output of the gh command will be:
-	// {
-	//  "mergedAt": null
-	// }
-	//
-	// We can then grep for "null", if present, the PR has not been merged yet.
-	return !strings.Contains(stdOut, "null")
+	return getPR(repo, nb).State == "MERGED"
 }
 
-func CheckBackportToPRs(repo, branch string) map[string]any {
-	git.CorrectCleanRepo(repo)
+// PRStatus is where a Pull Request stands on its way to being merged.
+type PRStatus struct {
+	// State is OPEN, CLOSED or MERGED.
+	State string
 
-	stdOut := execGh("pr", "list", "--json", "title,baseRefName,url,labels", "--repo", repo)
+	// ReviewDecision is APPROVED, CHANGES_REQUESTED or REVIEW_REQUIRED, empty if no review is required.
+	ReviewDecision string
 
-	var prs []PR
+	// Checks is the combined state of the checks of the last commit: SUCCESS, PENDING, FAILURE,
+	// ERROR or EXPECTED, empty if there are no checks.
+	Checks string
 
-	err := json.Unmarshal([]byte(stdOut), &prs)
-	if err != nil {
-		utils.BailOut(err, "failed to parse backport PRs, got: %s", stdOut)
-	}
-
-	var mustClose []PR
+	// MergeStateStatus is CLEAN when the Pull Request can be merged right away, BLOCKED, BEHIND, DIRTY,
+	// UNSTABLE, HAS_HOOKS, DRAFT or UNKNOWN otherwise.
+	MergeStateStatus string
 
-	for _, pr := range prs {
-		if pr.Base == branch {
-			mustClose = append(mustClose, pr)
-		}
+	// AutoMergeMethod is the merge method of the auto-merge enabled on the Pull Request, empty if disabled.
+	AutoMergeMethod MergeMethod
+}
 
-		for _, l := range pr.Labels {
-			if strings.HasPrefix(l.Name, "Backport to: ") && strings.Contains(l.Name, branch) {
-				mustClose = append(mustClose, pr)
-			}
-		}
+func (s PRStatus) String() string {
+	review := s.ReviewDecision
+	if review == "" {
+		review = "not required"
 	}
 
-	m := make(map[string]any, len(mustClose))
+	checks := s.Checks
+	if checks == "" {
+		checks = "none"
+	}
 
-	for _, pr := range mustClose {
-		nb := pr.URL[strings.LastIndex(pr.URL, "/")+1:]
-		markdownURL := fmt.Sprintf("#%s", nb)
-		m[markdownURL] = nil
+	msg := fmt.Sprintf("state: %s, review: %s, checks: %s", s.State, review, checks)
+	if s.AutoMergeMethod != "" {
+		msg += fmt.Sprintf(", auto-merge: %s", strings.ToLower(string(s.AutoMergeMethod)))
 	}
 
-	return m
+	return msg
 }
 
-func CheckReleaseBlockerPRs(repo, majorRelease string) map[string]any {
-	git.CorrectCleanRepo(repo)
+// GetPRStatus returns the state, review decision and checks state of the Pull Request.
+func GetPRStatus(repo string, nb int) PRStatus {
+	status, err := PRStatusOf(repo, nb)
+	if err != nil {
+		utils.BailOut(err, "failed to get the status of the Pull Request %d", nb)
+	}
 
-	stdOut := execGh("pr", "list", "--json", "title,url,labels", "--repo", repo)
+	return status
+}
 
-	var prs []PR
+// PRStatusOf is the same as GetPRStatus, but returns the error instead of bailing out,
+// for the callers that poll the status and can retry on transient errors.
+func PRStatusOf(repo string, nb int) (PRStatus, error) {
+	return getClient().GetPRStatus(repo, nb)
+}
 
-	err := json.Unmarshal([]byte(stdOut), &prs)
-	if err != nil {
-		utils.BailOut(err, "failed to parse the release blocker PRs, got: %s", stdOut)
-	}
+// CheckPendingItems returns the open Pull Requests to merge before releasing the branch: the ones based on it or
+// labelled to be backported to it, and the open release blocker issues and Pull Requests of the major release.
+// They are returned as markdown links (#123), the lookups are made at the same time.
+func CheckPendingItems(repo, branch, majorRelease string) (backports, releaseBlockers map[string]any) {
+	git.CorrectRepo(repo)
 
-	var mustClose []PR
+	blockerLabel := fmt.Sprintf("is:open label:%q", fmt.Sprintf("Release Blocker: release-%s.0", majorRelease))
 
-	branchName := fmt.Sprintf("release-%s.0", majorRelease)
+	var basedPRs, labelledPRs, blockerPRs []PR
+	var blockerIssues []Issue
 
-	for _, i := range prs {
-		for _, l := range i.Labels {
-			if strings.HasPrefix(l.Name, "Release Blocker: ") && strings.Contains(l.Name, branchName) {
-				mustClose = append(mustClose, i)
-			}
-		}
+	concurrently(
+		func() { basedPRs = listPRs(repo, ListOptions{State: "open", Base: branch, Limit: NoLimit}) },
+		func() {
+			labelledPRs = searchPRs(repo, fmt.Sprintf("is:open label:%q", "Backport to: "+branch), NoLimit)
+		},
+		func() { blockerPRs = searchPRs(repo, blockerLabel, NoLimit) },
+		func() { blockerIssues = searchIssues(repo, blockerLabel, NoLimit) },
+	)
+
+	backports = map[string]any{}
+	for _, pr := range slices.Concat(basedPRs, labelledPRs) {
+		backports[markdownLink(pr.URL)] = nil
 	}
 
-	m := make(map[string]any, len(mustClose))
+	releaseBlockers = map[string]any{}
+	for _, pr := range blockerPRs {
+		releaseBlockers[markdownLink(pr.URL)] = nil
+	}
 
-	for _, pr := range mustClose {
-		nb := pr.URL[strings.LastIndex(pr.URL, "/")+1:]
-		markdownURL := fmt.Sprintf("#%s", nb)
-		m[markdownURL] = nil
+	for _, issue := range blockerIssues {
+		releaseBlockers[markdownLink(issue.URL)] = nil
 	}
 
-	return m
+	return backports, releaseBlockers
 }
 
-func FindPR(repo, prTitle string) (nb int, url string) {
-	stdOut := execGh(
-		"pr", "list",
-		"--json", "url,title",
-		"--repo", repo,
-		"--search", prTitle,
-		"--state", "open",
-	)
-
-	var prs []PR
+// markdownLink turns the URL of an issue or a Pull Request into a markdown link: #123.
+func markdownLink(url string) string {
+	return fmt.Sprintf("#%s", url[strings.LastIndex(url, "/")+1:])
+}
 
-	err := json.Unmarshal([]byte(stdOut), &prs)
-	if err != nil {
-		utils.BailOut(err, "failed to parse PRs, got: %s", stdOut)
-	}
+func FindPR(repo, prTitle string) (nb int, url string) {
+	prs := searchPRs(repo, fmt.Sprintf("is:open %s", prTitle), defaultListLimit)
 
 	for _, pr := range prs {
 		if pr.Title == prTitle {
-			return URLToNb(pr.URL), pr.URL
+			return pr.Number, pr.URL
 		}
 	}
 
@@ -183,19 +194,7 @@ func FindPR(repo, prTitle string) (nb int, url string) {
 }
 
 func GetMergedPRsAndAuthorsByMilestone(repo, milestone string) (prs []PR, authors []string) {
-	stdOut := execGh(
-		"pr", "list",
-		"-s", "merged",
-		"-S", fmt.Sprintf("milestone:%s", milestone),
-		"--json", "number,title,labels,author",
-		"--limit", "5000",
-		"--repo", repo,
-	)
-
-	err := json.Unmarshal([]byte(stdOut), &prs)
-	if err != nil {
-		utils.BailOut(err, "failed to parse PRs, got: %s", stdOut)
-	}
+	prs = listMilestonePRs(repo, milestone, "MERGED")
 
 	// Get the full list of distinct PRs authors and sort them
 	authorMap := map[string]bool{}
@@ -217,32 +216,235 @@ func GetMergedPRsAndAuthorsByMilestone(repo, milestone string) (prs []PR, author
 }
 
 func GetOpenedPRsByMilestone(repo, milestone string) []PR {
-	stdOut := execGh(
-		"pr", "list",
-		"-s", "open",
-		"-S", fmt.Sprintf("milestone:%s", milestone),
-		"--json", "number,title,labels,author",
-		"--limit", "5000",
-		"--repo", repo,
-	)
+	return listMilestonePRs(repo, milestone, "OPEN")
+}
 
-	var prs []PR
+// listMilestonePRs returns all the Pull Requests of the milestone in the given states,
+// or none if there is no such milestone.
+func listMilestonePRs(repo, milestone string, states ...string) []PR {
+	ms := GetMilestonesByName(repo, milestone)
+	if len(ms) == 0 {
+		return nil
+	}
 
-	err := json.Unmarshal([]byte(stdOut), &prs)
+	prs, err := getClient().ListMilestonePRs(repo, ms[0].Number, states...)
 	if err != nil {
-		utils.BailOut(err, "failed to parse PRs, got: %s", stdOut)
+		utils.BailOut(err, "failed to list the Pull Requests of the milestone %s", milestone)
 	}
 
 	return prs
 }
 
-func AssignMilestoneToPRs(repo, milestone string, prs []PR) {
+// GetOpenedPRsByBase returns the open Pull Requests targeting the branch.
+func GetOpenedPRsByBase(repo, branch string) []PR {
+	return listPRs(repo, ListOptions{State: "open", Base: branch, Limit: NoLimit})
+}
+
+// CommentOnPRs posts a comment, whose body depends on the Pull Request, on each of the Pull Requests, a few of
+// them at a time. The failures are returned as a *BulkError once all the Pull Requests were processed.
+func CommentOnPRs(repo string, prs []PR, body func(pr PR) string, progress BulkProgress) error {
+	byNumber := make(map[int]PR, len(prs))
+	numbers := make([]int, 0, len(prs))
+
+	for _, pr := range prs {
+		byNumber[pr.Number] = pr
+		numbers = append(numbers, pr.Number)
+	}
+
+	return CommentOnItems(repo, numbers, func(nb int) string { return body(byNumber[nb]) }, progress)
+}
+
+// CommentOnItems posts a comment, whose body depends on the number, on each of the issues or Pull Requests, a few of
+// them at a time. The failures are returned as a *BulkError once all the items were processed.
+func CommentOnItems(repo string, numbers []int, body func(nb int) string, progress BulkProgress) error {
+	return runBulk(numbers, progress, func(nb int) error {
+		return getClient().CreateComment(repo, nb, body(nb))
+	})
+}
+
+// AssignMilestoneToPRs sets the milestone on the Pull Requests, a few of them at a time. The failures are
+// returned as a *BulkError once all the Pull Requests were processed.
+func AssignMilestoneToPRs(repo, milestone string, prs []PR, progress BulkProgress) error {
+	ms := GetMilestone(repo, milestone)
+
+	numbers := make([]int, 0, len(prs))
+	for _, pr := range prs {
+		numbers = append(numbers, pr.Number)
+	}
+
+	return runBulk(numbers, progress, func(nb int) error {
+		return getClient().SetMilestone(repo, nb, ms.Number)
+	})
+}
+
+// GetPRStatesForBranch returns the state (OPEN, CLOSED or MERGED) of all the Pull Requests
+// that were opened from the given head branch. The branch can be prefixed with the owner of
+// the fork it lives on ("owner:branch"), otherwise the owner of the repository is assumed.
+func GetPRStatesForBranch(repo, branch string) []string {
+	prs := listPRs(repo, ListOptions{State: "all", Head: branch})
+
+	states := make([]string, 0, len(prs))
 	for _, pr := range prs {
-		execGh(
-			"pr", "edit",
-			strconv.Itoa(pr.Number),
-			"--milestone", milestone,
-			"--repo", repo,
-		)
+		states = append(states, pr.State)
+	}
+
+	return states
+}
+
+// GetPRMergeCommit returns the SHA of the commit created when merging the Pull Request,
+// or an empty string if the Pull Request is not merged.
+func GetPRMergeCommit(repo string, nb int) string {
+	return getPR(repo, nb).MergeCommitSHA
+}
+
+func getPR(repo string, nb int) PR {
+	pr, err := getClient().GetPR(repo, nb)
+	if err != nil {
+		utils.BailOut(err, "failed to get the Pull Request %d", nb)
+	}
+
+	return pr
+}
+
+func listPRs(repo string, opts ListOptions) []PR {
+	prs, err := getClient().ListPRs(repo, opts)
+	if err != nil {
+		utils.BailOut(err, "failed to list the Pull Requests of %s", repo)
+	}
+
+	return prs
+}
+
+func searchPRs(repo, query string, limit int) []PR {
+	prs, err := getClient().SearchPRs(repo, query, limit)
+	if err != nil {
+		utils.BailOut(err, "failed to search the Pull Requests of %s matching '%s'", repo, query)
+	}
+
+	return prs
+}
+
+// restPR is a Pull Request as returned by the pulls endpoints.
+type restPR struct {
+	Number         int      `json:"number"`
+	Title          string   `json:"title"`
+	Body           string   `json:"body"`
+	State          string   `json:"state"`
+	HTMLURL        string   `json:"html_url"`
+	Labels         []Label  `json:"labels"`
+	User           restUser `json:"user"`
+	NodeID         string   `json:"node_id"`
+	MergedAt       *string  `json:"merged_at"`
+	MergeCommitSHA string   `json:"merge_commit_sha"`
+	Head           struct {
+		Ref string `json:"ref"`
+		SHA string `json:"sha"`
+	} `json:"head"`
+	Base struct {
+		Ref string `json:"ref"`
+	} `json:"base"`
+}
+
+func (rp restPR) toPR() PR {
+	pr := PR{
+		Title:   rp.Title,
+		Body:    rp.Body,
+		Branch:  rp.Head.Ref,
+		HeadSHA: rp.Head.SHA,
+		Base:    rp.Base.Ref,
+		URL:     rp.HTMLURL,
+		Labels:  rp.Labels,
+		Author:  rp.User.toAuthor(),
+		Number:  rp.Number,
+		State:   strings.ToUpper(rp.State),
+	}
+
+	if rp.MergedAt != nil {
+		pr.State = "MERGED"
+		pr.MergeCommitSHA = rp.MergeCommitSHA
+	}
+
+	return pr
+}
+
+// CreatePR opens the Pull Request from pr.Branch, which can be prefixed by the owner of a fork, and adds its labels.
+func (c *restClient) CreatePR(repo string, pr PR) (PR, error) {
+	req := map[string]any{
+		"title": pr.Title,
+		"body":  pr.Body,
+		"head":  pr.Branch,
+		"base":  pr.Base,
+	}
+
+	var rp restPR
+
+	if _, err := c.do(http.MethodPost, repoPath(repo, "pulls"), req, &rp); err != nil {
+		return PR{}, err
+	}
+
+	if len(pr.Labels) > 0 {
+		labels := map[string]any{"labels": labelNames(pr.Labels)}
+		if _, err := c.do(http.MethodPost, repoPath(repo, "issues", strconv.Itoa(rp.Number), "labels"), labels, &rp.Labels); err != nil {
+			return rp.toPR(), err
+		}
+	}
+
+	return rp.toPR(), nil
+}
+
+func (c *restClient) GetPR(repo string, nb int) (PR, error) {
+	var rp restPR
+
+	_, err := c.do(http.MethodGet, repoPath(repo, "pulls", strconv.Itoa(nb)), nil, &rp)
+
+	return rp.toPR(), err
+}
+
+// ListPRs lists the Pull Requests of the repository. Filtering on labels is not supported by the API.
+func (c *restClient) ListPRs(repo string, opts ListOptions) ([]PR, error) {
+	params := url.Values{}
+	params.Set("state", listState(opts.State))
+
+	if opts.Head != "" {
+		head := opts.Head
+		if !strings.Contains(head, ":") {
+			head = strings.Split(repo, "/")[0] + ":" + head
+		}
+
+		params.Set("head", head)
+	}
+
+	if opts.Base != "" {
+		params.Set("base", opts.Base)
 	}
+
+	rps, err := listAll[restPR](c, repoPath(repo, "pulls"), params, opts.Limit)
+
+	prs := make([]PR, 0, len(rps))
+	for _, rp := range rps {
+		prs = append(prs, rp.toPR())
+	}
+
+	return prs, err
+}
+
+// SearchPRs returns the Pull Requests of the repository matching the search query, i.e. "is:open milestone:v21.0.0".
+// The Branch, Base and MergeCommitSHA fields are not returned by the search API.
+func (c *restClient) SearchPRs(repo, query string, limit int) ([]PR, error) {
+	ris, err := c.search(fmt.Sprintf("repo:%s is:pr %s", repo, query), limit)
+
+	prs := make([]PR, 0, len(ris))
+	for _, ri := range ris {
+		prs = append(prs, PR{
+			Title:  ri.Title,
+			Body:   ri.Body,
+			URL:    ri.HTMLURL,
+			Labels: ri.Labels,
+			Author: ri.User.toAuthor(),
+			Number: ri.Number,
+			State:  strings.ToUpper(ri.State),
+		})
+	}
+
+	return prs, err
 }
@@ -0,0 +1,257 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	gh "github.com/cli/go-gh/v2"
+
+	"github.com/vitessio/vitess-releaser/go/releaser/utils"
+)
+
+const projectFieldTypeSingleSelect = "ProjectV2SingleSelectField"
+
+// execGh runs a gh command, the Projects (v2) boards are still managed through `gh project`.
+// Commands that can safely be run twice are retried on transient errors, like the API calls.
+func execGh(args ...string) string {
+	idempotent := len(args) > 1 && args[1] != "item-create"
+
+	for attempt := 1; ; attempt++ {
+		stdOut, stdErr, err := gh.Exec(args...)
+		if err == nil {
+			return stdOut.String()
+		}
+
+		if wait, retry := DefaultRetryPolicy.shouldRetry(attempt, idempotent, classifyGhError(stdErr.String())); retry {
+			time.Sleep(wait)
+			continue
+		}
+
+		cmd := append([]string{"gh"}, strings.Join(args, " "))
+		utils.BailOut(err, "failed to execute: %s, got: %s", strings.Join(cmd, " "), stdOut.String()+stdErr.String())
+	}
+}
+
+var ghServerError = regexp.MustCompile(`HTTP (5\d\d)`)
+
+// classifyGhError turns the error output of gh into an error that RetryPolicy understands.
+func classifyGhError(stdErr string) error {
+	lower := strings.ToLower(stdErr)
+
+	switch {
+	case strings.Contains(lower, "rate limit"):
+		return &APIError{Message: stdErr, StatusCode: http.StatusForbidden, RateLimited: true}
+	case ghServerError.MatchString(stdErr):
+		status, _ := strconv.Atoi(ghServerError.FindStringSubmatch(stdErr)[1])
+		return &APIError{Message: stdErr, StatusCode: status}
+	case strings.Contains(lower, "timeout"), strings.Contains(lower, "connection reset"), strings.Contains(lower, "eof"):
+		return &networkError{err: errors.New(stdErr)}
+	}
+
+	return errors.New(stdErr)
+}
+
+type ProjectFieldOption struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type ProjectField struct {
+	ID      string               `json:"id"`
+	Name    string               `json:"name"`
+	Type    string               `json:"type"`
+	Options []ProjectFieldOption `json:"options"`
+}
+
+// Project is a GitHub Projects (v2) board, Fields are indexed by their name.
+type Project struct {
+	ID     string
+	Owner  string
+	Number int
+	Fields map[string]ProjectField
+}
+
+// ProjectItem is an item of a Projects (v2) board. Values holds the value of
+// each field of the item, indexed by the lower-cased name of the field.
+type ProjectItem struct {
+	ID     string
+	Title  string
+	Values map[string]string
+}
+
+func GetProject(owner string, number int) Project {
+	nb := strconv.Itoa(number)
+
+	stdOut := execGh("project", "view", nb, "--owner", owner, "--format", "json")
+
+	var p struct {
+		ID string `json:"id"`
+	}
+
+	err := json.Unmarshal([]byte(stdOut), &p)
+	if err != nil {
+		utils.BailOut(err, "failed to parse project %s/%d, got: %s", owner, number, stdOut)
+	}
+
+	stdOut = execGh("project", "field-list", nb, "--owner", owner, "--format", "json", "--limit", "100")
+
+	var fields struct {
+		Fields []ProjectField `json:"fields"`
+	}
+
+	err = json.Unmarshal([]byte(stdOut), &fields)
+	if err != nil {
+		utils.BailOut(err, "failed to parse the fields of project %s/%d, got: %s", owner, number, stdOut)
+	}
+
+	project := Project{
+		ID:     p.ID,
+		Owner:  owner,
+		Number: number,
+		Fields: make(map[string]ProjectField, len(fields.Fields)),
+	}
+
+	for _, field := range fields.Fields {
+		project.Fields[field.Name] = field
+	}
+
+	return project
+}
+
+func (p Project) ListItems() []ProjectItem {
+	stdOut := execGh(
+		"project", "item-list", strconv.Itoa(p.Number),
+		"--owner", p.Owner,
+		"--format", "json",
+		"--limit", "1000",
+	)
+
+	var list struct {
+		Items []map[string]any `json:"items"`
+	}
+
+	err := json.Unmarshal([]byte(stdOut), &list)
+	if err != nil {
+		utils.BailOut(err, "failed to parse the items of project %s/%d, got: %s", p.Owner, p.Number, stdOut)
+	}
+
+	items := make([]ProjectItem, 0, len(list.Items))
+
+	for _, raw := range list.Items {
+		item := ProjectItem{Values: map[string]string{}}
+
+		for key, value := range raw {
+			str, ok := value.(string)
+			if !ok {
+				continue
+			}
+
+			switch key {
+			case "id":
+				item.ID = str
+			case "title":
+				item.Title = str
+			default:
+				item.Values[key] = str
+			}
+		}
+
+		items = append(items, item)
+	}
+
+	return items
+}
+
+// CreateDraftItem adds a new draft issue to the project and returns the ID of the new item.
+func (p Project) CreateDraftItem(title, body string) string {
+	stdOut := execGh(
+		"project", "item-create", strconv.Itoa(p.Number),
+		"--owner", p.Owner,
+		"--title", title,
+		"--body", body,
+		"--format", "json",
+	)
+
+	var item struct {
+		ID string `json:"id"`
+	}
+
+	err := json.Unmarshal([]byte(stdOut), &item)
+	if err != nil {
+		utils.BailOut(err, "failed to parse the new project item, got: %s", stdOut)
+	}
+
+	return item.ID
+}
+
+// SetItemField sets the value of the given text or single select field on a project item.
+// The field is ignored if it does not exist on the project, for single select fields the
+// value must match the name of one of the options.
+func (p Project) SetItemField(itemID, fieldName, value string) {
+	p.setItemField(itemID, fieldName, value, false)
+}
+
+// SetItemDateField sets the value of a date field on a project item, the date
+// must use the YYYY-MM-DD format.
+func (p Project) SetItemDateField(itemID, fieldName, date string) {
+	p.setItemField(itemID, fieldName, date, true)
+}
+
+func (p Project) setItemField(itemID, fieldName, value string, isDate bool) {
+	field, ok := p.Fields[fieldName]
+	if !ok {
+		return
+	}
+
+	args := []string{
+		"project", "item-edit",
+		"--id", itemID,
+		"--project-id", p.ID,
+		"--field-id", field.ID,
+	}
+
+	switch {
+	case field.Type == projectFieldTypeSingleSelect:
+		optionID := ""
+
+		for _, option := range field.Options {
+			if option.Name == value {
+				optionID = option.ID
+				break
+			}
+		}
+
+		if optionID == "" {
+			utils.BailOut(nil, "option '%s' not found in the '%s' field of project %s/%d", value, fieldName, p.Owner, p.Number)
+		}
+
+		args = append(args, "--single-select-option-id", optionID)
+	case isDate:
+		args = append(args, "--date", value)
+	default:
+		args = append(args, "--text", value)
+	}
+
+	_ = execGh(args...)
+}
@@ -17,51 +17,319 @@ limitations under the License.
 package github
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
 	"fmt"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
 
-	"github.com/vitessio/vitess-releaser/go/releaser/utils"
-
 	"github.com/vitessio/vitess-releaser/go/releaser/git"
+	"github.com/vitessio/vitess-releaser/go/releaser/utils"
 )
 
-func CreateRelease(repo, tag, notesFilePath string, latest, prerelease bool) (url string) {
-	target := git.GetSHAForGitRef(tag)
+// Release is a GitHub release. When Body is empty, the release notes are generated by GitHub.
+type Release struct {
+	ID         int64  `json:"id"`
+	Tag        string `json:"tag_name"`
+	Target     string `json:"target_commitish"`
+	Name       string `json:"name"`
+	Body       string `json:"body"`
+	Prerelease bool   `json:"prerelease"`
+	Latest     bool   `json:"-"`
+	URL        string `json:"html_url"`
+	UploadURL  string `json:"upload_url"`
+}
 
-	args := []string{
-		"release", "create",
-		"--repo", repo,
-		"--title", fmt.Sprintf("Vitess %s", tag),
-		"--target", target,
-		"--verify-tag",
+// ReleaseAsset is a file attached to a release. Digest, "sha256:<hex>", is only set
+// for the assets uploaded after GitHub started computing it.
+type ReleaseAsset struct {
+	ID     int64  `json:"id"`
+	Name   string `json:"name"`
+	Size   int64  `json:"size"`
+	Digest string `json:"digest"`
+	URL    string `json:"browser_download_url"`
+}
+
+// CreateRelease creates the release of the tag, with the release notes read from notesFilePath, or generated by GitHub
+// when empty. If the release already exists, it is updated to match: the body is refreshed from the notes, the
+// latest and prerelease flags are toggled, and the title and target are edited when they differ.
+// The changes made to an existing release are returned.
+func CreateRelease(repo, tag, notesFilePath string, latest, prerelease bool) (url string, changes []string) {
+	release := Release{
+		Tag:        tag,
+		Target:     git.GetSHAForGitRef(tag + "^{commit}"),
+		Name:       fmt.Sprintf("Vitess %s", tag),
+		Prerelease: prerelease,
+		Latest:     latest,
 	}
 
 	if notesFilePath != "" {
-		args = append(args, "-F", notesFilePath)
-	} else {
-		args = append(args, "--generate-notes")
+		notes, err := os.ReadFile(notesFilePath)
+		if err != nil {
+			utils.BailOut(err, "failed to read the release notes %s", notesFilePath)
+		}
+
+		release.Body = string(notes)
+	}
+
+	published, changes := CreateOrUpdateRelease(repo, release)
+
+	return published.URL, changes
+}
+
+// CreateOrUpdateRelease creates the release, or updates the existing release of the same tag when it does not
+// match. The changes made to an existing release are returned, they are empty if it already matched.
+// An empty Body leaves the body of an existing release untouched. A release is never unmarked as
+// the latest release, GitHub does it once another release is marked as the latest.
+func CreateOrUpdateRelease(repo string, release Release) (Release, []string) {
+	c := getClient()
+
+	created, err := c.CreateRelease(repo, release)
+	if err == nil {
+		return created, nil
+	}
+
+	if !errors.Is(err, ErrAlreadyExists) {
+		utils.BailOut(err, "failed to create the release %s", release.Tag)
 	}
 
-	if latest {
-		args = append(args, "--latest=true")
-	} else {
-		args = append(args, "--latest=false")
+	existing, err := c.GetReleaseByTag(repo, release.Tag)
+	if err != nil {
+		utils.BailOut(err, "failed to get the existing release %s", release.Tag)
 	}
 
-	if prerelease {
-		args = append(args, "--prerelease")
+	latest, err := c.GetLatestRelease(repo)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		utils.BailOut(err, "failed to get the latest release of %s", repo)
 	}
 
-	args = append(args, tag)
+	existing.Latest = latest.ID != 0 && latest.ID == existing.ID
 
-	stdOut, err := execGhWithError(args...)
+	update, changes := releaseUpdate(existing, release)
+	if len(changes) == 0 {
+		return existing, nil
+	}
+
+	updated, err := c.UpdateRelease(repo, existing.ID, update)
 	if err != nil {
-		if strings.Contains(err.Error(), "already exists") {
-			return fmt.Sprintf("https://github.com/%s/releases/tag/%s", repo, tag)
+		utils.BailOut(err, "failed to update the release %s", release.Tag)
+	}
+
+	return updated, changes
+}
+
+// releaseUpdate returns the existing release modified to match the expected one, and the list of changes.
+func releaseUpdate(existing, expected Release) (Release, []string) {
+	update := existing
+	update.Latest = false
+
+	var changes []string
+
+	if expected.Name != existing.Name {
+		update.Name = expected.Name
+		changes = append(changes, fmt.Sprintf("title: %q -> %q", existing.Name, expected.Name))
+	}
+
+	if expected.Target != "" && expected.Target != existing.Target {
+		update.Target = expected.Target
+		changes = append(changes, fmt.Sprintf("target: %s -> %s", existing.Target, expected.Target))
+	}
+
+	if expected.Body != "" && expected.Body != existing.Body {
+		update.Body = expected.Body
+		changes = append(changes, "body refreshed from the release notes")
+	}
+
+	if expected.Prerelease != existing.Prerelease {
+		update.Prerelease = expected.Prerelease
+		changes = append(changes, fmt.Sprintf("prerelease: %t -> %t", existing.Prerelease, expected.Prerelease))
+	}
+
+	if expected.Latest && !existing.Latest && !expected.Prerelease {
+		update.Latest = true
+		changes = append(changes, "marked as the latest release")
+	}
+
+	return update, changes
+}
+
+// UploadReleaseAssets attaches the local files to the release of the tag, under their base name.
+// An asset with the same name and content is kept as is, one with a different content is replaced.
+// The returned list tells what was done for each file.
+func UploadReleaseAssets(repo, tag string, paths []string) []string {
+	c := getClient()
+
+	release, err := c.GetReleaseByTag(repo, tag)
+	if err != nil {
+		utils.BailOut(err, "failed to get the release %s", tag)
+	}
+
+	assets, err := c.ListReleaseAssets(repo, release.ID)
+	if err != nil {
+		utils.BailOut(err, "failed to list the assets of the release %s", tag)
+	}
+
+	var done []string
+
+	for _, p := range paths {
+		data, err := os.ReadFile(p)
+		if err != nil {
+			utils.BailOut(err, "failed to read the release asset %s", p)
+		}
+
+		name := filepath.Base(p)
+
+		idx := slices.IndexFunc(assets, func(a ReleaseAsset) bool { return a.Name == name })
+		if idx >= 0 {
+			if sameAsset(c, repo, assets[idx], data) {
+				done = append(done, fmt.Sprintf("%s: already uploaded", name))
+				continue
+			}
+
+			if err := c.DeleteReleaseAsset(repo, assets[idx].ID); err != nil {
+				utils.BailOut(err, "failed to delete the outdated release asset %s", name)
+			}
+		}
+
+		asset, err := c.UploadReleaseAsset(release, name, data)
+		if err != nil {
+			utils.BailOut(err, "failed to upload the release asset %s", name)
 		}
 
-		utils.BailOutE(err)
+		if idx >= 0 {
+			assets[idx] = asset
+			done = append(done, fmt.Sprintf("%s: replaced", name))
+		} else {
+			assets = append(assets, asset)
+			done = append(done, fmt.Sprintf("%s: uploaded", name))
+		}
+	}
+
+	return done
+}
+
+// sameAsset compares the digest of the asset with the local file. Assets uploaded before GitHub
+// started computing digests have none, they are downloaded to compare their content.
+func sameAsset(c Client, repo string, asset ReleaseAsset, data []byte) bool {
+	if asset.Size != int64(len(data)) {
+		return false
+	}
+
+	if asset.Digest != "" {
+		return asset.Digest == fmt.Sprintf("sha256:%x", sha256.Sum256(data))
+	}
+
+	content, err := c.DownloadReleaseAsset(repo, asset.ID)
+	if err != nil {
+		utils.BailOut(err, "failed to download the release asset %s", asset.Name)
+	}
+
+	return bytes.Equal(content, data)
+}
+
+// CreateRelease creates a release for an existing tag, the tag is never created by this call.
+func (c *restClient) CreateRelease(repo string, release Release) (Release, error) {
+	if _, err := c.do(http.MethodGet, repoPath(repo, "git/ref/tags", url.PathEscape(release.Tag)), nil, nil); err != nil {
+		return Release{}, fmt.Errorf("tag %s not found on %s: %w", release.Tag, repo, err)
+	}
+
+	req := map[string]any{
+		"tag_name":               release.Tag,
+		"target_commitish":       release.Target,
+		"name":                   release.Name,
+		"body":                   release.Body,
+		"prerelease":             release.Prerelease,
+		"make_latest":            fmt.Sprintf("%t", release.Latest),
+		"generate_release_notes": release.Body == "",
+	}
+
+	var created Release
+
+	_, err := c.do(http.MethodPost, repoPath(repo, "releases"), req, &created)
+	created.Latest = release.Latest
+
+	return created, err
+}
+
+func (c *restClient) GetReleaseByTag(repo, tag string) (Release, error) {
+	var release Release
+
+	_, err := c.do(http.MethodGet, repoPath(repo, "releases/tags", url.PathEscape(tag)), nil, &release)
+
+	return release, err
+}
+
+func (c *restClient) GetLatestRelease(repo string) (Release, error) {
+	var release Release
+
+	_, err := c.do(http.MethodGet, repoPath(repo, "releases/latest"), nil, &release)
+	release.Latest = err == nil
+
+	return release, err
+}
+
+// UpdateRelease edits the title, target, body and flags of the release. The release is only
+// marked as the latest one when release.Latest is set.
+func (c *restClient) UpdateRelease(repo string, id int64, release Release) (Release, error) {
+	req := map[string]any{
+		"target_commitish": release.Target,
+		"name":             release.Name,
+		"body":             release.Body,
+		"prerelease":       release.Prerelease,
+	}
+
+	if release.Latest {
+		req["make_latest"] = "true"
 	}
 
-	return strings.ReplaceAll(stdOut, "\n", "")
+	var updated Release
+
+	_, err := c.do(http.MethodPatch, repoPath(repo, "releases", strconv.FormatInt(id, 10)), req, &updated)
+	updated.Latest = release.Latest
+
+	return updated, err
+}
+
+func (c *restClient) ListReleaseAssets(repo string, id int64) ([]ReleaseAsset, error) {
+	return listAll[ReleaseAsset](c, repoPath(repo, "releases", strconv.FormatInt(id, 10), "assets"), url.Values{}, NoLimit)
+}
+
+// UploadReleaseAsset uploads the asset to the upload URL of the release, which is a URI template:
+// https://uploads.github.com/repos/<repo>/releases/<id>/assets{?name,label}.
+func (c *restClient) UploadReleaseAsset(release Release, name string, data []byte) (ReleaseAsset, error) {
+	uploadURL, _, _ := strings.Cut(release.UploadURL, "{")
+
+	contentType := mime.TypeByExtension(filepath.Ext(name))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	var asset ReleaseAsset
+
+	_, err := c.do(http.MethodPost, uploadURL+"?"+url.Values{"name": {name}}.Encode(), rawBody{contentType: contentType, data: data}, &asset)
+
+	return asset, err
+}
+
+// DownloadReleaseAsset returns the content of the asset, which the API serves instead of its
+// metadata when asked for application/octet-stream.
+func (c *restClient) DownloadReleaseAsset(repo string, id int64) ([]byte, error) {
+	var content binaryContent
+
+	_, err := c.do(http.MethodGet, repoPath(repo, "releases/assets", strconv.FormatInt(id, 10)), nil, &content)
+
+	return content, err
+}
+
+func (c *restClient) DeleteReleaseAsset(repo string, id int64) error {
+	_, err := c.do(http.MethodDelete, repoPath(repo, "releases/assets", strconv.FormatInt(id, 10)), nil, nil)
+
+	return err
 }
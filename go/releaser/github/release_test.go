@@ -0,0 +1,139 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/vitessio/vitess-releaser/go/releaser/github"
+)
+
+func TestCreateOrUpdateRelease(t *testing.T) {
+	s := newServer(t)
+	c := s.Client()
+
+	s.AddTag(testRepo, "v21.0.0", "abc")
+	s.AddTag(testRepo, "v21.0.1", "def")
+
+	release := github.Release{Tag: "v21.0.0", Name: "Vitess v21.0.0", Body: "notes", Latest: true}
+
+	created, changes := github.CreateOrUpdateRelease(testRepo, release)
+	if created.ID == 0 || changes != nil {
+		t.Fatalf("expected the release to be created, got %+v and %q", created, changes)
+	}
+
+	if latest := s.LatestRelease(testRepo); latest != "v21.0.0" {
+		t.Fatalf("expected v21.0.0 to be the latest release, got %q", latest)
+	}
+
+	// Running it again is a no-op
+	if _, changes := github.CreateOrUpdateRelease(testRepo, release); len(changes) != 0 {
+		t.Fatalf("expected no change, got %q", changes)
+	}
+
+	if n := len(s.Releases(testRepo)); n != 1 {
+		t.Fatalf("expected a single release, got %d", n)
+	}
+
+	// An existing release is updated to match
+	release.Body = "fixed notes"
+	release.Prerelease = true
+
+	updated, changes := github.CreateOrUpdateRelease(testRepo, release)
+	if want := []string{"body refreshed from the release notes", "prerelease: false -> true"}; !slices.Equal(changes, want) {
+		t.Fatalf("expected the changes %q, got %q", want, changes)
+	}
+
+	if updated.Body != "fixed notes" || !updated.Prerelease {
+		t.Fatalf("unexpected release %+v", updated)
+	}
+
+	// Another release marked as the latest one takes over
+	github.CreateOrUpdateRelease(testRepo, github.Release{Tag: "v21.0.1", Name: "Vitess v21.0.1", Latest: true})
+
+	if latest := s.LatestRelease(testRepo); latest != "v21.0.1" {
+		t.Fatalf("expected v21.0.1 to be the latest release, got %q", latest)
+	}
+
+	// The tag is never created
+	_, err := c.CreateRelease(testRepo, github.Release{Tag: "v22.0.0"})
+	if !errors.Is(err, github.ErrNotFound) {
+		t.Fatalf("expected the release of a missing tag to fail with ErrNotFound, got %v", err)
+	}
+}
+
+func TestUploadReleaseAssets(t *testing.T) {
+	s := newServer(t)
+
+	s.AddTag(testRepo, "v21.0.0", "abc")
+	github.CreateOrUpdateRelease(testRepo, github.Release{Tag: "v21.0.0", Name: "Vitess v21.0.0"})
+
+	dir := t.TempDir()
+	checksums := filepath.Join(dir, "checksums.txt")
+	sbom := filepath.Join(dir, "sbom.json")
+
+	writeFile := func(path, content string) {
+		t.Helper()
+
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	writeFile(checksums, "aaaa")
+	writeFile(sbom, "{}")
+
+	upload := func(want ...string) {
+		t.Helper()
+
+		if done := github.UploadReleaseAssets(testRepo, "v21.0.0", []string{checksums, sbom}); !slices.Equal(done, want) {
+			t.Fatalf("expected %q, got %q", want, done)
+		}
+	}
+
+	upload("checksums.txt: uploaded", "sbom.json: uploaded")
+	upload("checksums.txt: already uploaded", "sbom.json: already uploaded")
+
+	// Same size, different content
+	writeFile(checksums, "bbbb")
+	upload("checksums.txt: replaced", "sbom.json: already uploaded")
+
+	assets := s.ReleaseAssets(testRepo, "v21.0.0")
+	if len(assets) != 2 {
+		t.Fatalf("expected 2 assets, got %+v", assets)
+	}
+
+	// The assets without a digest are downloaded to compare their content
+	s.ClearAssetDigests(testRepo, "v21.0.0")
+	writeFile(sbom, "[]")
+	upload("checksums.txt: already uploaded", "sbom.json: replaced")
+
+	var downloads int
+	for _, a := range assets {
+		downloads += s.Requests(http.MethodGet, fmt.Sprintf("/repos/%s/releases/assets/%d", testRepo, a.ID))
+	}
+
+	if downloads != 2 {
+		t.Fatalf("expected the 2 assets without a digest to be downloaded, got %d downloads", downloads)
+	}
+}
@@ -0,0 +1,204 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RetryPolicy controls how failed API calls are retried.
+//
+// Server errors and network errors are only retried for idempotent requests, as a POST
+// might have been processed before failing. Requests rejected by a rate limit were not
+// processed and are always retried, waiting for as long as GitHub asks, up to MaxRateLimitWait.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one.
+	MaxAttempts int
+
+	// BaseDelay is the delay before the first retry, doubled on every following retry up to MaxDelay.
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+
+	// MaxRateLimitWait is the longest we are willing to wait for a rate limit to reset.
+	MaxRateLimitWait time.Duration
+}
+
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:      5,
+	BaseDelay:        time.Second,
+	MaxDelay:         30 * time.Second,
+	MaxRateLimitWait: 15 * time.Minute,
+}
+
+// shouldRetry returns how long to wait before retrying a request that failed with err,
+// and whether it should be retried at all.
+func (p RetryPolicy) shouldRetry(attempt int, idempotent bool, err error) (time.Duration, bool) {
+	if err == nil || attempt >= p.MaxAttempts {
+		return 0, false
+	}
+
+	backoff := p.backoff(attempt)
+
+	var apiErr *APIError
+	var netErr *networkError
+
+	switch {
+	case errors.As(err, &apiErr) && apiErr.RateLimited:
+		wait := max(apiErr.RetryAfter, backoff)
+		if wait > p.MaxRateLimitWait {
+			return 0, false
+		}
+		return wait, true
+	case errors.As(err, &apiErr):
+		return backoff, idempotent && apiErr.StatusCode >= http.StatusInternalServerError
+	case errors.As(err, &netErr):
+		return backoff, idempotent
+	}
+
+	return 0, false
+}
+
+// backoff returns the exponential delay for the given attempt, with up to 50% of jitter.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	d := p.BaseDelay
+	for i := 1; i < attempt && d < p.MaxDelay; i++ {
+		d *= 2
+	}
+
+	d = min(d, p.MaxDelay)
+	if d <= 0 {
+		return 0
+	}
+
+	return d/2 + rand.N(d/2+1)
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete, http.MethodPatch:
+		// The PATCH requests we send set absolute values, sending them twice is harmless.
+		return true
+	}
+
+	return false
+}
+
+// rateLimitFromResponse tells whether resp was rejected by a rate limit and how long to wait before trying again.
+func rateLimitFromResponse(resp *http.Response, message string) (bool, time.Duration) {
+	h := resp.Header
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+	case resp.StatusCode != http.StatusForbidden:
+		return false, 0
+	case h.Get("Retry-After") != "", h.Get("X-RateLimit-Remaining") == "0":
+	case strings.Contains(strings.ToLower(message), "rate limit"):
+	default:
+		return false, 0
+	}
+
+	if s, err := strconv.Atoi(h.Get("Retry-After")); err == nil {
+		return true, time.Duration(s) * time.Second
+	}
+
+	if h.Get("X-RateLimit-Remaining") == "0" {
+		if reset, err := strconv.ParseInt(h.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+			return true, max(time.Until(time.Unix(reset, 0)), 0)
+		}
+	}
+
+	return true, 0
+}
+
+// RateLimit is the state of one of the GitHub API rate limits, as of the last response.
+type RateLimit struct {
+	Resource  string
+	Limit     int
+	Remaining int
+	Reset     time.Time
+}
+
+var (
+	rateLimitsMu sync.Mutex
+	rateLimits   = map[string]RateLimit{}
+)
+
+func recordRateLimit(h http.Header) {
+	limit, err := strconv.Atoi(h.Get("X-RateLimit-Limit"))
+	if err != nil {
+		return
+	}
+
+	remaining, _ := strconv.Atoi(h.Get("X-RateLimit-Remaining"))
+	reset, _ := strconv.ParseInt(h.Get("X-RateLimit-Reset"), 10, 64)
+
+	resource := h.Get("X-RateLimit-Resource")
+	if resource == "" {
+		resource = "core"
+	}
+
+	rateLimitsMu.Lock()
+	defer rateLimitsMu.Unlock()
+
+	rateLimits[resource] = RateLimit{
+		Resource:  resource,
+		Limit:     limit,
+		Remaining: remaining,
+		Reset:     time.Unix(reset, 0),
+	}
+}
+
+// RateLimitStatus returns the rate limits seen so far, sorted by resource.
+func RateLimitStatus() []RateLimit {
+	rateLimitsMu.Lock()
+	defer rateLimitsMu.Unlock()
+
+	limits := make([]RateLimit, 0, len(rateLimits))
+	for _, rl := range rateLimits {
+		limits = append(limits, rl)
+	}
+
+	sort.Slice(limits, func(i, j int) bool {
+		return limits[i].Resource < limits[j].Resource
+	})
+
+	return limits
+}
+
+// RateLimitSummary returns a one line summary of the remaining API budget,
+// or an empty string if no call was made yet.
+func RateLimitSummary() string {
+	limits := RateLimitStatus()
+	if len(limits) == 0 {
+		return ""
+	}
+
+	parts := make([]string, 0, len(limits))
+	for _, rl := range limits {
+		parts = append(parts, fmt.Sprintf("%s %d/%d", rl.Resource, rl.Remaining, rl.Limit))
+	}
+
+	return "GitHub API: " + strings.Join(parts, ", ")
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/vitessio/vitess-releaser/go/releaser/github"
+	"github.com/vitessio/vitess-releaser/go/releaser/github/githubtest"
+)
+
+func TestRetry(t *testing.T) {
+	s := newServer(t)
+	c := s.Client()
+
+	nb := s.AddIssue(testRepo, github.Issue{Title: "Release of v21.0.0"})
+	path := fmt.Sprintf("/repos/%s/issues/%d", testRepo, nb)
+
+	// Server errors on idempotent requests are retried
+	s.Fail(githubtest.Failure{Method: http.MethodGet, Path: path, Status: http.StatusBadGateway, Count: 2})
+
+	issue, err := c.GetIssue(testRepo, nb)
+	if err != nil {
+		t.Fatalf("expected the request to succeed once retried, got %v", err)
+	}
+
+	if issue.Title != "Release of v21.0.0" {
+		t.Fatalf("unexpected issue %+v", issue)
+	}
+
+	if n := s.Requests(http.MethodGet, path); n != 3 {
+		t.Fatalf("expected 3 attempts, got %d", n)
+	}
+
+	// Until the policy gives up
+	s.Fail(githubtest.Failure{Method: http.MethodGet, Path: path, Status: http.StatusInternalServerError, Count: githubtest.RetryPolicy.MaxAttempts})
+
+	if _, err := c.GetIssue(testRepo, nb); err == nil {
+		t.Fatal("expected the request to fail once all the attempts failed")
+	}
+
+	if n := s.Requests(http.MethodGet, path); n != 3+githubtest.RetryPolicy.MaxAttempts {
+		t.Fatalf("expected %d attempts, got %d", githubtest.RetryPolicy.MaxAttempts, n-3)
+	}
+
+	// Client errors are not
+	s.Fail(githubtest.Failure{Method: http.MethodPatch, Path: path, Status: http.StatusUnprocessableEntity, Message: "Validation Failed"})
+
+	if _, err := c.UpdateIssueBody(testRepo, nb, "body"); !errors.Is(err, github.ErrValidation) {
+		t.Fatalf("expected ErrValidation, got %v", err)
+	}
+
+	if n := s.Requests(http.MethodPatch, path); n != 1 {
+		t.Fatalf("expected a single attempt for a client error, got %d", n)
+	}
+
+	// Neither are the requests that are not idempotent, they may have been applied
+	commentsPath := path + "/comments"
+	s.Fail(githubtest.Failure{Method: http.MethodPost, Path: commentsPath, Status: http.StatusBadGateway})
+
+	if err := c.CreateComment(testRepo, nb, "comment"); err == nil {
+		t.Fatal("expected the comment to fail")
+	}
+
+	if n := s.Requests(http.MethodPost, commentsPath); n != 1 {
+		t.Fatalf("expected a single attempt for a POST request, got %d", n)
+	}
+
+	// Rate limited requests are retried once the limit resets, whatever the method
+	s.Fail(githubtest.Failure{
+		Method:  http.MethodPost,
+		Path:    commentsPath,
+		Status:  http.StatusForbidden,
+		Message: "You have exceeded a secondary rate limit",
+		Header:  http.Header{"Retry-After": {"0"}},
+	})
+
+	if err := c.CreateComment(testRepo, nb, "comment"); err != nil {
+		t.Fatalf("expected the comment to be posted once the rate limit reset, got %v", err)
+	}
+
+	if comments := s.Comments(testRepo, nb); len(comments) != 1 {
+		t.Fatalf("expected a single comment, got %q", comments)
+	}
+
+	// Unless the limit resets too late
+	s.Fail(githubtest.Failure{Path: "/user", Status: http.StatusForbidden, Message: "API rate limit exceeded", Header: http.Header{"Retry-After": {"3600"}}})
+
+	_, err = c.CurrentUser()
+	if !errors.Is(err, github.ErrRateLimited) || errors.Is(err, github.ErrUnauthorized) {
+		t.Fatalf("expected ErrRateLimited, got %v", err)
+	}
+
+	if n := s.Requests(http.MethodGet, "/user"); n != 1 {
+		t.Fatalf("expected a single attempt when the rate limit resets too late, got %d", n)
+	}
+}
+
+func TestRetryGraphQL(t *testing.T) {
+	s := newServer(t)
+	c := s.Client()
+
+	nb := s.AddPR(testRepo, github.PR{Title: "Release of v21.0.0", Branch: "release-21.0-release", Base: "release-21.0"})
+
+	// Queries are retried
+	s.Fail(githubtest.Failure{Path: "/graphql", Status: http.StatusBadGateway})
+
+	if _, err := c.GetPRStatus(testRepo, nb); err != nil {
+		t.Fatalf("expected the query to succeed once retried, got %v", err)
+	}
+
+	if n := s.Requests(http.MethodPost, "/graphql"); n != 2 {
+		t.Fatalf("expected 2 attempts of the query, got %d", n)
+	}
+
+	// Mutations are not
+	s.Fail(githubtest.Failure{Path: "/graphql", Status: http.StatusBadGateway})
+
+	if err := c.EnableAutoMerge(testRepo, nb, github.MergeMethodSquash); err == nil {
+		t.Fatal("expected the mutation to fail")
+	}
+
+	if n := s.Requests(http.MethodPost, "/graphql"); n != 3 {
+		t.Fatalf("expected a single attempt of the mutation, got %d", n-2)
+	}
+
+	if method := s.AutoMergeMethod(testRepo, nb); method != "" {
+		t.Fatalf("expected auto-merge to be left disabled, got %s", method)
+	}
+}
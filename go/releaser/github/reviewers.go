@@ -0,0 +1,94 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+var (
+	reviewersMu sync.Mutex
+	reviewers   []string
+)
+
+// RequestReviewsFrom sets the users, and the teams with the format org/team, whose review is
+// requested on the Pull Requests created with PR.Create. An empty list disables the requests.
+func RequestReviewsFrom(r []string) {
+	reviewersMu.Lock()
+	defer reviewersMu.Unlock()
+
+	reviewers = slices.Clone(r)
+}
+
+// prReviewers resolves the configured reviewers of a Pull Request created on repo by author. The teams are
+// requested as teams, which keeps their members up to date and lets GitHub apply the team's review assignment.
+// Only the teams of the owner of the repository can be requested, the others are only mentioned. The author,
+// which may be a bot, is left out of the users as GitHub refuses to request their review. The mentions are
+// the handles of the configured users and teams, as they are written in the body of the Pull Request.
+func prReviewers(repo, author string) (users, teams, mentions []string) {
+	reviewersMu.Lock()
+	configured := slices.Clone(reviewers)
+	reviewersMu.Unlock()
+
+	owner, _, _ := strings.Cut(repo, "/")
+
+	for _, r := range configured {
+		org, team, isTeam := strings.Cut(r, "/")
+		if !isTeam {
+			if !strings.EqualFold(r, author) && !slices.Contains(users, r) {
+				mentions = append(mentions, "@"+r)
+				users = append(users, r)
+			}
+
+			continue
+		}
+
+		mentions = append(mentions, "@"+r)
+
+		if strings.EqualFold(org, owner) && !slices.Contains(teams, team) {
+			teams = append(teams, team)
+		}
+	}
+
+	return users, teams, mentions
+}
+
+// reviewersMention mentions the reviewers in the body of a Pull Request, as the review requests
+// are only notified to the users and teams that are allowed to review.
+func reviewersMention(mentions []string) string {
+	if len(mentions) == 0 {
+		return ""
+	}
+
+	return "> Review requested from " + strings.Join(mentions, ", ")
+}
+
+// RequestReviewers requests the review of the users, and of the teams of the owner of the repository, by slug.
+func (c *restClient) RequestReviewers(repo string, nb int, users, teams []string) error {
+	req := map[string]any{
+		"reviewers":      append([]string{}, users...),
+		"team_reviewers": append([]string{}, teams...),
+	}
+
+	_, err := c.do(http.MethodPost, repoPath(repo, "pulls", strconv.Itoa(nb), "requested_reviewers"), req, nil)
+
+	return err
+}
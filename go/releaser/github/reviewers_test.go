@@ -0,0 +1,83 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github_test
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/vitessio/vitess-releaser/go/releaser/github"
+	"github.com/vitessio/vitess-releaser/go/releaser/github/githubtest"
+)
+
+func TestCreatePRReviewers(t *testing.T) {
+	s := newServer(t)
+
+	github.RequestReviewsFrom([]string{"vitessio/release", "dave", s.User, "planetscale/release", "dave"})
+	t.Cleanup(func() { github.RequestReviewsFrom(nil) })
+
+	pr := github.PR{Title: "Release of v21.0.0", Body: "Release", Branch: "release-21.0-release", Base: "release-21.0"}
+
+	nb, _, err := pr.Create("#1", testRepo)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The teams are mentioned by their handle, and the author is left out
+	if want := "> Review requested from @vitessio/release, @dave, @planetscale/release"; !strings.Contains(pr.Body, want) {
+		t.Fatalf("expected the body to contain %q, got %q", want, pr.Body)
+	}
+
+	// The team of the owner is requested as a team, the one of another organization cannot be
+	users, teams := s.RequestedReviewers(testRepo, nb)
+	if !slices.Equal(users, []string{"dave"}) || !slices.Equal(teams, []string{"release"}) {
+		t.Fatalf("expected the review of dave and the release team, got %q and the teams %q", users, teams)
+	}
+}
+
+func TestCreatePRReviewersFailure(t *testing.T) {
+	s := newServer(t)
+
+	github.RequestReviewsFrom([]string{"outsider"})
+	t.Cleanup(func() { github.RequestReviewsFrom(nil) })
+
+	s.Fail(githubtest.Failure{
+		Method:  http.MethodPost,
+		Path:    fmt.Sprintf("/repos/%s/pulls/1/requested_reviewers", testRepo),
+		Status:  http.StatusUnprocessableEntity,
+		Message: "Reviews may only be requested from collaborators",
+	})
+
+	pr := github.PR{Title: "Release of v21.0.0", Body: "Release", Branch: "release-21.0-release", Base: "release-21.0"}
+
+	// The Pull Request is still created, the failure is returned as a warning
+	nb, url, err := pr.Create("#1", testRepo)
+	if nb != 1 || url == "" {
+		t.Fatalf("expected the Pull Request to be created, got %d %q", nb, url)
+	}
+
+	if err == nil || !strings.Contains(err.Error(), "collaborators") {
+		t.Fatalf("expected the review request error, got %v", err)
+	}
+
+	if !strings.Contains(pr.Body, "@outsider") {
+		t.Fatalf("expected the reviewer to be mentioned, got %q", pr.Body)
+	}
+}
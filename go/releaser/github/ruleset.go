@@ -0,0 +1,199 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+
+	"github.com/vitessio/vitess-releaser/go/releaser/git"
+	"github.com/vitessio/vitess-releaser/go/releaser/utils"
+)
+
+const (
+	rulesetTargetBranch     = "branch"
+	rulesetSourceRepository = "Repository"
+
+	refAll           = "~ALL"
+	refDefaultBranch = "~DEFAULT_BRANCH"
+)
+
+// Ruleset is a repository ruleset. Only the fields needed to know which branches it targets are kept,
+// Conditions is only set when the ruleset is read on its own, and not listed.
+type Ruleset struct {
+	ID          int64              `json:"id"`
+	Name        string             `json:"name"`
+	Target      string             `json:"target"`
+	SourceType  string             `json:"source_type"`
+	Enforcement string             `json:"enforcement"`
+	Conditions  *RulesetConditions `json:"conditions,omitempty"`
+}
+
+type RulesetConditions struct {
+	RefName RulesetRefName `json:"ref_name"`
+}
+
+// RulesetRefName lists the patterns of the refs targeted by a ruleset, see
+// https://docs.github.com/en/rest/repos/rules#create-a-repository-ruleset.
+type RulesetRefName struct {
+	Include []string `json:"include"`
+	Exclude []string `json:"exclude"`
+}
+
+// AppliesTo tells whether the ruleset targets the branch. Like GitHub, the patterns use the fnmatch
+// syntax, where "*" does not match "/" and "**" matches any number of directories.
+func (rs Ruleset) AppliesTo(branch, defaultBranch string) bool {
+	if rs.Target != rulesetTargetBranch || rs.Conditions == nil {
+		return false
+	}
+
+	ref := "refs/heads/" + branch
+	matches := func(patterns []string) bool {
+		for _, pattern := range patterns {
+			switch pattern {
+			case refAll:
+				return true
+			case refDefaultBranch:
+				if branch == defaultBranch {
+					return true
+				}
+			default:
+				if git.MatchPath(pattern, ref) {
+					return true
+				}
+			}
+		}
+
+		return false
+	}
+
+	return matches(rs.Conditions.RefName.Include) && !matches(rs.Conditions.RefName.Exclude)
+}
+
+// branchRulesets returns the rulesets of the repository targeting branches, with their conditions.
+// The rulesets inherited from the organization are left out as they cannot be modified from the repository.
+func branchRulesets(repo string) []Ruleset {
+	c := getClient()
+
+	list, err := c.ListRulesets(repo)
+	if err != nil {
+		utils.BailOut(err, "failed to list the rulesets of %s", repo)
+	}
+
+	var rulesets []Ruleset
+
+	for _, rs := range list {
+		if rs.Target != rulesetTargetBranch || rs.SourceType != rulesetSourceRepository {
+			continue
+		}
+
+		full, err := c.GetRuleset(repo, rs.ID)
+		if err != nil {
+			utils.BailOut(err, "failed to get the ruleset %s of %s", rs.Name, repo)
+		}
+
+		rulesets = append(rulesets, full)
+	}
+
+	return rulesets
+}
+
+func defaultBranch(repo string) string {
+	branch, err := getClient().GetDefaultBranch(repo)
+	if err != nil {
+		utils.BailOut(err, "failed to get the default branch of %s", repo)
+	}
+
+	return branch
+}
+
+// copyRulesets makes the rulesets targeting the source branch target the destination branch too.
+func copyRulesets(repo, source, destination string) {
+	def := defaultBranch(repo)
+	ref := "refs/heads/" + destination
+
+	for _, rs := range branchRulesets(repo) {
+		if !rs.AppliesTo(source, def) || rs.AppliesTo(destination, def) {
+			continue
+		}
+
+		conditions := *rs.Conditions
+		conditions.RefName.Include = append(slices.Clone(conditions.RefName.Include), ref)
+		conditions.RefName.Exclude = slices.DeleteFunc(slices.Clone(conditions.RefName.Exclude), func(pattern string) bool {
+			return pattern == ref
+		})
+
+		err := getClient().UpdateRulesetConditions(repo, rs.ID, conditions)
+		if err != nil {
+			utils.BailOut(err, "failed to add %s to the targets of the ruleset %s", destination, rs.Name)
+		}
+	}
+}
+
+// diffRulesets lists the rulesets that target only one of the two branches.
+func diffRulesets(repo, source, destination string) []string {
+	def := defaultBranch(repo)
+
+	var diff []string
+
+	for _, rs := range branchRulesets(repo) {
+		onSource, onDestination := rs.AppliesTo(source, def), rs.AppliesTo(destination, def)
+
+		switch {
+		case onSource && !onDestination:
+			diff = append(diff, fmt.Sprintf("ruleset %q: only targets %s", rs.Name, source))
+		case !onSource && onDestination:
+			diff = append(diff, fmt.Sprintf("ruleset %q: only targets %s", rs.Name, destination))
+		}
+	}
+
+	return diff
+}
+
+func (c *restClient) ListRulesets(repo string) ([]Ruleset, error) {
+	return listAll[Ruleset](c, repoPath(repo, "rulesets"), url.Values{}, NoLimit)
+}
+
+func (c *restClient) GetRuleset(repo string, id int64) (Ruleset, error) {
+	var rs Ruleset
+
+	_, err := c.do(http.MethodGet, repoPath(repo, "rulesets", strconv.FormatInt(id, 10)), nil, &rs)
+
+	return rs, err
+}
+
+// UpdateRulesetConditions replaces the conditions of the ruleset, its rules are left untouched.
+func (c *restClient) UpdateRulesetConditions(repo string, id int64, conditions RulesetConditions) error {
+	body := map[string]any{"conditions": conditions}
+
+	_, err := c.do(http.MethodPut, repoPath(repo, "rulesets", strconv.FormatInt(id, 10)), body, nil)
+
+	return err
+}
+
+func (c *restClient) GetDefaultBranch(repo string) (string, error) {
+	var r struct {
+		DefaultBranch string `json:"default_branch"`
+	}
+
+	_, err := c.do(http.MethodGet, "repos/"+repo, nil, &r)
+
+	return r.DefaultBranch, err
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github_test
+
+import (
+	"testing"
+
+	"github.com/vitessio/vitess-releaser/go/releaser/github"
+)
+
+func TestRulesetAppliesTo(t *testing.T) {
+	ruleset := func(include, exclude []string) github.Ruleset {
+		return github.Ruleset{Target: "branch", Conditions: &github.RulesetConditions{RefName: github.RulesetRefName{Include: include, Exclude: exclude}}}
+	}
+
+	tcs := []struct {
+		name             string
+		include, exclude []string
+		branch           string
+		applies          bool
+	}{
+		{name: "exact branch", include: []string{"refs/heads/release-21.0"}, branch: "release-21.0", applies: true},
+		{name: "star", include: []string{"refs/heads/release-*"}, branch: "release-21.0", applies: true},
+		{name: "star does not cross directories", include: []string{"refs/heads/release-*"}, branch: "release-21.0/backports"},
+		{name: "double star", include: []string{"refs/heads/**"}, branch: "release-21.0/backports", applies: true},
+		{name: "double star in the middle", include: []string{"refs/heads/**/release-*"}, branch: "team/release-21.0", applies: true},
+		{name: "all branches", include: []string{"~ALL"}, branch: "release-21.0", applies: true},
+		{name: "default branch", include: []string{"~DEFAULT_BRANCH"}, branch: "main", applies: true},
+		{name: "not the default branch", include: []string{"~DEFAULT_BRANCH"}, branch: "release-21.0"},
+		{name: "excluded", include: []string{"refs/heads/**"}, exclude: []string{"refs/heads/release-*"}, branch: "release-21.0"},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := ruleset(tc.include, tc.exclude).AppliesTo(tc.branch, "main"); got != tc.applies {
+				t.Fatalf("expected the ruleset to apply to %s: %t", tc.branch, tc.applies)
+			}
+		})
+	}
+
+	if (github.Ruleset{Target: "tag", Conditions: &github.RulesetConditions{RefName: github.RulesetRefName{Include: []string{"~ALL"}}}}).AppliesTo("main", "main") {
+		t.Fatal("expected a tag ruleset not to apply to branches")
+	}
+}
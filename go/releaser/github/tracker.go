@@ -0,0 +1,224 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/vitessio/vitess-releaser/go/releaser/utils"
+)
+
+// The outcomes of the items tracked by the release issue once they are not pending anymore.
+const (
+	OutcomeMerged     = "merged"
+	OutcomeClosed     = "closed"
+	OutcomeUnlabelled = "unlabelled"
+)
+
+// TrackedItem is a backport Pull Request or a release blocker, issue or Pull Request, tracked by the release issue.
+type TrackedItem struct {
+	Number    int
+	IsPR      bool
+	Title     string
+	URL       string
+	Author    Author
+	CreatedAt time.Time
+	Labels    []Label
+
+	// State is OPEN, CLOSED or MERGED.
+	State string
+
+	// The fields below only apply to Pull Requests.
+	Base string
+
+	// ReviewDecision is APPROVED, CHANGES_REQUESTED or REVIEW_REQUIRED, empty if no review is required.
+	ReviewDecision string
+
+	// Checks is the combined state of the checks of the last commit: SUCCESS, PENDING, FAILURE,
+	// ERROR or EXPECTED, empty if there are no checks.
+	Checks string
+
+	// Mergeable is MERGEABLE, CONFLICTING or UNKNOWN while GitHub computes it.
+	Mergeable string
+}
+
+// Outcome returns how an item that is not pending anymore got resolved: merged, closed, or
+// unlabelled when it is still open but is not a backport or a release blocker anymore.
+func (t TrackedItem) Outcome() string {
+	switch t.State {
+	case "MERGED":
+		return OutcomeMerged
+	case "CLOSED":
+		return OutcomeClosed
+	default:
+		return OutcomeUnlabelled
+	}
+}
+
+// ItemNumber returns the number of an issue or a Pull Request from its markdown link, i.e. #123, or from its URL.
+// It returns false if the text is neither of them.
+func ItemNumber(link string) (int, bool) {
+	link = strings.TrimPrefix(link[strings.LastIndex(link, "/")+1:], "#")
+
+	nb, err := strconv.Atoi(link)
+
+	return nb, err == nil && nb > 0
+}
+
+// GetTrackedItems returns the tracked items with the given numbers, in the same order. Unlike most functions of this
+// package it does not bail out on errors, as it is used to display the items in the TUI: the items that could not be
+// read are left out and their errors are returned as a *BulkError.
+func GetTrackedItems(repo string, numbers []int) ([]TrackedItem, error) {
+	var mu sync.Mutex
+
+	found := make(map[int]TrackedItem, len(numbers))
+
+	err := runBulk(numbers, nil, func(nb int) error {
+		item, err := getClient().GetTrackedItem(repo, nb)
+		if err != nil {
+			return err
+		}
+
+		mu.Lock()
+		found[nb] = item
+		mu.Unlock()
+
+		return nil
+	})
+
+	items := make([]TrackedItem, 0, len(found))
+	for _, nb := range numbers {
+		if item, ok := found[nb]; ok {
+			items = append(items, item)
+		}
+	}
+
+	return items, err
+}
+
+// GetItemOutcomes returns the outcome of the items that are not pending anymore, by number. The items
+// that could not be read have no outcome, so they are looked up again the next time.
+func GetItemOutcomes(repo string, numbers []int) map[int]string {
+	items, err := GetTrackedItems(repo, numbers)
+
+	var bulkErr *BulkError
+	if err != nil && !errors.As(err, &bulkErr) {
+		utils.BailOut(err, "failed to get the state of the items tracked by the release issue")
+	}
+
+	outcomes := make(map[int]string, len(items))
+	for _, item := range items {
+		outcomes[item.Number] = item.Outcome()
+	}
+
+	return outcomes
+}
+
+const trackedItemQuery = `
+query($owner: String!, $name: String!, $number: Int!) {
+  repository(owner: $owner, name: $name) {
+    issueOrPullRequest(number: $number) {
+      __typename
+      ... on Issue {
+        number title url state createdAt
+        author { __typename login }
+        labels(first: 100) { nodes { name color description } }
+      }
+      ... on PullRequest {
+        number title url state createdAt baseRefName reviewDecision mergeable
+        author { __typename login }
+        labels(first: 100) { nodes { name color description } }
+        commits(last: 1) { nodes { commit { statusCheckRollup { state } } } }
+      }
+    }
+  }
+}`
+
+// GetTrackedItem returns the issue or the Pull Request with the given number, along with where it stands.
+func (c *restClient) GetTrackedItem(repo string, nb int) (TrackedItem, error) {
+	owner, name, _ := strings.Cut(repo, "/")
+
+	var data struct {
+		Repository struct {
+			Item *struct {
+				Typename       string    `json:"__typename"`
+				Number         int       `json:"number"`
+				Title          string    `json:"title"`
+				URL            string    `json:"url"`
+				State          string    `json:"state"`
+				CreatedAt      time.Time `json:"createdAt"`
+				BaseRefName    string    `json:"baseRefName"`
+				ReviewDecision string    `json:"reviewDecision"`
+				Mergeable      string    `json:"mergeable"`
+				Author         *struct {
+					Typename string `json:"__typename"`
+					Login    string `json:"login"`
+				} `json:"author"`
+				Labels struct {
+					Nodes []Label `json:"nodes"`
+				} `json:"labels"`
+				Commits struct {
+					Nodes []struct {
+						Commit struct {
+							StatusCheckRollup *struct {
+								State string `json:"state"`
+							} `json:"statusCheckRollup"`
+						} `json:"commit"`
+					} `json:"nodes"`
+				} `json:"commits"`
+			} `json:"issueOrPullRequest"`
+		} `json:"repository"`
+	}
+
+	err := c.graphql(trackedItemQuery, map[string]any{"owner": owner, "name": name, "number": nb}, &data)
+	if err != nil {
+		return TrackedItem{}, err
+	}
+
+	it := data.Repository.Item
+	if it == nil {
+		return TrackedItem{}, ErrNotFound
+	}
+
+	item := TrackedItem{
+		Number:         it.Number,
+		IsPR:           it.Typename == "PullRequest",
+		Title:          it.Title,
+		URL:            it.URL,
+		Author:         Author{Login: "ghost"},
+		CreatedAt:      it.CreatedAt,
+		Labels:         it.Labels.Nodes,
+		State:          it.State,
+		Base:           it.BaseRefName,
+		ReviewDecision: it.ReviewDecision,
+		Mergeable:      it.Mergeable,
+	}
+
+	if it.Author != nil {
+		item.Author = restUser{Login: it.Author.Login, Type: it.Author.Typename}.toAuthor()
+	}
+
+	if commits := it.Commits.Nodes; len(commits) > 0 && commits[0].Commit.StatusCheckRollup != nil {
+		item.Checks = commits[0].Commit.StatusCheckRollup.State
+	}
+
+	return item, nil
+}
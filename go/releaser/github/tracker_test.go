@@ -0,0 +1,57 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github_test
+
+import (
+	"maps"
+	"net/http"
+	"testing"
+
+	"github.com/vitessio/vitess-releaser/go/releaser/github"
+	"github.com/vitessio/vitess-releaser/go/releaser/github/githubtest"
+)
+
+func TestGetItemOutcomes(t *testing.T) {
+	s := newServer(t)
+
+	merged := s.AddPR(testRepo, github.PR{Title: "backport", Branch: "backport", Base: "release-21.0"})
+	s.MergePR(testRepo, merged, "abc")
+
+	closed := s.AddIssue(testRepo, github.Issue{Title: "release blocker", State: "closed"})
+	unlabelled := s.AddPR(testRepo, github.PR{Title: "not a backport anymore", Branch: "feature", Base: "main"})
+
+	want := map[int]string{merged: github.OutcomeMerged, closed: github.OutcomeClosed, unlabelled: github.OutcomeUnlabelled}
+	numbers := []int{merged, closed, unlabelled}
+
+	if got := github.GetItemOutcomes(testRepo, numbers); !maps.Equal(got, want) {
+		t.Fatalf("expected the outcomes %v, got %v", want, got)
+	}
+
+	// An item that cannot be read is left without outcome instead of failing
+	s.Fail(githubtest.Failure{Method: http.MethodPost, Path: "/graphql", Status: http.StatusForbidden, Message: "Resource not accessible"})
+
+	got := github.GetItemOutcomes(testRepo, numbers)
+	if len(got) != len(want)-1 {
+		t.Fatalf("expected one outcome to be missing, got %v", got)
+	}
+
+	for nb, outcome := range got {
+		if want[nb] != outcome {
+			t.Fatalf("expected the outcome %q for %d, got %q", want[nb], nb, outcome)
+		}
+	}
+}
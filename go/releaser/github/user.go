@@ -17,23 +17,42 @@ limitations under the License.
 package github
 
 import (
-	"encoding/json"
+	"net/http"
+	"strings"
 
 	"github.com/vitessio/vitess-releaser/go/releaser/utils"
 )
 
 func CurrentUser() string {
-	exec := execGh("api", "user")
-	x := map[string]any{}
-
-	err := json.Unmarshal([]byte(exec), &x)
+	login, err := getClient().CurrentUser()
 	if err != nil {
-		utils.BailOut(err, "failed to parse the current user, got: %s", exec)
+		utils.BailOut(err, "failed to get the current GitHub user")
 	}
 
-	login, ok := x["login"].(string)
-	if !ok {
-		utils.BailOut(nil, "failed to get login string from GitHub user response")
-	}
 	return login
 }
+
+func (c *restClient) CurrentUser() (string, error) {
+	var user restUser
+
+	if _, err := c.do(http.MethodGet, "user", nil, &user); err != nil {
+		return "", err
+	}
+
+	return user.Login, nil
+}
+
+// restUser is a user, or a bot, as returned by the REST API.
+type restUser struct {
+	Login string `json:"login"`
+	Type  string `json:"type"`
+}
+
+// toAuthor names bots the way gh does: "app/<name>".
+func (u restUser) toAuthor() Author {
+	if u.Type == "Bot" {
+		return Author{Login: "app/" + strings.TrimSuffix(u.Login, "[bot]")}
+	}
+
+	return Author{Login: u.Login}
+}
@@ -18,7 +18,9 @@ package releaser
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
+	"maps"
 	"strconv"
 	"strings"
 	"text/template"
@@ -58,6 +60,26 @@ const (
 const (
 	markdownItemDone = "- [x]"
 
+	// carriedOverPrefix marks the history of an item carried over from previous release issues.
+	carriedOverPrefix = " <sub>carried over from "
+	carriedOverSuffix = "</sub>"
+
+	// outcomePrefix and outcomeSuffix surround the outcome of a backport or a release blocker item, once it is done.
+	outcomePrefix = " _("
+	outcomeSuffix = ")_"
+
+	// projectSyncMarker prefixes the hidden line holding the status of each step
+	// the last time they were mirrored on the project board.
+	projectSyncMarker = "<!-- vitess-releaser-project: "
+
+	// branchProtectionMarker prefixes the hidden line holding the snapshots of the branch
+	// protection rules taken before letting the release team bypass them.
+	branchProtectionMarker = "<!-- vitess-releaser-branch-protection: "
+
+	// freezeNoticesMarker prefixes the hidden line holding the code freeze notice last
+	// posted on each open Pull Request of the release branch.
+	freezeNoticesMarker = "<!-- vitess-releaser-freeze-notices: "
+
 	// Divers.
 	dateItem = "> This release is scheduled for"
 
@@ -108,6 +130,7 @@ const (
 	postSlackAnnouncementItem = "Notify the community on Slack for the new release."
 	twitterItem               = "Twitter announcement."
 	RemoveBypassProtection    = "Remove bypass branch protection rules, if required."
+	cleanupBranchesItem       = "Cleanup the branches generated by vitess-releaser."
 	closeReleaseItem          = "Close this Issue."
 )
 
@@ -119,6 +142,14 @@ type (
 		// 	- GH links:		"#111"
 		//  - HTTP links:	"https://github.com...."
 		URL string
+
+		// History lists the previous releases (i.e. "v21.0.0-RC1") whose release
+		// issue already tracked this item without it being resolved.
+		History []string
+
+		// Outcome is how a backport or a release blocker item got resolved: merged, closed or
+		// unlabelled, see the github.Outcome constants. It is empty while the item is pending.
+		Outcome string
 	}
 
 	ParentOfItems struct {
@@ -132,6 +163,22 @@ type (
 		VtopRelease string
 		GA          bool
 
+		// Related release issues of the same major
+		PreviousRelease RelatedRelease
+		NextRelease     RelatedRelease
+
+		// ProjectSyncedStatus is the status of each step, indexed by step name, as it was
+		// last mirrored on the project board. It is nil if the project board is not used.
+		ProjectSyncedStatus map[string]bool
+
+		// BranchProtectionSnapshots are the branch protection rules to restore once the bypass
+		// is no longer needed, indexed by "repo:branch". See State.BypassBranchProtection.
+		BranchProtectionSnapshots map[string]json.RawMessage
+
+		// FreezeNotices is the code freeze notice, "frozen" or "unfrozen", last posted
+		// on each Pull Request targeting the release branch, indexed by Pull Request number.
+		FreezeNotices map[int]string
+
 		// Prerequisites
 		General                  ParentOfItems
 		SlackPreRequisite        bool
@@ -180,6 +227,7 @@ type (
 		Twitter                bool
 		CloseIssue             bool
 		RemoveBypassProtection bool
+		CleanupBranches        bool
 	}
 )
 
@@ -190,6 +238,14 @@ const (
 > The release of vitess-operator **v{{.VtopRelease}}** is also planned.
 {{- end }}
 > Release team: @vitessio/release
+{{- if or .PreviousRelease.URL .NextRelease.URL }}
+
+> [!TIP]
+> Related releases:
+{{- if .PreviousRelease.URL }} previous [v{{.PreviousRelease.Release}}]({{.PreviousRelease.URL}}){{ end }}
+{{- if and .PreviousRelease.URL .NextRelease.URL }} |{{ end }}
+{{- if .NextRelease.URL }} next [v{{.NextRelease.Release}}]({{.NextRelease.URL}}){{ end }}
+{{- end }}
 
 > [!IMPORTANT]  
 > Please **do not** edit the content of the Issue's body manually.
@@ -206,11 +262,11 @@ const (
 - [{{fmtStatus .CheckSummary}}] Make sure the release notes summary is prepared and clean.
 - Make sure important Pull Requests are merged, list below.
 {{- range $item := .CheckBackport.Items }}
-  - [{{fmtStatus $item.Done}}] {{$item.URL}}
+  - [{{fmtStatus $item.Done}}] {{$item.URL}}{{fmtOutcome $item.Outcome}}{{fmtHistory $item.History}}
 {{- end }}
 - Make sure release blocker items are closed, list below.
 {{- range $item := .ReleaseBlocker.Items }}
-  - [{{fmtStatus $item.Done}}] {{$item.URL}}
+  - [{{fmtStatus $item.Done}}] {{$item.URL}}{{fmtOutcome $item.Outcome}}{{fmtHistory $item.History}}
 {{- end }}
 {{- if .GA }}
 - [{{fmtStatus .DraftBlogPost}}] Draft the release blog post.
@@ -329,7 +385,20 @@ const (
 - [{{fmtStatus .SlackPostRelease}}] Notify the community on Slack for the new release.
 - [{{fmtStatus .Twitter}}] Twitter announcement.
 - [{{fmtStatus .RemoveBypassProtection}}] Remove bypass branch protection rules, if required.
+- [{{fmtStatus .CleanupBranches}}] Cleanup the branches generated by vitess-releaser.
 - [{{fmtStatus .CloseIssue}}] Close this Issue.
+{{- if .ProjectSyncedStatus }}
+
+<!-- vitess-releaser-project: {{fmtJSON .ProjectSyncedStatus}} -->
+{{- end }}
+{{- if .BranchProtectionSnapshots }}
+
+<!-- vitess-releaser-branch-protection: {{fmtJSON .BranchProtectionSnapshots}} -->
+{{- end }}
+{{- if .FreezeNotices }}
+
+<!-- vitess-releaser-freeze-notices: {{fmtJSON .FreezeNotices}} -->
+{{- end }}
 
 `
 )
@@ -378,8 +447,6 @@ func (s *State) LoadIssue() {
 
 	title, body := github.GetIssueTitleAndBody(s.VitessRelease.Repo, s.IssueNbGH)
 
-	lines := strings.Split(body, "\n")
-
 	var newIssue Issue
 
 	// Parse the title of the Issue to determine the RC increment if any
@@ -397,6 +464,27 @@ func (s *State) LoadIssue() {
 	newIssue.DoVtOp = s.VtOpRelease.Release != ""
 	newIssue.VtopRelease = AddRCToReleaseTitle(s.VtOpRelease.Release, newIssue.RC)
 
+	parseIssueBody(body, &newIssue)
+
+	s.Issue = newIssue
+	s.issueBody = body
+	s.LoadRelatedReleaseIssues()
+}
+
+// SyncIssueFromProjectBoard applies the status changes made on the project board to the loaded release issue,
+// and uploads it if any. Reading the board takes several calls, it is thus only done when starting the releaser.
+func (s *State) SyncIssueFromProjectBoard() {
+	if s.SyncFromProjectBoard() {
+		_, fn := s.UploadIssue()
+		fn()
+	}
+}
+
+// parseIssueBody reads the markdown body of a release issue and fills the
+// given Issue with the status and links of each item.
+func parseIssueBody(body string, newIssue *Issue) {
+	lines := strings.Split(body, "\n")
+
 	st := stateReadingItem
 	for i, line := range lines {
 		switch st {
@@ -414,6 +502,27 @@ func (s *State) LoadIssue() {
 			}
 
 			switch {
+			case strings.HasPrefix(line, projectSyncMarker):
+				raw := strings.TrimSuffix(strings.TrimPrefix(line, projectSyncMarker), " -->")
+
+				err := json.Unmarshal([]byte(raw), &newIssue.ProjectSyncedStatus)
+				if err != nil {
+					utils.BailOut(err, "failed to parse the project board status from the release issue body (%s)", raw)
+				}
+			case strings.HasPrefix(line, branchProtectionMarker):
+				raw := strings.TrimSuffix(strings.TrimPrefix(line, branchProtectionMarker), " -->")
+
+				err := json.Unmarshal([]byte(raw), &newIssue.BranchProtectionSnapshots)
+				if err != nil {
+					utils.BailOut(err, "failed to parse the branch protection snapshots from the release issue body (%s)", raw)
+				}
+			case strings.HasPrefix(line, freezeNoticesMarker):
+				raw := strings.TrimSuffix(strings.TrimPrefix(line, freezeNoticesMarker), " -->")
+
+				err := json.Unmarshal([]byte(raw), &newIssue.FreezeNotices)
+				if err != nil {
+					utils.BailOut(err, "failed to parse the code freeze notices from the release issue body (%s)", raw)
+				}
 			case strings.Contains(line, generalPrerequisitesItem) && isNextLineAList(lines, i):
 				st = stateReadingGeneral
 			case strings.Contains(line, draftBlogPostItem):
@@ -568,6 +677,8 @@ func (s *State) LoadIssue() {
 				newIssue.ReleaseArtifacts = strings.HasPrefix(line, markdownItemDone)
 			case strings.Contains(line, RemoveBypassProtection):
 				newIssue.RemoveBypassProtection = strings.HasPrefix(line, markdownItemDone)
+			case strings.Contains(line, cleanupBranchesItem):
+				newIssue.CleanupBranches = strings.HasPrefix(line, markdownItemDone)
 			}
 		case stateReadingGeneral:
 			newIssue.General.Items = append(newIssue.General.Items, handleNewListItem(lines, i, &st))
@@ -613,8 +724,6 @@ func (s *State) LoadIssue() {
 			newIssue.VtopBackToDevMode.URL = handleSingleTextItem(line, &st)
 		}
 	}
-
-	s.Issue = newIssue
 }
 
 func handleNewListItem(lines []string, i int, s *int) ItemWithLink {
@@ -624,6 +733,19 @@ func handleNewListItem(lines []string, i int, s *int) ItemWithLink {
 		URL:  strings.TrimSpace(line[len(markdownItemDone):]),
 	}
 
+	if idx := strings.Index(newItem.URL, carriedOverPrefix); idx != -1 {
+		history := strings.TrimSuffix(newItem.URL[idx+len(carriedOverPrefix):], carriedOverSuffix)
+		newItem.History = strings.Split(history, ", ")
+		newItem.URL = strings.TrimSpace(newItem.URL[:idx])
+	}
+
+	for _, outcome := range []string{github.OutcomeMerged, github.OutcomeClosed, github.OutcomeUnlabelled} {
+		if url, ok := strings.CutSuffix(newItem.URL, outcomePrefix+outcome+outcomeSuffix); ok {
+			newItem.URL, newItem.Outcome = url, outcome
+			break
+		}
+	}
+
 	if i+1 == len(lines) || !strings.HasPrefix(lines[i+1], "  -") {
 		*s = stateReadingItem
 	}
@@ -651,11 +773,31 @@ func (s *State) UploadIssue() (*logging.ProgressLogging, func() string) {
 		TotalSteps: 2,
 	}
 
+	if s.Project.Enabled() {
+		pl.TotalSteps++
+	}
+
 	return pl, func() string {
+		if s.Project.Enabled() {
+			pl.NewStepf("Mirror the release steps on project %s/%d", s.Project.Owner, s.Project.Number)
+
+			// the board only reflects the status of the steps, it is left alone while they do not change
+			if !maps.Equal(s.Issue.ProjectSyncedStatus, s.Issue.stepsStatus()) {
+				s.MirrorOnProjectBoard()
+			}
+		}
+
 		pl.NewStepf("Update Issue #%d on GitHub", s.IssueNbGH)
+
 		body := s.Issue.toString()
+		if body == s.issueBody {
+			pl.NewStepf("Issue unchanged: %s", s.IssueLink)
+			return s.IssueLink
+		}
+
 		issue := github.Issue{Body: body, Number: s.IssueNbGH}
 		link := issue.UpdateBody(s.VitessRelease.Repo)
+		s.issueBody = body
 		pl.NewStepf("Issue updated: %s", link)
 
 		return link
@@ -664,7 +806,11 @@ func (s *State) UploadIssue() (*logging.ProgressLogging, func() string) {
 
 func CreateReleaseIssue(state *State) (*logging.ProgressLogging, func() (int, string)) {
 	pl := &logging.ProgressLogging{
-		TotalSteps: 2,
+		TotalSteps: 3,
+	}
+
+	if state.Project.Enabled() {
+		pl.TotalSteps++
 	}
 
 	return pl, func() (int, string) {
@@ -675,6 +821,10 @@ func CreateReleaseIssue(state *State) (*logging.ProgressLogging, func() (int, st
 			ItemWithLink{URL: "Have `vitessio/vitess` and `planetscale/vitess-operator` cloned in the same parent directory."},
 		)
 
+		pl.NewStepf("Look for related release issues")
+		state.LoadRelatedReleaseIssues()
+		carryOverFromPreviousIssue(state)
+
 		pl.NewStepf("Create Release Issue on GitHub")
 
 		issueTitle := fmt.Sprintf("Release of `v%s`", state.VitessRelease.Release)
@@ -692,10 +842,34 @@ func CreateReleaseIssue(state *State) (*logging.ProgressLogging, func() (int, st
 
 		pl.NewStepf("Issue created: %s", link)
 
+		if state.Project.Enabled() {
+			pl.NewStepf("Mirror the release steps on project %s/%d", state.Project.Owner, state.Project.Number)
+			_, fn := state.UploadIssue()
+			fn()
+		}
+
 		return nb, link
 	}
 }
 
+// carryOverFromPreviousIssue adds the backport and release blocker items that were
+// left unresolved in the previous release issue of the same major to the new issue.
+func carryOverFromPreviousIssue(state *State) {
+	if state.Issue.PreviousRelease.URL == "" {
+		return
+	}
+
+	_, body := github.GetIssueTitleAndBody(state.VitessRelease.Repo, github.URLToNb(state.Issue.PreviousRelease.URL))
+
+	var previous Issue
+
+	parseIssueBody(body, &previous)
+
+	release := state.Issue.PreviousRelease.Release
+	state.Issue.CheckBackport = carryOverUnresolvedItems(release, previous.CheckBackport, state.Issue.CheckBackport)
+	state.Issue.ReleaseBlocker = carryOverUnresolvedItems(release, previous.ReleaseBlocker, state.Issue.ReleaseBlocker)
+}
+
 func (i *Issue) toString() string {
 	tmpl := template.New("release-issue")
 	tmpl = tmpl.Funcs(template.FuncMap{
@@ -706,6 +880,28 @@ func (i *Issue) toString() string {
 		"fmtShortDate": func(d time.Time) string {
 			return d.Format("Mon _2 Jan")
 		},
+		"fmtJSON": func(v any) string {
+			b, err := json.Marshal(v)
+			if err != nil {
+				utils.BailOut(err, "failed to marshal %v", v)
+			}
+
+			return string(b)
+		},
+		"fmtOutcome": func(outcome string) string {
+			if outcome == "" {
+				return ""
+			}
+
+			return outcomePrefix + outcome + outcomeSuffix
+		},
+		"fmtHistory": func(history []string) string {
+			if len(history) == 0 {
+				return ""
+			}
+
+			return carriedOverPrefix + strings.Join(history, ", ") + carriedOverSuffix
+		},
 	})
 
 	parsed, err := tmpl.Parse(releaseIssueTemplate)
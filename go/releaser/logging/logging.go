@@ -17,6 +17,7 @@ limitations under the License.
 package logging
 
 import (
+	"context"
 	"fmt"
 	"slices"
 	"sync"
@@ -26,6 +27,12 @@ type ProgressLogging struct {
 	mu               sync.Mutex
 	Done, TotalSteps int
 	StepsDone        []string
+
+	// status describes the progress of the step or what it is waiting for, while waiting is set the step can be cancelled.
+	status  string
+	waiting bool
+	ctx     context.Context
+	cancel  context.CancelFunc
 }
 
 func (pl *ProgressLogging) GetDone() int {
@@ -48,6 +55,17 @@ func (pl *ProgressLogging) NewStepf(msg string, args ...any) {
 	pl.StepsDone = append(pl.StepsDone, fmt.Sprintf("%d/%d - %s", pl.Done, pl.TotalSteps, msgF))
 }
 
+// Warnf logs a warning as an extra step, for a failure that does not stop the step.
+func (pl *ProgressLogging) Warnf(msg string, args ...any) {
+	pl.mu.Lock()
+	defer pl.mu.Unlock()
+
+	pl.TotalSteps++
+	pl.Done++
+	msgF := fmt.Sprintf(msg, args...)
+	pl.StepsDone = append(pl.StepsDone, fmt.Sprintf("%d/%d - Warning: %s", pl.Done, pl.TotalSteps, msgF))
+}
+
 func (pl *ProgressLogging) GetStepInProgress() []string {
 	pl.mu.Lock()
 	defer pl.mu.Unlock()
@@ -61,3 +79,81 @@ func (pl *ProgressLogging) SetTotalStep(v int) {
 
 	pl.TotalSteps = v
 }
+
+// Context returns the context of the step, it is done once Cancel was called.
+func (pl *ProgressLogging) Context() context.Context {
+	pl.mu.Lock()
+	defer pl.mu.Unlock()
+
+	if pl.ctx == nil {
+		pl.ctx, pl.cancel = context.WithCancel(context.Background())
+	}
+
+	return pl.ctx
+}
+
+// Cancel cancels the context of the step.
+func (pl *ProgressLogging) Cancel() {
+	_ = pl.Context()
+
+	pl.mu.Lock()
+	defer pl.mu.Unlock()
+
+	pl.cancel()
+}
+
+// SetWaiting marks the step as waiting on something external, with the given status.
+// The step can only be cancelled while waiting.
+func (pl *ProgressLogging) SetWaiting(waiting bool, status string) {
+	pl.mu.Lock()
+	defer pl.mu.Unlock()
+
+	pl.waiting = waiting
+	pl.status = status
+}
+
+// SetStatus shows the progress of the current step, i.e. the number of items processed so far,
+// without making the step cancellable.
+func (pl *ProgressLogging) SetStatus(status string) {
+	pl.mu.Lock()
+	defer pl.mu.Unlock()
+
+	pl.status = status
+}
+
+// ItemProgress returns a callback showing, as the status of the step, the progress of an operation
+// made on many items, i.e. "Pull Requests: 42/300, last #123, 1 failed".
+func (pl *ProgressLogging) ItemProgress(items string) func(done, total, nb int, err error) {
+	var failed int
+
+	return func(done, total, nb int, err error) {
+		if err != nil {
+			failed++
+		}
+
+		status := fmt.Sprintf("%s: %d/%d, last #%d", items, done, total, nb)
+		if failed > 0 {
+			status += fmt.Sprintf(", %d failed", failed)
+		}
+
+		if done == total {
+			status = ""
+		}
+
+		pl.SetStatus(status)
+	}
+}
+
+func (pl *ProgressLogging) IsWaiting() bool {
+	pl.mu.Lock()
+	defer pl.mu.Unlock()
+
+	return pl.waiting
+}
+
+func (pl *ProgressLogging) GetStatus() string {
+	pl.mu.Lock()
+	defer pl.mu.Unlock()
+
+	return pl.status
+}
@@ -0,0 +1,228 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package post_release
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/vitessio/vitess-releaser/go/releaser"
+	"github.com/vitessio/vitess-releaser/go/releaser/git"
+	"github.com/vitessio/vitess-releaser/go/releaser/github"
+	"github.com/vitessio/vitess-releaser/go/releaser/logging"
+)
+
+// BranchToCleanup is a branch that is no longer needed once the release is done.
+type BranchToCleanup struct {
+	Repo     string
+	Remote   string
+	Name     string
+	Reason   string
+	Local    bool
+	OnRemote bool
+	IsVtOp   bool
+}
+
+func (b BranchToCleanup) String() string {
+	var where []string
+	if b.Local {
+		where = append(where, "local")
+	}
+
+	if b.OnRemote {
+		where = append(where, b.Remote)
+	}
+
+	return fmt.Sprintf("%s: %s (%s) - %s", b.Repo, b.Name, strings.Join(where, ", "), b.Reason)
+}
+
+// FindBranchesToCleanup lists the branches generated by the tool whose Pull Requests are all merged
+// or closed, and the RC branch once the GA is released. Branches without any Pull Request are kept.
+func FindBranchesToCleanup(state *releaser.State) []BranchToCleanup {
+	branches := findBranchesToCleanup(state.VitessRelease, state.Issue.GA, false)
+
+	if state.Issue.DoVtOp {
+		state.GoToVtOp()
+		defer state.GoToVitess()
+
+		branches = append(branches, findBranchesToCleanup(state.VtOpRelease, false, true)...)
+	}
+
+	return branches
+}
+
+func findBranchesToCleanup(ri releaser.ReleaseInformation, ga, isVtOp bool) []BranchToCleanup {
+	// generated branches are pushed to the push remote, which is the upstream remote when not using a fork
+	onRemote := map[string]bool{}
+	for _, b := range git.ListRemoteBranches(ri.PushRemote) {
+		onRemote[b] = true
+	}
+
+	local := map[string]bool{}
+	for _, b := range git.ListLocalBranches() {
+		local[b] = true
+	}
+
+	var names []string
+
+	for _, m := range []map[string]bool{onRemote, local} {
+		for name := range m {
+			if git.IsGeneratedBranch(name) && !slices.Contains(names, name) {
+				names = append(names, name)
+			}
+		}
+	}
+
+	slices.Sort(names)
+
+	var branches []BranchToCleanup
+
+	for _, name := range names {
+		prStates := github.GetPRStatesForBranch(ri.Repo, ri.HeadRef(name))
+		if len(prStates) == 0 || slices.Contains(prStates, "OPEN") {
+			continue
+		}
+
+		reason := "Pull Request closed"
+		if slices.Contains(prStates, "MERGED") {
+			reason = "Pull Request merged"
+		}
+
+		branches = append(branches, BranchToCleanup{
+			Repo:     ri.Repo,
+			Remote:   ri.PushRemote,
+			Name:     name,
+			Reason:   reason,
+			Local:    local[name],
+			OnRemote: onRemote[name],
+			IsVtOp:   isVtOp,
+		})
+	}
+
+	if ga && ri.BaseReleaseBranch != "" {
+		rcBranch := ri.BaseReleaseBranch + "-rc"
+		rcOnUpstream := git.RemoteBranchExists(ri.Remote, rcBranch)
+
+		if local[rcBranch] || rcOnUpstream {
+			branches = append(branches, BranchToCleanup{
+				Repo:     ri.Repo,
+				Remote:   ri.Remote,
+				Name:     rcBranch,
+				Reason:   "RC branch, no longer needed after the GA",
+				Local:    local[rcBranch],
+				OnRemote: rcOnUpstream,
+				IsVtOp:   isVtOp,
+			})
+		}
+	}
+
+	return branches
+}
+
+// CleanupBranches deletes the given branches locally and on their remote. Failures are
+// reported in the progress logging but do not stop the deletion of the other branches.
+func CleanupBranches(state *releaser.State, branches []BranchToCleanup) (*logging.ProgressLogging, func() string) {
+	pl := &logging.ProgressLogging{
+		TotalSteps: len(branches) + 2,
+	}
+
+	return pl, func() string {
+		var failed int
+
+		for _, vtop := range []bool{false, true} {
+			var selected []BranchToCleanup
+			for _, b := range branches {
+				if b.IsVtOp == vtop {
+					selected = append(selected, b)
+				}
+			}
+
+			// only move to the vitess-operator clone when there is something to delete in it
+			if len(selected) == 0 {
+				continue
+			}
+
+			if vtop && !state.Issue.DoVtOp {
+				pl.TotalSteps -= len(selected)
+
+				continue
+			}
+
+			if vtop {
+				state.GoToVtOp()
+			}
+
+			for _, b := range selected {
+				if errs := deleteBranch(b); len(errs) > 0 {
+					failed++
+
+					pl.NewStepf("Failed to delete %s: %s", b.Name, strings.Join(errs, "; "))
+
+					continue
+				}
+
+				pl.NewStepf("Deleted %s", b)
+			}
+
+			if vtop {
+				state.GoToVitess()
+			}
+		}
+
+		if failed > 0 {
+			pl.NewStepf("%d branch(es) could not be deleted, please delete them manually", failed)
+			pl.TotalSteps--
+
+			return ""
+		}
+
+		state.Issue.CleanupBranches = true
+
+		if state.IssueNbGH == 0 {
+			pl.TotalSteps = pl.Done
+
+			return ""
+		}
+
+		pl.NewStepf("Update Issue %s on GitHub", state.IssueLink)
+		_, fn := state.UploadIssue()
+		issueLink := fn()
+
+		pl.NewStepf("Issue updated, see: %s", issueLink)
+
+		return issueLink
+	}
+}
+
+func deleteBranch(b BranchToCleanup) []string {
+	var errs []string
+
+	if b.Local {
+		if err := git.DeleteLocalBranch(b.Name); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+
+	if b.OnRemote {
+		if err := git.DeleteRemoteBranch(b.Remote, b.Name); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+
+	return errs
+}
@@ -0,0 +1,184 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package post_release
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/vitessio/vitess-releaser/go/releaser"
+	"github.com/vitessio/vitess-releaser/go/releaser/github"
+	"github.com/vitessio/vitess-releaser/go/releaser/logging"
+)
+
+const (
+	// DefaultRetiredLabelPrefix is prepended to the name of the release labels of unsupported branches.
+	DefaultRetiredLabelPrefix = "EOL: "
+
+	// DefaultSupportedReleases is the number of major releases, including the one being released, whose branches are supported.
+	DefaultSupportedReleases = 3
+)
+
+// releaseLabelRegexp matches the labels created for each release branch by code_freeze.CreateNewLabels.
+var releaseLabelRegexp = regexp.MustCompile(`^(Backport to|Release Blocker): release-(\d+)\.0$`)
+
+// ReleaseLabel is a label created for a release branch, along with the open issues and Pull Requests carrying it.
+type ReleaseLabel struct {
+	Name  string
+	Major int
+
+	// Retired is set when the label was already renamed with the retired prefix.
+	Retired bool
+
+	// Supported is false when the branch of the label is no longer supported.
+	Supported bool
+
+	OpenIssues []int
+	OpenPRs    []int
+}
+
+func (l ReleaseLabel) String() string {
+	status := "supported"
+
+	switch {
+	case l.Retired:
+		status = "retired"
+	case !l.Supported:
+		status = "unsupported"
+	}
+
+	return fmt.Sprintf("%s (%s) - %d open issue(s), %d open Pull Request(s)", l.Name, status, len(l.OpenIssues), len(l.OpenPRs))
+}
+
+// OpenItems returns the open issues and Pull Requests carrying the label, as "#nb".
+func (l ReleaseLabel) OpenItems() []string {
+	var items []string
+	for _, nb := range slices.Concat(l.OpenIssues, l.OpenPRs) {
+		items = append(items, fmt.Sprintf("#%d", nb))
+	}
+
+	return items
+}
+
+// ListReleaseLabels lists the release labels of the vitess repository and counts the open issues and Pull Requests
+// carrying them. The labels of the branches older than the last supported majors are unsupported, the labels
+// already renamed with retiredPrefix are listed as retired.
+func ListReleaseLabels(state *releaser.State, retiredPrefix string, supported int) []ReleaseLabel {
+	repo := state.VitessRelease.Repo
+	oldestSupported := state.VitessRelease.MajorReleaseNb - supported + 1
+
+	var labels []ReleaseLabel
+
+	for _, l := range github.ListLabels(repo) {
+		name, retired := strings.CutPrefix(l.Name, retiredPrefix)
+		if retiredPrefix == "" {
+			retired = false
+		}
+
+		m := releaseLabelRegexp.FindStringSubmatch(name)
+		if m == nil {
+			continue
+		}
+
+		major, _ := strconv.Atoi(m[2])
+		labels = append(labels, ReleaseLabel{
+			Name:      l.Name,
+			Major:     major,
+			Retired:   retired,
+			Supported: major >= oldestSupported,
+		})
+	}
+
+	issues, prs := github.ListOpenIssuesAndPRs(repo)
+
+	for i := range labels {
+		for _, issue := range issues {
+			if slices.ContainsFunc(issue.Labels, func(l github.Label) bool { return l.Name == labels[i].Name }) {
+				labels[i].OpenIssues = append(labels[i].OpenIssues, issue.Number)
+			}
+		}
+
+		for _, pr := range prs {
+			if slices.ContainsFunc(pr.Labels, func(l github.Label) bool { return l.Name == labels[i].Name }) {
+				labels[i].OpenPRs = append(labels[i].OpenPRs, pr.Number)
+			}
+		}
+	}
+
+	slices.SortFunc(labels, func(a, b ReleaseLabel) int {
+		if a.Major != b.Major {
+			return b.Major - a.Major
+		}
+
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return labels
+}
+
+// RetireReleaseLabels renames the labels of the unsupported branches with retiredPrefix, this way they are
+// no longer applied by mistake. A warning is logged for every retired label still carried by open items.
+func RetireReleaseLabels(state *releaser.State, labels []ReleaseLabel, retiredPrefix string) (*logging.ProgressLogging, func() string) {
+	pl := &logging.ProgressLogging{}
+
+	for _, l := range labels {
+		if l.Supported {
+			continue
+		}
+
+		if !l.Retired {
+			pl.TotalSteps++
+		}
+
+		if len(l.OpenItems()) > 0 {
+			pl.TotalSteps++
+		}
+	}
+
+	return pl, func() string {
+		var warnings int
+
+		for _, l := range labels {
+			if l.Supported {
+				continue
+			}
+
+			if !l.Retired {
+				newName := retiredPrefix + l.Name
+				pl.NewStepf("Rename '%s' to '%s'", l.Name, newName)
+				github.RenameLabel(state.VitessRelease.Repo, l.Name, newName)
+
+				l.Name = newName
+			}
+
+			if items := l.OpenItems(); len(items) > 0 {
+				warnings++
+
+				pl.NewStepf("Warning: the retired label '%s' is still carried by open items: %s", l.Name, strings.Join(items, ", "))
+			}
+		}
+
+		if warnings > 0 {
+			return fmt.Sprintf("%d retired label(s) still carried by open items", warnings)
+		}
+
+		return ""
+	}
+}
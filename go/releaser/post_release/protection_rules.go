@@ -16,9 +16,33 @@ limitations under the License.
 
 package post_release
 
-func RemoveByPassProtectionRules() []string {
-	return []string{
-		"In branch protection for current branch, confirm 'Do not allow bypassing the above settings' is checked.",
-		"",
+import (
+	"github.com/vitessio/vitess-releaser/go/releaser"
+	"github.com/vitessio/vitess-releaser/go/releaser/logging"
+)
+
+// RemoveBypassProtection restores the branch protection rules that are still bypassed, from the
+// snapshots stored in the release issue, and verifies the restored rules match the snapshots.
+func RemoveBypassProtection(state *releaser.State) (*logging.ProgressLogging, func() string) {
+	pl := &logging.ProgressLogging{
+		TotalSteps: 3,
+	}
+
+	return pl, func() string {
+		if len(state.BypassedBranches()) == 0 {
+			pl.NewStepf("No branch protection rules are bypassed")
+		} else {
+			pl.NewStepf("Restore the branch protection rules of %d branch(es)", len(state.BypassedBranches()))
+			state.RestoreAllBranchProtections(pl)
+		}
+
+		state.Issue.RemoveBypassProtection = true
+
+		pl.NewStepf("Update Issue %s on GitHub", state.IssueLink)
+		_, fn := state.UploadIssue()
+		issueLink := fn()
+		pl.NewStepf("Issue updated, see: %s", issueLink)
+
+		return issueLink
 	}
 }
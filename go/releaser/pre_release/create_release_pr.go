@@ -66,18 +66,25 @@ func CreateReleasePR(state *releaser.State) (*logging.ProgressLogging, func() st
 
 		// setup
 		pl.NewStepf("Fetch from git remote")
-		git.CorrectCleanRepo(state.VitessRelease.Repo)
-		git.ResetHard(state.VitessRelease.Remote, state.VitessRelease.ReleaseBranch)
+		git.CorrectRepo(state.VitessRelease.Repo)
+
+		wt := git.NewWorktree(state.VitessRelease.Remote, state.VitessRelease.ReleaseBranch)
+		defer wt.Remove()
 
 		releasePRName := fmt.Sprintf("[%s] Release of `v%s`", state.VitessRelease.ReleaseBranch, state.VitessRelease.Release)
 
 		// look for existing PRs
 		pl.NewStepf("Look for an existing Release Pull Request named '%s'", releasePRName)
 
-		if _, url = github.FindPR(state.VitessRelease.Repo, releasePRName); url != "" {
+		if nb, foundURL := github.FindPR(state.VitessRelease.Repo, releasePRName); foundURL != "" {
+			url = foundURL
 			pl.TotalSteps = 5 // only 5 total steps in this situation
 			pl.NewStepf("An opened Release Pull Request was found: %s", url)
 
+			if unfreezeBranch {
+				code_freeze.NotifyUnfrozenPRs(pl, state, nb, url)
+			}
+
 			done = true
 
 			return url
@@ -85,7 +92,7 @@ func CreateReleasePR(state *releaser.State) (*logging.ProgressLogging, func() st
 
 		// find new branch to create the release
 		pl.NewStepf("Create temporary branch from %s", state.VitessRelease.ReleaseBranch)
-		newBranchName := git.FindNewGeneratedBranch(state.VitessRelease.Remote, state.VitessRelease.ReleaseBranch, "create-release")
+		newBranchName := git.FindNewGeneratedBranch(state.VitessRelease.Remote, state.VitessRelease.ReleaseBranch, git.BranchCreateRelease)
 
 		// deactivate code freeze
 		if unfreezeBranch {
@@ -94,10 +101,12 @@ func CreateReleasePR(state *releaser.State) (*logging.ProgressLogging, func() st
 
 			pl.NewStepf("Commit unfreezing the branch %s", state.VitessRelease.ReleaseBranch)
 
-			if !git.CommitAll(fmt.Sprintf("Unfreeze branch %s", state.VitessRelease.ReleaseBranch)) {
+			if !git.CommitAll(fmt.Sprintf("Unfreeze branch %s", state.VitessRelease.ReleaseBranch), code_freeze.CodeFreezeFiles) {
 				commitCount++
 
-				git.Push(state.VitessRelease.Remote, newBranchName)
+				if !state.VerifyAndPush(pl, state.VitessRelease.PushRemote, newBranchName) {
+					return ""
+				}
 			}
 		}
 
@@ -106,10 +115,12 @@ func CreateReleasePR(state *releaser.State) (*logging.ProgressLogging, func() st
 
 		pl.NewStepf("Commit the release notes")
 
-		if !git.CommitAll("Addition of release notes") {
+		if !git.CommitAll("Addition of release notes", []string{releaseNotesPathPrefix + "**"}) {
 			commitCount++
 
-			git.Push(state.VitessRelease.Remote, newBranchName)
+			if !state.VerifyAndPush(pl, state.VitessRelease.PushRemote, newBranchName) {
+				return ""
+			}
 		}
 
 		lowerRelease := strings.ToLower(state.VitessRelease.Release)
@@ -125,10 +136,12 @@ func CreateReleasePR(state *releaser.State) (*logging.ProgressLogging, func() st
 
 		pl.NewStepf("Commit the update to the codebase for the v%s release", state.VitessRelease.Release)
 
-		if !git.CommitAll(fmt.Sprintf("Update codebase for the v%s release", state.VitessRelease.Release)) {
+		if !git.CommitAll(fmt.Sprintf("Update codebase for the v%s release", state.VitessRelease.Release), append([]string{filepath.Join(examplesCompose, "**"), filepath.Join(examplesOperator, "**")}, releaser.VersionFiles...)) {
 			commitCount++
 
-			git.Push(state.VitessRelease.Remote, newBranchName)
+			if !state.VerifyAndPush(pl, state.VitessRelease.PushRemote, newBranchName) {
+				return ""
+			}
 		}
 
 		if commitCount == 0 {
@@ -145,12 +158,22 @@ func CreateReleasePR(state *releaser.State) (*logging.ProgressLogging, func() st
 		pr := github.PR{
 			Title:  releasePRName,
 			Body:   fmt.Sprintf("Includes the release notes and release commit for the `v%s` release. Once this PR is merged, we will be able to tag `v%s` on the merge commit.", state.VitessRelease.Release, state.VitessRelease.Release),
-			Branch: newBranchName,
+			Branch: state.VitessRelease.HeadRef(newBranchName),
 			Base:   state.VitessRelease.ReleaseBranch,
 			Labels: []github.Label{{Name: "Component: General"}, {Name: "Type: Release"}, {Name: "Do Not Merge"}},
 		}
-		_, url = pr.Create(state.IssueLink, state.VitessRelease.Repo)
+		var nb int
+
+		var reviewErr error
+		nb, url, reviewErr = pr.Create(state.IssueLink, state.VitessRelease.Repo)
 		pl.NewStepf("Pull Request created %s", url)
+		if reviewErr != nil {
+			pl.Warnf("%s", reviewErr)
+		}
+
+		if unfreezeBranch {
+			code_freeze.NotifyUnfrozenPRs(pl, state, nb, url)
+		}
 
 		done = true
 
@@ -59,19 +59,21 @@ func VtopUpdateGolang(state *releaser.State) (*logging.ProgressLogging, func() s
 		}()
 
 		pl.NewStepf("Fetch from git remote vitess repository")
-		git.CorrectCleanRepo(state.VitessRelease.Repo)
-		git.ResetHard(state.VitessRelease.Remote, state.VitessRelease.ReleaseBranch)
+		git.CorrectRepo(state.VitessRelease.Repo)
+		git.FetchBranches(state.VitessRelease.Remote, state.VitessRelease.ReleaseBranch)
 
 		pl.NewStepf("Get Go version of vitess")
 
-		vitessGoVersion := currentGolangVersionInVitess()
+		vitessGoVersion := currentGolangVersionInVitess(git.ShowFile(state.VitessRelease.Remote, state.VitessRelease.ReleaseBranch, "build.env"))
 
 		state.GoToVtOp()
 		defer state.GoToVitess()
 
 		pl.NewStepf("Fetch from git remote vitess-operator repository")
-		git.CorrectCleanRepo(state.VtOpRelease.Repo)
-		git.ResetHard(state.VtOpRelease.Remote, state.VtOpRelease.ReleaseBranch)
+		git.CorrectRepo(state.VtOpRelease.Repo)
+
+		wt := git.NewWorktree(state.VtOpRelease.Remote, state.VtOpRelease.ReleaseBranch)
+		defer wt.Remove()
 
 		pl.NewStepf("Get Go version of vitess-operator")
 
@@ -111,14 +113,14 @@ func VtopUpdateGolang(state *releaser.State) (*logging.ProgressLogging, func() s
 		}
 
 		pl.NewStepf("Create new branch based on %s/%s", state.VtOpRelease.Remote, state.VtOpRelease.ReleaseBranch)
-		newBranchName := git.FindNewGeneratedBranch(state.VtOpRelease.Remote, state.VtOpRelease.ReleaseBranch, "go-upgrade")
+		newBranchName := git.FindNewGeneratedBranch(state.VtOpRelease.Remote, state.VtOpRelease.ReleaseBranch, git.BranchGoUpgrade)
 
 		pl.NewStepf("Updating the Go version of the operator to %s", vitessGoVersion.String())
 		updateGolangVersionForVtop(vitessGoVersion)
 
 		pl.NewStepf("Commit and push to branch %s", newBranchName)
 
-		if git.CommitAll(fmt.Sprintf("Update Go version to %s", vitessGoVersion.String())) {
+		if git.CommitAll(fmt.Sprintf("Update Go version to %s", vitessGoVersion.String()), vtopGolangFiles) {
 			pl.TotalSteps = 11
 			pl.NewStepf("Nothing to commit, seems like the update is already done")
 
@@ -127,18 +129,25 @@ func VtopUpdateGolang(state *releaser.State) (*logging.ProgressLogging, func() s
 			return ""
 		}
 
-		git.Push(state.VtOpRelease.Remote, newBranchName)
+		if !state.VerifyAndPush(pl, state.VtOpRelease.PushRemote, newBranchName) {
+			return ""
+		}
 
 		pl.NewStepf("Create Pull Request")
 
 		pr := github.PR{
 			Title:  goUpdatePRName,
 			Body:   fmt.Sprintf("This Pull Request updates the Golang version to %s.", vitessGoVersion.String()),
-			Branch: newBranchName,
+			Branch: state.VtOpRelease.HeadRef(newBranchName),
 			Base:   state.VtOpRelease.ReleaseBranch,
 		}
-		_, url = pr.Create(state.IssueLink, state.VtOpRelease.Repo)
+
+		var reviewErr error
+		_, url, reviewErr = pr.Create(state.IssueLink, state.VtOpRelease.Repo)
 		pl.NewStepf("Pull Request created %s", url)
+		if reviewErr != nil {
+			pl.Warnf("%s", reviewErr)
+		}
 
 		done = true
 
@@ -146,6 +155,9 @@ func VtopUpdateGolang(state *releaser.State) (*logging.ProgressLogging, func() s
 	}
 }
 
+// vtopGolangFiles are the files modified by updateGolangVersionForVtop.
+var vtopGolangFiles = []string{"go.mod", "build/Dockerfile.release", ".buildkite/pipeline.yml", ".github/workflows/*.yaml"}
+
 func updateGolangVersionForVtop(targetGoVersion *version.Version) {
 	utils.Exec("sed", "-i.bak", "-E", fmt.Sprintf("s/^go (.*)/go %s/g", targetGoVersion.String()), "go.mod")
 	utils.Exec("rm", "-f", "go.mod.bak")
@@ -187,15 +199,8 @@ func findVtopWorkflowFiles() []string {
 	return files
 }
 
-func currentGolangVersionInVitess() *version.Version {
-	buildFile := "build.env"
-	contentRaw, err := os.ReadFile(buildFile)
-	if err != nil {
-		utils.BailOut(err, "failed to read the file %s", buildFile)
-	}
-
-	content := string(contentRaw)
-
+// currentGolangVersionInVitess parses the minimum Go version out of the content of vitess' build.env file.
+func currentGolangVersionInVitess(content string) *version.Version {
 	versre := regexp.MustCompile(regexpFindGolangVersionInVitess)
 
 	versionStr := versre.FindStringSubmatch(content)
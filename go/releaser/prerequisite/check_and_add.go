@@ -26,37 +26,19 @@ import (
 
 func CheckAndAddPRsIssues(state *releaser.State) (*logging.ProgressLogging, func() string) {
 	pl := &logging.ProgressLogging{
-		TotalSteps: 6,
+		TotalSteps: 4,
 	}
 
 	return pl, func() string {
 		pl.NewStepf("Read Release Issue")
 		state.LoadIssue()
 
-		pl.NewStepf("Check and add Pull Requests")
+		pl.NewStepf("Check and add Pull Requests and Release Blocker items")
 
-		prsOnGH := github.CheckBackportToPRs(state.VitessRelease.Repo, state.VitessRelease.ReleaseBranch)
+		prsOnGH, releaseBlockers := github.CheckPendingItems(state.VitessRelease.Repo, state.VitessRelease.ReleaseBranch, state.VitessRelease.MajorRelease)
 		state.Issue.CheckBackport = addLinksToParentOfItems(state.Issue.CheckBackport, prsOnGH)
-
-		pl.NewStepf("Check and add Release Blocker Issues")
-
-		releaseBlockerIssuesOnGH := github.CheckReleaseBlockerIssues(state.VitessRelease.Repo, state.VitessRelease.MajorRelease)
-
-		pl.NewStepf("Check and add Release Blocker PRs")
-
-		releaseBlockerPRsOnGH := github.CheckReleaseBlockerPRs(state.VitessRelease.Repo, state.VitessRelease.MajorRelease)
-
-		// Merge the two maps together and add them to the issue
-		releaseBlockers := map[string]any{}
-		for key := range releaseBlockerIssuesOnGH {
-			releaseBlockers[key] = nil
-		}
-
-		for key := range releaseBlockerPRsOnGH {
-			releaseBlockers[key] = nil
-		}
-
 		state.Issue.ReleaseBlocker = addLinksToParentOfItems(state.Issue.ReleaseBlocker, releaseBlockers)
+		recordOutcomes(state.VitessRelease.Repo, state.Issue.CheckBackport, state.Issue.ReleaseBlocker)
 
 		pl.NewStepf("Update Issue %s on GitHub", state.IssueLink)
 		_, fn := state.UploadIssue()
@@ -95,6 +77,34 @@ outerPR:
 	return parent
 }
 
+// recordOutcomes records whether the items that are not pending anymore were merged, closed or unlabelled.
+// The outcome of an item is looked up until it could be read once, the items of older release issues included.
+func recordOutcomes(repo string, parents ...releaser.ParentOfItems) {
+	var numbers []int
+
+	for _, parent := range parents {
+		for _, item := range parent.Items {
+			if nb, ok := github.ItemNumber(item.URL); ok && item.Done && item.Outcome == "" {
+				numbers = append(numbers, nb)
+			}
+		}
+	}
+
+	if len(numbers) == 0 {
+		return
+	}
+
+	outcomes := github.GetItemOutcomes(repo, numbers)
+
+	for _, parent := range parents {
+		for i, item := range parent.Items {
+			if nb, ok := github.ItemNumber(item.URL); ok && item.Done && item.Outcome == "" {
+				parent.Items[i].Outcome = outcomes[nb]
+			}
+		}
+	}
+}
+
 func GetCheckAndAddInfoMsg(state *releaser.State) string {
 	nbPRs, releaseBlockerItems := state.Issue.CheckBackport.ItemsLeft(), state.Issue.ReleaseBlocker.ItemsLeft()
 
@@ -0,0 +1,117 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package prerequisite
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"slices"
+	"text/template"
+	"time"
+
+	"github.com/vitessio/vitess-releaser/go/releaser"
+	"github.com/vitessio/vitess-releaser/go/releaser/github"
+	"github.com/vitessio/vitess-releaser/go/releaser/logging"
+	"github.com/vitessio/vitess-releaser/go/releaser/utils"
+)
+
+const reminderTemplate = `Hello @{{.Author}} :wave:
+
+This {{.Kind}} is tracked as {{.Tracked}} of the release of ` + "`v{{.Release}}`" + `, scheduled on {{.Date}}. Could you please take a look and let us know whether it will be ready in time? If it should not be part of ` + "`v{{.Release}}`" + `, please say so on the release issue, {{.IssueLink}}.
+`
+
+type reminder struct {
+	Author    string
+	Kind      string
+	Tracked   string
+	Release   string
+	Date      string
+	IssueLink string
+}
+
+// RemindAuthors comments on the given backports and release blockers to remind their authors of the release date.
+func RemindAuthors(state *releaser.State, items []github.TrackedItem) (*logging.ProgressLogging, func() string) {
+	pl := &logging.ProgressLogging{
+		TotalSteps: 2,
+	}
+
+	return pl, func() string {
+		pl.NewStepf("Remind the authors of %d item(s)", len(items))
+
+		byNumber := make(map[int]github.TrackedItem, len(items))
+		numbers := make([]int, 0, len(items))
+
+		for _, item := range items {
+			byNumber[item.Number] = item
+			numbers = append(numbers, item.Number)
+		}
+
+		t := template.Must(template.New("reminder").Parse(reminderTemplate))
+
+		err := github.CommentOnItems(state.VitessRelease.Repo, numbers, func(nb int) string {
+			item := byNumber[nb]
+
+			r := reminder{
+				Author:    item.Author.Login,
+				Kind:      "issue",
+				Tracked:   "a backport",
+				Release:   state.VitessRelease.Release,
+				Date:      state.Issue.Date.Format(time.DateOnly),
+				IssueLink: state.IssueLink,
+			}
+
+			if item.IsPR {
+				r.Kind = "Pull Request"
+			}
+
+			if isReleaseBlocker(state, nb) {
+				r.Tracked = "a release blocker"
+			}
+
+			b := bytes.NewBufferString("")
+			if err := t.Execute(b, r); err != nil {
+				utils.BailOut(err, "failed to execute the reminder template")
+			}
+
+			return b.String()
+		}, pl.ItemProgress("items"))
+
+		var bulkErr *github.BulkError
+		if err != nil && !errors.As(err, &bulkErr) {
+			utils.BailOut(err, "failed to remind the authors of the pending items")
+		}
+
+		if bulkErr != nil {
+			msg := fmt.Sprintf("Warning: some authors could not be reminded, %s", bulkErr)
+			pl.NewStepf("%s", msg)
+
+			return msg
+		}
+
+		pl.NewStepf("Done")
+
+		return ""
+	}
+}
+
+func isReleaseBlocker(state *releaser.State, nb int) bool {
+	return slices.ContainsFunc(state.Issue.ReleaseBlocker.Items, func(item releaser.ItemWithLink) bool {
+		itemNb, ok := github.ItemNumber(item.URL)
+		return ok && itemNb == nb
+	})
+}
@@ -0,0 +1,299 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package releaser
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vitessio/vitess-releaser/go/releaser/github"
+	"github.com/vitessio/vitess-releaser/go/releaser/steps"
+	"github.com/vitessio/vitess-releaser/go/releaser/utils"
+)
+
+const (
+	projectFieldStatus  = "Status"
+	projectFieldOwner   = "Owner"
+	projectFieldDueDate = "Due date"
+	projectFieldSection = "Section"
+
+	projectStatusTodo = "Todo"
+	projectStatusDone = "Done"
+
+	sectionPrerequisites = "Prerequisites"
+	sectionCodeFreeze    = "Code Freeze"
+	sectionPreRelease    = "Pre-Release"
+	sectionRelease       = "Release"
+	sectionPostRelease   = "Post-Release"
+)
+
+// ProjectInformation identifies the GitHub Projects (v2) board on which the
+// release steps are mirrored. The integration is disabled when Owner is empty.
+type ProjectInformation struct {
+	Owner  string
+	Number int
+}
+
+// ParseProjectFlag parses a project reference with the format "owner/number".
+func ParseProjectFlag(value string) ProjectInformation {
+	if value == "" {
+		return ProjectInformation{}
+	}
+
+	owner, nbStr, found := strings.Cut(value, "/")
+	if !found {
+		utils.BailOut(nil, "the project must be formatted as owner/number, got: %s", value)
+	}
+
+	nb, err := strconv.Atoi(nbStr)
+	if err != nil {
+		utils.BailOut(err, "failed to parse the project number (%s)", nbStr)
+	}
+
+	return ProjectInformation{Owner: owner, Number: nb}
+}
+
+func (p ProjectInformation) Enabled() bool {
+	return p.Owner != ""
+}
+
+type projectStep struct {
+	name    string
+	section string
+	done    projectStatus
+	skip    func(i *Issue) bool
+}
+
+// projectStatus reads and writes the status of a step in the release issue.
+type projectStatus struct {
+	get func(i *Issue) bool
+	set func(i *Issue, done bool)
+}
+
+// flag is the status of a step with its own check box.
+func flag(field func(i *Issue) *bool) projectStatus {
+	return projectStatus{
+		get: func(i *Issue) bool { return *field(i) },
+		set: func(i *Issue, done bool) { *field(i) = done },
+	}
+}
+
+// allItems is the status of a step that is done once all its items are.
+func allItems(list func(i *Issue) *ParentOfItems) projectStatus {
+	return projectStatus{
+		get: func(i *Issue) bool { return list(i).Done() },
+		set: func(i *Issue, done bool) {
+			if done {
+				list(i).MarkAllAsDone()
+			} else {
+				list(i).MarkAllAsNotDone()
+			}
+		},
+	}
+}
+
+func never(*Issue) bool { return false }
+
+func notGA(i *Issue) bool { return !i.GA }
+
+func noVtOp(i *Issue) bool { return !i.DoVtOp }
+
+func noCodeFreeze(i *Issue) bool { return i.RC > 1 || i.GA }
+
+func notRC1(i *Issue) bool { return noCodeFreeze(i) || i.RC != 1 }
+
+// projectSteps lists the steps of the release issue that are mirrored on the project board, that is
+// every step with a check box in the issue. The lists of Pull Requests to backport and of release
+// blockers are not mirrored: they have no check box of their own and change on every refresh.
+var projectSteps = []projectStep{
+	{steps.GeneralPrerequisite, sectionPrerequisites, allItems(func(i *Issue) *ParentOfItems { return &i.General }), never},
+	{steps.SlackAnnouncement, sectionPrerequisites, flag(func(i *Issue) *bool { return &i.SlackPreRequisite }), never},
+	{steps.CheckSummary, sectionPrerequisites, flag(func(i *Issue) *bool { return &i.CheckSummary }), never},
+	{steps.DraftBlogPost, sectionPrerequisites, flag(func(i *Issue) *bool { return &i.DraftBlogPost }), notGA},
+	{steps.CrossPostBlogPost, sectionPrerequisites, flag(func(i *Issue) *bool { return &i.RequestCrossPostBlogPost }), notGA},
+
+	{steps.CodeFreeze, sectionCodeFreeze, flag(func(i *Issue) *bool { return &i.CodeFreeze.Done }), noCodeFreeze},
+	{steps.CopyBranchProtectionRules, sectionCodeFreeze, flag(func(i *Issue) *bool { return &i.CopyBranchProtectionRules }), notRC1},
+	{steps.CreateNewLabels, sectionCodeFreeze, flag(func(i *Issue) *bool { return &i.CreateNewLabels.Done }), notRC1},
+	{steps.UpdateSnapshotOnMain, sectionCodeFreeze, flag(func(i *Issue) *bool { return &i.UpdateSnapshotOnMain.Done }), notRC1},
+	{steps.CreateMilestone, sectionCodeFreeze, flag(func(i *Issue) *bool { return &i.NewGitHubMilestone.Done }), noCodeFreeze},
+	{steps.VtopCreateBranch, sectionCodeFreeze, flag(func(i *Issue) *bool { return &i.VtopCreateBranch }), func(i *Issue) bool { return noVtOp(i) || notRC1(i) }},
+	{steps.VtopBumpMainVersion, sectionCodeFreeze, flag(func(i *Issue) *bool { return &i.VtopBumpMainVersion.Done }), func(i *Issue) bool { return noVtOp(i) || notRC1(i) }},
+	{steps.VtopUpdateCompatibilityTable, sectionCodeFreeze, flag(func(i *Issue) *bool { return &i.VtopUpdateCompatibilityTable }), func(i *Issue) bool { return noVtOp(i) || notRC1(i) }},
+
+	{steps.CreateReleasePR, sectionPreRelease, flag(func(i *Issue) *bool { return &i.CreateReleasePR.Done }), never},
+	{steps.VtopUpdateGolang, sectionPreRelease, flag(func(i *Issue) *bool { return &i.VtopUpdateGolang.Done }), noVtOp},
+	{steps.CreateBlogPostPR, sectionPreRelease, flag(func(i *Issue) *bool { return &i.CreateBlogPostPR }), notGA},
+	{steps.UpdateCobraDocs, sectionPreRelease, flag(func(i *Issue) *bool { return &i.UpdateCobraDocs }), never},
+
+	{steps.MergeReleasePR, sectionRelease, flag(func(i *Issue) *bool { return &i.MergeReleasePR.Done }), never},
+	{steps.TagRelease, sectionRelease, flag(func(i *Issue) *bool { return &i.TagRelease.Done }), never},
+	{steps.JavaRelease, sectionRelease, flag(func(i *Issue) *bool { return &i.JavaRelease }), func(i *Issue) bool { return i.RC == 0 && !i.GA }},
+	{steps.VtopCreateReleasePR, sectionRelease, flag(func(i *Issue) *bool { return &i.VtopCreateReleasePR.Done }), noVtOp},
+	{steps.ReleaseNotesOnMain, sectionRelease, flag(func(i *Issue) *bool { return &i.ReleaseNotesOnMain.Done }), never},
+	{steps.BackToDev, sectionRelease, flag(func(i *Issue) *bool { return &i.BackToDevMode.Done }), never},
+	{steps.MergeBlogPost, sectionRelease, flag(func(i *Issue) *bool { return &i.MergeBlogPostPR }), notGA},
+	{steps.WebsiteDocumentation, sectionRelease, flag(func(i *Issue) *bool { return &i.WebsiteDocumentation }), never},
+	{steps.Benchmarked, sectionRelease, flag(func(i *Issue) *bool { return &i.Benchmarked }), never},
+	{steps.DockerImages, sectionRelease, flag(func(i *Issue) *bool { return &i.DockerImages }), never},
+	{steps.CloseMilestone, sectionRelease, flag(func(i *Issue) *bool { return &i.CloseMilestone.Done }), func(i *Issue) bool { return i.RC > 0 }},
+	{steps.ReleaseArtifacts, sectionRelease, flag(func(i *Issue) *bool { return &i.ReleaseArtifacts }), never},
+	{steps.VtopMergeReleasePR, sectionRelease, flag(func(i *Issue) *bool { return &i.VtopMergeReleasePR.Done }), noVtOp},
+	{steps.VtopTagRelease, sectionRelease, flag(func(i *Issue) *bool { return &i.VtopTagRelease.Done }), noVtOp},
+	{steps.VtopBackToDev, sectionRelease, flag(func(i *Issue) *bool { return &i.VtopBackToDevMode.Done }), noVtOp},
+	{steps.VtopManualUpdate, sectionRelease, flag(func(i *Issue) *bool { return &i.VtopManualUpdate }), noVtOp},
+
+	{steps.SlackAnnouncementPost, sectionPostRelease, flag(func(i *Issue) *bool { return &i.SlackPostRelease }), never},
+	{steps.Twitter, sectionPostRelease, flag(func(i *Issue) *bool { return &i.Twitter }), never},
+	{steps.RemoveBypassProtection, sectionPostRelease, flag(func(i *Issue) *bool { return &i.RemoveBypassProtection }), never},
+	{steps.CleanupBranches, sectionPostRelease, flag(func(i *Issue) *bool { return &i.CleanupBranches }), never},
+	{steps.CloseIssue, sectionPostRelease, flag(func(i *Issue) *bool { return &i.CloseIssue }), never},
+}
+
+func (s *State) projectItemTitle(step projectStep) string {
+	return fmt.Sprintf("[v%s] %s", s.VitessRelease.Release, step.name)
+}
+
+func (s *State) projectDueDate(section string) string {
+	d := s.Issue.Date
+
+	switch section {
+	case sectionPrerequisites:
+		d = d.AddDate(0, 0, -14)
+	case sectionCodeFreeze:
+		if s.Issue.RC == 1 {
+			d = d.AddDate(0, 0, -7)
+		} else {
+			d = d.AddDate(0, 0, -2)
+		}
+	case sectionPreRelease:
+		d = d.AddDate(0, 0, -2)
+	}
+
+	return d.Format(time.DateOnly)
+}
+
+func projectItemsByTitle(project github.Project) map[string]github.ProjectItem {
+	items := project.ListItems()
+
+	m := make(map[string]github.ProjectItem, len(items))
+	for _, item := range items {
+		m[item.Title] = item
+	}
+
+	return m
+}
+
+// MirrorOnProjectBoard creates or updates one item per release step on the project board,
+// using the status of the steps found in the release issue.
+func (s *State) MirrorOnProjectBoard() {
+	if !s.Project.Enabled() {
+		return
+	}
+
+	project := github.GetProject(s.Project.Owner, s.Project.Number)
+	items := projectItemsByTitle(project)
+
+	var owner string
+
+	for _, step := range projectSteps {
+		if step.skip(&s.Issue) {
+			continue
+		}
+
+		title := s.projectItemTitle(step)
+		status := projectStatusTodo
+
+		if step.done.get(&s.Issue) {
+			status = projectStatusDone
+		}
+
+		item, ok := items[title]
+		if !ok {
+			if owner == "" {
+				owner = github.CurrentUser()
+			}
+
+			item.ID = project.CreateDraftItem(title, fmt.Sprintf("Step of the release tracked in %s", s.IssueLink))
+			project.SetItemField(item.ID, projectFieldOwner, owner)
+			project.SetItemField(item.ID, projectFieldSection, step.section)
+			project.SetItemDateField(item.ID, projectFieldDueDate, s.projectDueDate(step.section))
+		}
+
+		if item.Values[strings.ToLower(projectFieldStatus)] != status {
+			project.SetItemField(item.ID, projectFieldStatus, status)
+		}
+	}
+
+	s.Issue.ProjectSyncedStatus = s.Issue.stepsStatus()
+}
+
+// SyncFromProjectBoard reads the status of each step on the project board and applies
+// the changes that were made on the board since the last time we mirrored the issue on it.
+// It returns true if the release issue was modified.
+func (s *State) SyncFromProjectBoard() bool {
+	if !s.Project.Enabled() || s.Issue.ProjectSyncedStatus == nil {
+		return false
+	}
+
+	project := github.GetProject(s.Project.Owner, s.Project.Number)
+	items := projectItemsByTitle(project)
+
+	var changed bool
+
+	for _, step := range projectSteps {
+		if step.skip(&s.Issue) {
+			continue
+		}
+
+		item, ok := items[s.projectItemTitle(step)]
+		if !ok {
+			continue
+		}
+
+		onBoard := item.Values[strings.ToLower(projectFieldStatus)] == projectStatusDone
+
+		// The board did not change since we last mirrored the issue on it,
+		// the issue remains the source of truth for this step.
+		if lastSynced, ok := s.Issue.ProjectSyncedStatus[step.name]; ok && lastSynced == onBoard {
+			continue
+		}
+
+		if step.done.get(&s.Issue) != onBoard {
+			step.done.set(&s.Issue, onBoard)
+			changed = true
+		}
+	}
+
+	return changed
+}
+
+func (i *Issue) stepsStatus() map[string]bool {
+	m := make(map[string]bool, len(projectSteps))
+
+	for _, step := range projectSteps {
+		if step.skip(i) {
+			continue
+		}
+
+		m[step.name] = step.done.get(i)
+	}
+
+	return m
+}
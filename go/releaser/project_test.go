@@ -0,0 +1,83 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package releaser
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestProjectStepsMirrorIssue(t *testing.T) {
+	tcs := []struct {
+		rc     int
+		ga     bool
+		doVtOp bool
+	}{
+		{rc: 1}, {rc: 1, doVtOp: true}, {rc: 2}, {rc: 2, doVtOp: true}, {ga: true}, {ga: true, doVtOp: true}, {}, {doVtOp: true},
+	}
+
+	for _, tc := range tcs {
+		t.Run(fmt.Sprintf("rc=%d ga=%t vtop=%t", tc.rc, tc.ga, tc.doVtOp), func(t *testing.T) {
+			issue := Issue{
+				Date:    time.Date(2024, time.October, 30, 0, 0, 0, 0, time.UTC),
+				RC:      tc.rc,
+				GA:      tc.ga,
+				DoVtOp:  tc.doVtOp,
+				General: ParentOfItems{Items: []ItemWithLink{{URL: "prerequisite"}}},
+			}
+
+			var checkBoxes int
+			for _, line := range strings.Split(issue.toString(), "\n") {
+				if strings.HasPrefix(line, "- [ ] ") {
+					checkBoxes++
+				}
+			}
+
+			var mirrored int
+			for _, step := range projectSteps {
+				if !step.skip(&issue) {
+					mirrored++
+				}
+			}
+
+			if mirrored != checkBoxes {
+				t.Fatalf("expected %d steps to be mirrored on the project board, got %d", checkBoxes, mirrored)
+			}
+		})
+	}
+}
+
+func TestProjectStepsDerivedStatus(t *testing.T) {
+	issue := Issue{General: ParentOfItems{Items: []ItemWithLink{{URL: "a"}, {URL: "b", Done: true}}}}
+	general := projectSteps[0]
+
+	if general.done.get(&issue) {
+		t.Fatal("expected the general prerequisites not to be done")
+	}
+
+	general.done.set(&issue, true)
+	if !issue.General.Done() {
+		t.Fatalf("expected all the general prerequisites to be done, got %+v", issue.General.Items)
+	}
+
+	general.done.set(&issue, false)
+	if issue.General.ItemsLeft() != 2 {
+		t.Fatalf("expected no general prerequisite to be done, got %+v", issue.General.Items)
+	}
+}
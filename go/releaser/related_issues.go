@@ -0,0 +1,133 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package releaser
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+
+	"github.com/vitessio/vitess-releaser/go/releaser/github"
+)
+
+// RelatedRelease links to the release issue of another release of the same major,
+// for instance the RC-1 issue when working on the RC-2 issue.
+type RelatedRelease struct {
+	Release string
+	URL     string
+}
+
+// LoadRelatedReleaseIssues finds the release issues of the previous and next releases
+// of the current major release, and stores them in the Issue so they can be linked
+// in the issue's header.
+func (s *State) LoadRelatedReleaseIssues() {
+	issues := github.GetReleaseIssuesForMajor(s.VitessRelease.Repo, s.VitessRelease.MajorRelease)
+	s.Issue.PreviousRelease, s.Issue.NextRelease = findPreviousAndNextReleases(s.VitessRelease.Release, issues)
+}
+
+// findPreviousAndNextReleases returns the closest releases before and after the current one.
+// RC releases are ordered before their GA: 21.0.0-RC1 < 21.0.0-RC2 < 21.0.0 < 21.0.1.
+func findPreviousAndNextReleases(current string, issues []github.ReleaseIssue) (previous, next RelatedRelease) {
+	currentVersion, ok := parseReleaseVersion(current)
+	if !ok {
+		return previous, next
+	}
+
+	var prevVersion, nextVersion *releaseVersion
+
+	for _, issue := range issues {
+		v, ok := parseReleaseVersion(issue.Release)
+		if !ok {
+			continue
+		}
+
+		switch {
+		case v.compare(currentVersion) < 0 && (prevVersion == nil || v.compare(*prevVersion) > 0):
+			prevVersion = &v
+			previous = RelatedRelease{Release: issue.Release, URL: issue.URL}
+		case v.compare(currentVersion) > 0 && (nextVersion == nil || v.compare(*nextVersion) < 0):
+			nextVersion = &v
+			next = RelatedRelease{Release: issue.Release, URL: issue.URL}
+		}
+	}
+
+	return previous, next
+}
+
+// releaseVersion is a release, i.e. "21.0.0-RC2", split into its version and its RC number. go-version
+// compares the pre-release part as a string, which would order RC10 before RC2, the RC numbers are
+// thus compared on their own. A GA release has no RC number and comes after all its RCs.
+type releaseVersion struct {
+	version *version.Version
+	rc      int
+}
+
+func parseReleaseVersion(release string) (releaseVersion, bool) {
+	base, rc, isRC := strings.Cut(strings.ToUpper(release), "-RC")
+
+	v, err := version.NewVersion(base)
+	if err != nil || v.Prerelease() != "" {
+		return releaseVersion{}, false
+	}
+
+	rv := releaseVersion{version: v, rc: math.MaxInt}
+	if isRC {
+		rv.rc, err = strconv.Atoi(rc)
+		if err != nil {
+			return releaseVersion{}, false
+		}
+	}
+
+	return rv, true
+}
+
+func (rv releaseVersion) compare(other releaseVersion) int {
+	if c := rv.version.Compare(other.version); c != 0 {
+		return c
+	}
+
+	return cmp.Compare(rv.rc, other.rc)
+}
+
+// carryOverUnresolvedItems copies the items of the previous release issue that were
+// not resolved into the new issue, recording the previous release in their history
+// so we can track for how long they have been pending.
+func carryOverUnresolvedItems(previousRelease string, from, to ParentOfItems) ParentOfItems {
+outer:
+	for _, item := range from.Items {
+		if item.Done {
+			continue
+		}
+
+		for _, existing := range to.Items {
+			if existing.URL == item.URL {
+				continue outer
+			}
+		}
+
+		to.Items = append(to.Items, ItemWithLink{
+			URL:     item.URL,
+			History: append(slices.Clone(item.History), "v"+previousRelease),
+		})
+	}
+
+	return to
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package releaser
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vitessio/vitess-releaser/go/releaser/github"
+)
+
+func TestFindPreviousAndNextReleases(t *testing.T) {
+	var issues []github.ReleaseIssue
+	for _, release := range []string{"21.0.0-RC1", "21.0.0-RC2", "21.0.0-RC10", "21.0.0", "21.0.1", "21.0.10", "not a release"} {
+		issues = append(issues, github.ReleaseIssue{Release: release, URL: "https://github.com/vitessio/vitess/issues/" + release})
+	}
+
+	tcs := []struct {
+		current        string
+		previous, next string
+	}{
+		{current: "21.0.0-RC1", previous: "", next: "21.0.0-RC2"},
+		{current: "21.0.0-RC2", previous: "21.0.0-RC1", next: "21.0.0-RC10"},
+		{current: "21.0.0-RC3", previous: "21.0.0-RC2", next: "21.0.0-RC10"},
+		{current: "21.0.0-RC10", previous: "21.0.0-RC2", next: "21.0.0"},
+		{current: "21.0.0", previous: "21.0.0-RC10", next: "21.0.1"},
+		{current: "21.0.2", previous: "21.0.1", next: "21.0.10"},
+		{current: "21.0.10", previous: "21.0.1", next: ""},
+		{current: "21.0.0-rc2", previous: "21.0.0-RC1", next: "21.0.0-RC10"},
+		{current: "invalid", previous: "", next: ""},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.current, func(t *testing.T) {
+			previous, next := findPreviousAndNextReleases(tc.current, issues)
+			if previous.Release != tc.previous || next.Release != tc.next {
+				t.Fatalf("expected %q and %q, got %q and %q", tc.previous, tc.next, previous.Release, next.Release)
+			}
+		})
+	}
+}
+
+func TestCarryOverUnresolvedItems(t *testing.T) {
+	from := ParentOfItems{Items: []ItemWithLink{
+		{URL: "#1", History: []string{"v21.0.0-RC1"}},
+		{URL: "#2", Done: true, Outcome: github.OutcomeMerged},
+		{URL: "#3"},
+	}}
+	to := ParentOfItems{Items: []ItemWithLink{{URL: "#3"}, {URL: "#4"}}}
+
+	got := carryOverUnresolvedItems("21.0.0-RC2", from, to)
+
+	want := []ItemWithLink{
+		{URL: "#3"},
+		{URL: "#4"},
+		{URL: "#1", History: []string{"v21.0.0-RC1", "v21.0.0-RC2"}},
+	}
+	if !reflect.DeepEqual(got.Items, want) {
+		t.Fatalf("expected %+v, got %+v", want, got.Items)
+	}
+
+	if len(from.Items[0].History) != 1 {
+		t.Fatalf("expected the history of the previous issue to be left untouched, got %q", from.Items[0].History)
+	}
+}
+
+func TestIssueItemsRoundTrip(t *testing.T) {
+	issue := Issue{
+		Date:            time.Date(2024, time.October, 30, 0, 0, 0, 0, time.UTC),
+		RC:              2,
+		PreviousRelease: RelatedRelease{Release: "21.0.0-RC1", URL: "https://github.com/vitessio/vitess/issues/1"},
+		CheckBackport: ParentOfItems{Items: []ItemWithLink{
+			{URL: "#10"},
+			{URL: "#11", History: []string{"v21.0.0-RC1"}},
+			{URL: "#12", Done: true, Outcome: github.OutcomeMerged, History: []string{"v20.0.0", "v21.0.0-RC1"}},
+		}},
+		ReleaseBlocker: ParentOfItems{Items: []ItemWithLink{
+			{URL: "https://github.com/vitessio/vitess/issues/13", Done: true, Outcome: github.OutcomeUnlabelled},
+			{URL: "#14", History: []string{"v21.0.0-RC1"}},
+		}},
+	}
+
+	body := issue.toString()
+
+	for _, want := range []string{
+		"  - [ ] #11 <sub>carried over from v21.0.0-RC1</sub>",
+		"  - [x] #12 _(merged)_ <sub>carried over from v20.0.0, v21.0.0-RC1</sub>",
+		"previous [v21.0.0-RC1](https://github.com/vitessio/vitess/issues/1)",
+	} {
+		if !strings.Contains(body, want) {
+			t.Fatalf("expected the body to contain %q, got:\n%s", want, body)
+		}
+	}
+
+	var parsed Issue
+	parseIssueBody(body, &parsed)
+
+	if !reflect.DeepEqual(parsed.CheckBackport, issue.CheckBackport) {
+		t.Fatalf("expected the backports %+v, got %+v", issue.CheckBackport, parsed.CheckBackport)
+	}
+
+	if !reflect.DeepEqual(parsed.ReleaseBlocker, issue.ReleaseBlocker) {
+		t.Fatalf("expected the release blockers %+v, got %+v", issue.ReleaseBlocker, parsed.ReleaseBlocker)
+	}
+
+	// The RC number comes from the title of the issue, and the related releases from the other issues
+	parsed.RC, parsed.PreviousRelease = issue.RC, issue.PreviousRelease
+
+	if again := parsed.toString(); again != body {
+		t.Fatalf("expected the parsed issue to render the same body, got:\n%s\ninstead of:\n%s", again, body)
+	}
+}
@@ -47,8 +47,10 @@ func BackToDevModeOnBranch(state *releaser.State, itemToUpdate *releaser.ItemWit
 		}()
 
 		pl.NewStepf("Fetch from git remote")
-		git.CorrectCleanRepo(state.VitessRelease.Repo)
-		git.ResetHard(state.VitessRelease.Remote, branch)
+		git.CorrectRepo(state.VitessRelease.Repo)
+
+		wt := git.NewWorktree(state.VitessRelease.Remote, branch)
+		defer wt.Remove()
 
 		// If we are releasing an RC release, the next SNAPSHOT version on the release branch
 		// will be the same release as the RC but without the RC tag.
@@ -76,7 +78,7 @@ func BackToDevModeOnBranch(state *releaser.State, itemToUpdate *releaser.ItemWit
 		}
 
 		pl.NewStepf("Create new branch based on %s/%s", state.VitessRelease.Remote, branch)
-		newBranchName := git.FindNewGeneratedBranch(state.VitessRelease.Remote, branch, "back-to-dev-mode")
+		newBranchName := git.FindNewGeneratedBranch(state.VitessRelease.Remote, branch, git.BranchBackToDevMode)
 
 		pl.NewStepf("Update version.go")
 		releaser.UpdateVersionGoFile(devModeRelease)
@@ -86,7 +88,7 @@ func BackToDevModeOnBranch(state *releaser.State, itemToUpdate *releaser.ItemWit
 
 		pl.NewStepf("Commit and push to branch %s", newBranchName)
 
-		if git.CommitAll(fmt.Sprintf("Back to dev mode: %s", backToDevModePRName)) {
+		if git.CommitAll(fmt.Sprintf("Back to dev mode: %s", backToDevModePRName), releaser.VersionFiles) {
 			pl.TotalSteps = 9 // only 9 total steps in this situation
 			pl.NewStepf("Nothing to commit, seems like back to dev mode is already done")
 
@@ -95,19 +97,26 @@ func BackToDevModeOnBranch(state *releaser.State, itemToUpdate *releaser.ItemWit
 			return ""
 		}
 
-		git.Push(state.VitessRelease.Remote, newBranchName)
+		if !state.VerifyAndPush(pl, state.VitessRelease.PushRemote, newBranchName) {
+			return ""
+		}
 
 		pl.NewStepf("Create Pull Request")
 
 		pr := github.PR{
 			Title:  backToDevModePRName,
 			Body:   fmt.Sprintf("Includes the changes required to go back into dev mode (v%s) after the release of v%s.", devModeRelease, state.VitessRelease.Release),
-			Branch: newBranchName,
+			Branch: state.VitessRelease.HeadRef(newBranchName),
 			Base:   branch,
 			Labels: []github.Label{{Name: "Component: General"}, {Name: "Type: Release"}},
 		}
-		_, url = pr.Create(state.IssueLink, state.VitessRelease.Repo)
+
+		var reviewErr error
+		_, url, reviewErr = pr.Create(state.IssueLink, state.VitessRelease.Repo)
 		pl.NewStepf("Pull Request created %s", url)
+		if reviewErr != nil {
+			pl.Warnf("%s", reviewErr)
+		}
 
 		done = true
 
@@ -48,11 +48,10 @@ func CopyReleaseNotesToBranch(state *releaser.State, itemToUpdate *releaser.Item
 		}()
 
 		pl.NewStepf("Fetch from git remote")
-		git.CorrectCleanRepo(state.VitessRelease.Repo)
-		git.ResetHard(state.VitessRelease.Remote, state.VitessRelease.ReleaseBranch)
+		git.CorrectRepo(state.VitessRelease.Repo)
 
-		git.Checkout(branch)
-		git.ResetHard(state.VitessRelease.Remote, branch)
+		wt := git.NewWorktree(state.VitessRelease.Remote, branch)
+		defer wt.Remove()
 
 		prName := fmt.Sprintf("[%s] Copy `v%s` release notes", branch, state.VitessRelease.Release)
 
@@ -68,7 +67,7 @@ func CopyReleaseNotesToBranch(state *releaser.State, itemToUpdate *releaser.Item
 		}
 
 		pl.NewStepf("Create new branch based on %s/%s", state.VitessRelease.Remote, branch)
-		newBranchName := git.FindNewGeneratedBranch(state.VitessRelease.Remote, branch, fmt.Sprintf("release-notes-%s", branch))
+		newBranchName := git.FindNewGeneratedBranch(state.VitessRelease.Remote, branch, fmt.Sprintf("%s-%s", git.BranchReleaseNotes, branch))
 
 		pl.NewStepf("Copy release notes from %s/%s", state.VitessRelease.Remote, state.VitessRelease.ReleaseBranch)
 		releaseNotesPath := pre_release.GetReleaseNotesDirPathForMajor(releaser.RemoveRCFromReleaseTitle(state.VitessRelease.Release))
@@ -76,7 +75,7 @@ func CopyReleaseNotesToBranch(state *releaser.State, itemToUpdate *releaser.Item
 
 		pl.NewStepf("Commit and push to branch %s", newBranchName)
 
-		if git.CommitAll(fmt.Sprintf("Copy release notes from %s into %s", state.VitessRelease.ReleaseBranch, branch)) {
+		if git.CommitAll(fmt.Sprintf("Copy release notes from %s into %s", state.VitessRelease.ReleaseBranch, branch), []string{releaseNotesPath + "/**"}) {
 			pl.TotalSteps = 8 // only 8 total steps in this situation
 			pl.NewStepf("Nothing to commit, seems like the release notes have already been copied")
 
@@ -85,19 +84,26 @@ func CopyReleaseNotesToBranch(state *releaser.State, itemToUpdate *releaser.Item
 			return ""
 		}
 
-		git.Push(state.VitessRelease.Remote, newBranchName)
+		if !state.VerifyAndPush(pl, state.VitessRelease.PushRemote, newBranchName) {
+			return ""
+		}
 
 		pl.NewStepf("Create Pull Request")
 
 		pr := github.PR{
 			Title:  prName,
 			Body:   fmt.Sprintf("This Pull Request copies the release notes found on `%s` to keep release notes up-to-date after the `v%s` release.", state.VitessRelease.ReleaseBranch, state.VitessRelease.Release),
-			Branch: newBranchName,
+			Branch: state.VitessRelease.HeadRef(newBranchName),
 			Base:   branch,
 			Labels: []github.Label{{Name: "Component: General"}, {Name: "Type: Release"}},
 		}
-		_, url = pr.Create(state.IssueLink, state.VitessRelease.Repo)
+
+		var reviewErr error
+		_, url, reviewErr = pr.Create(state.IssueLink, state.VitessRelease.Repo)
 		pl.NewStepf("Pull Request created %s", url)
+		if reviewErr != nil {
+			pl.Warnf("%s", reviewErr)
+		}
 
 		done = true
 
@@ -0,0 +1,91 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package release
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/vitessio/vitess-releaser/go/releaser"
+	"github.com/vitessio/vitess-releaser/go/releaser/git"
+	"github.com/vitessio/vitess-releaser/go/releaser/github"
+	"github.com/vitessio/vitess-releaser/go/releaser/logging"
+	"github.com/vitessio/vitess-releaser/go/releaser/pre_release"
+)
+
+// vitessReleaseTag returns the git tag of the vitess release, in lower case (v19.0.0-rc1),
+// and the path of its release notes.
+func vitessReleaseTag(state *releaser.State) (tag, notesPath string) {
+	tag = fmt.Sprintf("v%s", strings.ToLower(state.VitessRelease.Release))
+	notesPath = path.Join(pre_release.GetReleaseNotesDirPath(releaser.RemoveRCFromReleaseTitle(state.VitessRelease.Release)), "release_notes.md")
+
+	return tag, notesPath
+}
+
+// publishGitHubRelease creates or updates the GitHub release of the tag and uploads the release assets of the state.
+// The notes are read from notesPath, relative to the current directory, which is expected to be a worktree.
+// The changes made to an existing release and the uploaded assets are added as steps on top of the total steps of pl.
+func publishGitHubRelease(pl *logging.ProgressLogging, state *releaser.State, info releaser.ReleaseInformation, tag, notesPath string, assets []string) string {
+	url, changes := github.CreateRelease(info.Repo, tag, notesPath, info.IsLatestRelease && state.Issue.RC == 0, state.Issue.RC > 0)
+
+	for _, change := range changes {
+		pl.SetTotalStep(pl.GetTotal() + 1)
+		pl.NewStepf("Updated the existing release, %s", change)
+	}
+
+	if len(assets) == 0 {
+		return url
+	}
+
+	pl.SetTotalStep(pl.GetTotal() + 1)
+	pl.NewStepf("Upload %d release asset(s)", len(assets))
+
+	for _, done := range github.UploadReleaseAssets(info.Repo, tag, assets) {
+		pl.SetTotalStep(pl.GetTotal() + 1)
+		pl.NewStepf("%s", done)
+	}
+
+	return url
+}
+
+// UpdateGitHubRelease refreshes the GitHub release of the vitess tag after it was created by TagRelease: the body is
+// read again from the release notes at the head of the release branch, where they are fixed after the release, the flags,
+// title and target are fixed, and the release assets are uploaded.
+func UpdateGitHubRelease(state *releaser.State) (*logging.ProgressLogging, func() string) {
+	pl := &logging.ProgressLogging{
+		TotalSteps: 3,
+	}
+
+	return pl, func() string {
+		tag, notesPath := vitessReleaseTag(state)
+
+		pl.NewStepf("Fetch the tag %s and %s from git remote", tag, state.VitessRelease.ReleaseBranch)
+		git.CorrectRepo(state.VitessRelease.Repo)
+		git.FetchTags(state.VitessRelease.Remote, tag)
+
+		wt := git.NewWorktree(state.VitessRelease.Remote, state.VitessRelease.ReleaseBranch)
+		defer wt.Remove()
+
+		pl.NewStepf("Update the release %s on the GitHub UI", tag)
+		url := publishGitHubRelease(pl, state, state.VitessRelease, tag, notesPath, state.ReleaseAssets)
+
+		pl.NewStepf("Done %s", url)
+
+		return url
+	}
+}
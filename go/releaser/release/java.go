@@ -37,8 +37,11 @@ func JavaRelease(state *releaser.State) (*logging.ProgressLogging, func() string
 		lowerCaseRelease := "v" + strings.ToLower(state.VitessRelease.Release)
 
 		pl.NewStepf("Fetch from git remote")
-		git.CorrectCleanRepo(state.VitessRelease.Repo)
-		git.Checkout(lowerCaseRelease)
+		git.CorrectRepo(state.VitessRelease.Repo)
+		git.FetchTags(state.VitessRelease.Remote, lowerCaseRelease)
+
+		wt := git.NewWorktreeForRef(lowerCaseRelease)
+		defer wt.Remove()
 
 		if strings.Contains(state.VitessRelease.Repo, "vitessio/vitess") {
 			pl.NewStepf("Do the Java release")
@@ -17,9 +17,10 @@ limitations under the License.
 package release
 
 import (
+	"context"
+	"errors"
 	"strconv"
 	"strings"
-	"time"
 
 	"github.com/vitessio/vitess-releaser/go/releaser"
 	"github.com/vitessio/vitess-releaser/go/releaser/github"
@@ -29,7 +30,11 @@ import (
 
 func MergeReleasePR(state *releaser.State) (*logging.ProgressLogging, func() string) {
 	pl := &logging.ProgressLogging{
-		TotalSteps: 5,
+		TotalSteps: 7,
+	}
+
+	if state.AutoMerge {
+		pl.TotalSteps++
 	}
 
 	return pl, func() string {
@@ -41,18 +46,38 @@ func MergeReleasePR(state *releaser.State) (*logging.ProgressLogging, func() str
 			utils.BailOut(err, "failed to parse the PR number from GitHub URL: %s", url)
 		}
 
+		// the label prevents merging the Release Pull Request before the release day, which is today
+		pl.NewStepf("Remove the 'Do Not Merge' label from %s", url)
+		github.RemoveLabel(state.VitessRelease.Repo, nb, "Do Not Merge")
+
+		if state.AutoMerge {
+			pl.NewStepf("Enable auto-merge with a merge commit on %s", url)
+			github.AutoMergePR(state.VitessRelease.Repo, nb, github.MergeMethodMerge)
+		}
+
 		pl.NewStepf("Waiting for %s to be merged", url)
-	outer:
-		for {
-			select {
-			case <-time.After(5 * time.Second):
-				if github.IsPRMerged(state.VitessRelease.Repo, nb) {
-					break outer
-				}
-			}
+
+		err = releaser.WaitForPRToBeMerged(pl, state.VitessRelease.Repo, nb, utils.WaitOptions{})
+		if errors.Is(err, context.Canceled) {
+			pl.SetTotalStep(pl.GetDone() + 1)
+			pl.NewStepf("Stopped waiting, run this step again once the Pull Request is merged")
+
+			return ""
 		}
+
+		if err != nil {
+			utils.BailOut(err, "failed to wait for %s to be merged", url)
+		}
+
 		pl.NewStepf("Pull Request has been merged")
 
+		// the release is tagged on the merge commit, which must contain the history of the release branch
+		pl.NewStepf("Verify that %s was merged with a merge commit", url)
+
+		if err := github.VerifyMergeMethod(state.VitessRelease.Repo, nb, github.MergeMethodMerge); err != nil {
+			utils.BailOut(err, "the Release Pull Request must be merged with a merge commit")
+		}
+
 		state.Issue.MergeReleasePR.Done = true
 		state.Issue.MergeReleasePR.URL = url
 		pl.NewStepf("Update Issue %s on GitHub", state.IssueLink)
@@ -18,6 +18,7 @@ package release
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/vitessio/vitess-releaser/go/releaser"
 	"github.com/vitessio/vitess-releaser/go/releaser/github"
@@ -26,7 +27,7 @@ import (
 
 func CloseMilestone(state *releaser.State) (*logging.ProgressLogging, func() string) {
 	pl := &logging.ProgressLogging{
-		TotalSteps: 5,
+		TotalSteps: 8,
 	}
 
 	return pl, func() string {
@@ -34,19 +35,45 @@ func CloseMilestone(state *releaser.State) (*logging.ProgressLogging, func() str
 		nextRelease := releaser.FindVersionAfterNextRelease(state)
 		nextMilestone := fmt.Sprintf("v%s", nextRelease)
 
-		pl.NewStepf("Get opened Pull Requests for Milestone %s", milestone)
+		pl.NewStepf("Summary before closing: %s", milestonesSummary(state.VitessRelease.Repo, milestone, nextMilestone))
+
+		pl.NewStepf("Get opened Pull Requests and issues for Milestone %s", milestone)
 		prs := github.GetOpenedPRsByMilestone(state.VitessRelease.Repo, milestone)
+		issues := github.GetOpenedIssuesByMilestone(state.VitessRelease.Repo, milestone)
+
+		var failures []string
 
 		if len(prs) > 0 {
 			pl.NewStepf("Move %d Pull Requests to the %s Milestone", len(prs), nextMilestone)
-			github.AssignMilestoneToPRs(state.VitessRelease.Repo, nextMilestone, prs)
+			if err := github.AssignMilestoneToPRs(state.VitessRelease.Repo, nextMilestone, prs, pl.ItemProgress("Pull Requests")); err != nil {
+				failures = append(failures, fmt.Sprintf("Pull Requests: %s", err))
+			}
 		} else {
 			pl.TotalSteps--
 		}
 
+		if len(issues) > 0 {
+			pl.NewStepf("Move %d issues to the %s Milestone", len(issues), nextMilestone)
+			if err := github.AssignMilestoneToIssues(state.VitessRelease.Repo, nextMilestone, issues, pl.ItemProgress("Issues")); err != nil {
+				failures = append(failures, fmt.Sprintf("issues: %s", err))
+			}
+		} else {
+			pl.TotalSteps--
+		}
+
+		// The milestone is left open, this way the step can be run again to move the remaining items
+		if len(failures) > 0 {
+			pl.SetTotalStep(pl.GetDone() + 1)
+			pl.NewStepf("Milestone %s not closed, some items could not be moved, run the step again. %s", milestone, strings.Join(failures, " | "))
+
+			return ""
+		}
+
 		pl.NewStepf("Close Milestone %s", milestone)
 		url := github.CloseMilestone(state.VitessRelease.Repo, milestone)
 
+		pl.NewStepf("Summary after closing: %s", milestonesSummary(state.VitessRelease.Repo, milestone, nextMilestone))
+
 		pl.NewStepf("Update Issue %s on GitHub", state.IssueLink)
 		state.Issue.CloseMilestone.Done = true
 		state.Issue.CloseMilestone.URL = url
@@ -58,3 +85,20 @@ func CloseMilestone(state *releaser.State) (*logging.ProgressLogging, func() str
 		return url
 	}
 }
+
+// milestonesSummary returns the number of open and closed items, issues and Pull Requests, of each milestone.
+func milestonesSummary(repo string, milestones ...string) string {
+	var summary []string
+
+	for _, name := range milestones {
+		ms := github.GetMilestonesByName(repo, name)
+		if len(ms) == 0 {
+			summary = append(summary, fmt.Sprintf("%s: not found", name))
+			continue
+		}
+
+		summary = append(summary, ms[0].String())
+	}
+
+	return strings.Join(summary, " | ")
+}
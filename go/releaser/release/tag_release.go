@@ -18,35 +18,41 @@ package release
 
 import (
 	"fmt"
-	"path"
 	"strings"
 
 	"github.com/vitessio/vitess-releaser/go/releaser"
 	"github.com/vitessio/vitess-releaser/go/releaser/git"
-	"github.com/vitessio/vitess-releaser/go/releaser/github"
 	"github.com/vitessio/vitess-releaser/go/releaser/logging"
-	"github.com/vitessio/vitess-releaser/go/releaser/pre_release"
 	"github.com/vitessio/vitess-releaser/go/releaser/utils"
 )
 
 func TagRelease(state *releaser.State) (*logging.ProgressLogging, func() string) {
 	pl := &logging.ProgressLogging{
-		TotalSteps: 6,
+		TotalSteps: 9,
 	}
 
 	return pl, func() string {
 		pl.NewStepf("Fetch from git remote")
-		git.CorrectCleanRepo(state.VitessRelease.Repo)
-		git.ResetHard(state.VitessRelease.Remote, state.VitessRelease.ReleaseBranch)
+		git.CorrectRepo(state.VitessRelease.Repo)
+
+		target := resolveTagTarget(pl, state.VitessRelease, state.Issue.CreateReleasePR.URL)
 
 		// We want to transform the release name into lower case in case the release is an RC
 		// Example: we will go from v19.0.0-RC1 to v19.0.0-rc1 which is a better format for our tags
 		lowerCaseRelease := strings.ToLower(state.VitessRelease.Release)
+		checkVersionAtTarget(pl, target, lowerCaseRelease, false)
+
+		wt := git.NewWorktreeForRef(target)
+		defer wt.Remove()
 
 		pl.NewStepf("Create and push the tags")
 
-		gitTag := fmt.Sprintf("v%s", lowerCaseRelease)
-		git.TagAndPush(state.VitessRelease.Remote, gitTag)
+		gitTag, releaseNotesPath := vitessReleaseTag(state)
+		tagMsg := fmt.Sprintf(
+			"Release of v%s\n\nRelease notes: https://github.com/%s/blob/%s/%s",
+			state.VitessRelease.Release, state.VitessRelease.Repo, gitTag, releaseNotesPath,
+		)
+		git.TagAndPush(state.VitessRelease.Remote, gitTag, target, tagMsg)
 
 		// we also need to tag and push the Go doc tag
 		// i.e. if we release v17.0.1, we also want to tag: v0.17.1
@@ -56,12 +62,15 @@ func TagRelease(state *releaser.State) (*logging.ProgressLogging, func() string)
 		}
 
 		gdocGitTag := fmt.Sprintf("v0.%s.%s", nextReleaseSplit[0], nextReleaseSplit[2])
-		git.TagAndPush(state.VitessRelease.Remote, gdocGitTag)
+		git.TagAndPush(state.VitessRelease.Remote, gdocGitTag, target, fmt.Sprintf("Go module tag of %s\n\n%s", gitTag, tagMsg))
+
+		pl.NewStepf("Verify the pushed tags")
+		git.VerifyPushedTag(state.VitessRelease.Remote, gitTag, target)
+		git.VerifyPushedTag(state.VitessRelease.Remote, gdocGitTag, target)
 
 		pl.NewStepf("Create the release on the GitHub UI")
 
-		releaseNotesPath := path.Join(pre_release.GetReleaseNotesDirPath(releaser.RemoveRCFromReleaseTitle(state.VitessRelease.Release)), "release_notes.md")
-		url := github.CreateRelease(state.VitessRelease.Repo, gitTag, releaseNotesPath, state.VitessRelease.IsLatestRelease && state.Issue.RC == 0, state.Issue.RC > 0)
+		url := publishGitHubRelease(pl, state, state.VitessRelease, gitTag, releaseNotesPath, state.ReleaseAssets)
 
 		pl.NewStepf("Done %s", url)
 
@@ -0,0 +1,72 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package release
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/vitessio/vitess-releaser/go/releaser"
+	"github.com/vitessio/vitess-releaser/go/releaser/git"
+	"github.com/vitessio/vitess-releaser/go/releaser/github"
+	"github.com/vitessio/vitess-releaser/go/releaser/logging"
+	"github.com/vitessio/vitess-releaser/go/releaser/utils"
+)
+
+// resolveTagTarget returns the commit that must be tagged: the merge commit of the release
+// Pull Request. Commits merged on the release branch after the release Pull Request are not
+// part of the release. If no release Pull Request was recorded, the HEAD of the release branch is used.
+func resolveTagTarget(pl *logging.ProgressLogging, ri releaser.ReleaseInformation, releasePRURL string) string {
+	// the release branch must be up-to-date to find the merge commit of the release Pull Request
+	git.InvalidateFetchCache(ri.Remote, ri.ReleaseBranch)
+	git.FetchBranches(ri.Remote, ri.ReleaseBranch)
+
+	branch := fmt.Sprintf("%s/%s", ri.Remote, ri.ReleaseBranch)
+	head := git.GetSHAForGitRef(branch)
+
+	if !strings.HasPrefix(releasePRURL, "https://") {
+		pl.NewStepf("No release Pull Request recorded, tagging the HEAD of %s: %s", branch, head)
+		return head
+	}
+
+	target := github.GetPRMergeCommit(ri.Repo, github.URLToNb(releasePRURL))
+	if target == "" {
+		utils.BailOut(nil, "the release Pull Request %s is not merged", releasePRURL)
+	}
+
+	if !git.IsAncestor(target, branch) {
+		utils.BailOut(nil, "the merge commit %s of %s is not part of %s", target, releasePRURL, branch)
+	}
+
+	if target != head {
+		pl.NewStepf("%s has new commits since %s was merged, tagging the merge commit %s", branch, releasePRURL, target)
+	} else {
+		pl.NewStepf("Tagging the merge commit of %s: %s", releasePRURL, target)
+	}
+
+	return target
+}
+
+// checkVersionAtTarget makes sure the version.go file at the target commit matches the release we are tagging.
+func checkVersionAtTarget(pl *logging.ProgressLogging, target, release string, isVtOp bool) {
+	v := releaser.VersionAtRef(target, isVtOp)
+	if !strings.EqualFold(v, release) {
+		utils.BailOut(nil, "version.go at %s contains version %s, expected %s", target, v, release)
+	}
+
+	pl.NewStepf("version.go at %s matches the release: %s", target, v)
+}
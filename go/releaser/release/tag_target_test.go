@@ -0,0 +1,123 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package release
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/vitessio/vitess-releaser/go/releaser"
+	"github.com/vitessio/vitess-releaser/go/releaser/git"
+	"github.com/vitessio/vitess-releaser/go/releaser/github"
+	"github.com/vitessio/vitess-releaser/go/releaser/github/githubtest"
+	"github.com/vitessio/vitess-releaser/go/releaser/logging"
+	"github.com/vitessio/vitess-releaser/go/releaser/utils"
+)
+
+const testRepo = "vitessio/vitess"
+
+// newClone creates an upstream repository with the given commits on release-21.0, and moves to a clone of it.
+// It returns the SHA of each commit.
+func newClone(t *testing.T, commits ...string) []string {
+	t.Helper()
+
+	t.Setenv("GIT_CONFIG_GLOBAL", os.DevNull)
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+
+	for _, who := range []string{"AUTHOR", "COMMITTER"} {
+		t.Setenv("GIT_"+who+"_NAME", "releaser")
+		t.Setenv("GIT_"+who+"_EMAIL", "releaser@vitess.io")
+	}
+
+	upstream := filepath.Join(t.TempDir(), "vitess")
+	utils.Exec("git", "init", "--quiet", "--initial-branch=release-21.0", upstream)
+
+	var shas []string
+	for _, msg := range commits {
+		utils.Exec("git", "-C", upstream, "commit", "--quiet", "--allow-empty", "-m", msg)
+		shas = append(shas, strings.TrimSpace(utils.Exec("git", "-C", upstream, "rev-parse", "HEAD")))
+	}
+
+	clone := filepath.Join(t.TempDir(), "clone")
+	utils.Exec("git", "clone", "--quiet", upstream, clone)
+	t.Chdir(clone)
+
+	// the cache is keyed by the git directory, which is new for each test
+	t.Cleanup(git.InvalidateAllFetches)
+
+	return shas
+}
+
+func newServer(t *testing.T) *githubtest.Server {
+	t.Helper()
+
+	s := githubtest.NewServer()
+	github.SetClient(s.Client())
+
+	t.Cleanup(func() {
+		github.SetClient(nil)
+		s.Close()
+	})
+
+	return s
+}
+
+func TestResolveTagTarget(t *testing.T) {
+	ri := releaser.ReleaseInformation{Repo: testRepo, Remote: "origin", ReleaseBranch: "release-21.0"}
+
+	t.Run("merge commit of the release Pull Request", func(t *testing.T) {
+		s := newServer(t)
+		shas := newClone(t, "initial commit", "release v21.0.0", "backport after the release")
+
+		nb := s.AddPR(testRepo, github.PR{Title: "[release-21.0] Release of v21.0.0", Branch: "release-21.0-create-release-1", Base: "release-21.0"})
+		s.MergePR(testRepo, nb, shas[1])
+
+		pl := &logging.ProgressLogging{}
+
+		if got := resolveTagTarget(pl, ri, fmt.Sprintf("https://github.com/%s/pull/%d", testRepo, nb)); got != shas[1] {
+			t.Fatalf("expected the merge commit %s, got %s", shas[1], got)
+		}
+
+		if last := pl.StepsDone[len(pl.StepsDone)-1]; !strings.Contains(last, "has new commits") {
+			t.Fatalf("expected the new commits on the release branch to be reported, got %q", last)
+		}
+	})
+
+	t.Run("merge commit at the head of the branch", func(t *testing.T) {
+		s := newServer(t)
+		shas := newClone(t, "initial commit", "release v21.0.0")
+
+		nb := s.AddPR(testRepo, github.PR{Title: "[release-21.0] Release of v21.0.0", Branch: "release-21.0-create-release-1", Base: "release-21.0"})
+		s.MergePR(testRepo, nb, shas[1])
+
+		if got := resolveTagTarget(&logging.ProgressLogging{}, ri, fmt.Sprintf("https://github.com/%s/pull/%d", testRepo, nb)); got != shas[1] {
+			t.Fatalf("expected the merge commit %s, got %s", shas[1], got)
+		}
+	})
+
+	t.Run("no release Pull Request", func(t *testing.T) {
+		newServer(t)
+		shas := newClone(t, "initial commit", "release v21.0.0")
+
+		if got := resolveTagTarget(&logging.ProgressLogging{}, ri, ""); got != shas[1] {
+			t.Fatalf("expected the head of the release branch %s, got %s", shas[1], got)
+		}
+	})
+}
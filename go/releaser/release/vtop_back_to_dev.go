@@ -54,12 +54,14 @@ func VtopBackToDev(state *releaser.State) (*logging.ProgressLogging, func() stri
 
 		// 1. Setup of the vtop codebase
 		pl.NewStepf("Fetch from git remote")
-		git.CorrectCleanRepo(state.VtOpRelease.Repo)
-		git.ResetHard(state.VtOpRelease.Remote, state.VtOpRelease.ReleaseBranch)
+		git.CorrectRepo(state.VtOpRelease.Repo)
+
+		wt := git.NewWorktree(state.VtOpRelease.Remote, state.VtOpRelease.ReleaseBranch)
+		defer wt.Remove()
 
 		// 2. Create temporary branch for the release
 		pl.NewStepf("Create temporary branch from %s", state.VtOpRelease.ReleaseBranch)
-		newBranchName := git.FindNewGeneratedBranch(state.VtOpRelease.Remote, state.VtOpRelease.ReleaseBranch, "back-to-dev")
+		newBranchName := git.FindNewGeneratedBranch(state.VtOpRelease.Remote, state.VtOpRelease.ReleaseBranch, git.BranchBackToDev)
 
 		releaseNameWithRC := releaser.AddRCToReleaseTitle(state.VtOpRelease.Release, state.Issue.RC)
 		lowerReleaseName := strings.ToLower(releaseNameWithRC)
@@ -71,7 +73,7 @@ func VtopBackToDev(state *releaser.State) (*logging.ProgressLogging, func() stri
 		pl.NewStepf("Go back to dev mode with version = %s", nextRelease)
 		code_freeze.UpdateVtOpVersionGoFile(nextRelease)
 
-		noCommit := git.CommitAll("Go back to dev mode")
+		noCommit := git.CommitAll("Go back to dev mode", code_freeze.VtOpVersionFiles)
 		if noCommit {
 			done = true
 			pl.TotalSteps -= 3
@@ -81,7 +83,9 @@ func VtopBackToDev(state *releaser.State) (*logging.ProgressLogging, func() stri
 
 		// 5. Push back to dev mode
 		pl.NewStepf("Pushing back to dev mode to %s", newBranchName)
-		git.Push(state.VtOpRelease.Remote, newBranchName)
+		if !state.VerifyAndPush(pl, state.VtOpRelease.PushRemote, newBranchName) {
+			return ""
+		}
 
 		// 6. Create the Pull Request
 		pl.NewStepf("Create Pull Request")
@@ -89,12 +93,17 @@ func VtopBackToDev(state *releaser.State) (*logging.ProgressLogging, func() stri
 		pr := github.PR{
 			Title:  fmt.Sprintf("[%s] Back to dev mode after the release of `v%s`", state.VtOpRelease.ReleaseBranch, lowerReleaseName),
 			Body:   fmt.Sprintf("This Pull Request updates the %s branch to go back to dev mode after the release of v%s.", state.VtOpRelease.ReleaseBranch, lowerReleaseName),
-			Branch: newBranchName,
+			Branch: state.VtOpRelease.HeadRef(newBranchName),
 			Base:   state.VtOpRelease.ReleaseBranch,
 			Labels: []github.Label{},
 		}
-		_, url = pr.Create(state.IssueLink, state.VtOpRelease.Repo)
+
+		var reviewErr error
+		_, url, reviewErr = pr.Create(state.IssueLink, state.VtOpRelease.Repo)
 		pl.NewStepf("Pull Request created %s", url)
+		if reviewErr != nil {
+			pl.Warnf("%s", reviewErr)
+		}
 
 		done = true
 
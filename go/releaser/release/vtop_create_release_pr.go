@@ -17,6 +17,8 @@ limitations under the License.
 package release
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io/fs"
 	"path/filepath"
@@ -64,45 +66,52 @@ func VtopCreateReleasePR(state *releaser.State) (*logging.ProgressLogging, func(
 			pl.NewStepf("Issue updated, see: %s", issueLink)
 		}()
 
+		// 1. Find out what is the previous release of vitess, this must be done
+		// before moving to the vtop worktree as we need the vitess repository
+		pl.NewStepf("Figuring out what the previous release of vitess is")
+		vitessPreviousRelease := releaser.FindPreviousRelease(state.VitessRelease.Remote, state.VitessRelease.MajorRelease)
+
 		state.GoToVtOp()
 		defer state.GoToVitess()
 
-		// 1. Setup of the vtop codebase
+		// 2. Setup of the vtop codebase
 		pl.NewStepf("Fetch from git remote")
-		git.CorrectCleanRepo(state.VtOpRelease.Repo)
-		git.ResetHard(state.VtOpRelease.Remote, state.VtOpRelease.ReleaseBranch)
+		git.CorrectRepo(state.VtOpRelease.Repo)
+
+		wt := git.NewWorktree(state.VtOpRelease.Remote, state.VtOpRelease.ReleaseBranch)
+		defer wt.Remove()
 
-		// 2. Check Go Upgrade PR
+		// 3. Check Go Upgrade PR
 		// We must ensure that, if any, the golang upgrade PR has been merged
 		// otherwise we cannot proceed with the creation of the Release PR.
 		if hasGoUpgradePR {
 			prNb := github.URLToNb(state.Issue.VtopUpdateGolang.URL)
 			pl.NewStepf("Checking if %s is merged. Please merge it if not already done. This step will timeout in 2 minutes.", state.Issue.VtopUpdateGolang.URL)
 
-			timeout := time.After(2 * time.Minute)
-		outer:
-			for {
-				select {
-				case <-time.After(5 * time.Second):
-					if github.IsPRMerged(state.VtOpRelease.Repo, prNb) {
-						break outer
-					}
-				case <-timeout:
-					pl.TotalSteps = 5
-					pl.NewStepf("This step has timeout, please merge the Pull Request %s and try again.", state.Issue.VtopUpdateGolang.URL)
-					return ""
-				}
+			err := releaser.WaitForPRToBeMerged(pl, state.VtOpRelease.Repo, prNb, utils.WaitOptions{Timeout: 2 * time.Minute})
+			switch {
+			case errors.Is(err, utils.ErrWaitTimeout):
+				pl.TotalSteps = 5
+				pl.NewStepf("This step has timeout, please merge the Pull Request %s and try again.", state.Issue.VtopUpdateGolang.URL)
+				return ""
+			case errors.Is(err, context.Canceled):
+				pl.TotalSteps = 5
+				pl.NewStepf("Stopped waiting, please merge the Pull Request %s and try again.", state.Issue.VtopUpdateGolang.URL)
+				return ""
+			case err != nil:
+				utils.BailOut(err, "failed to wait for %s to be merged", state.Issue.VtopUpdateGolang.URL)
 			}
+
 			pl.NewStepf("PR has been merged")
 		}
 
-		// 3. Figuring out the name of the release PR
+		// 4. Figuring out the name of the release PR
 		releasePRName := fmt.Sprintf("[%s] Release of `v%s`", state.VtOpRelease.ReleaseBranch, state.VtOpRelease.Release)
 		if state.Issue.RC > 0 {
 			releasePRName = fmt.Sprintf("[%s] Release of `v%s-RC%d`", state.VtOpRelease.ReleaseBranch, state.VtOpRelease.Release, state.Issue.RC)
 		}
 
-		// 4. Look for existing PRs
+		// 5. Look for existing PRs
 		pl.NewStepf("Look for an existing Release Pull Request named '%s'", releasePRName)
 
 		if _, url = github.FindPR(state.VtOpRelease.Repo, releasePRName); url != "" {
@@ -118,47 +127,47 @@ func VtopCreateReleasePR(state *releaser.State) (*logging.ProgressLogging, func(
 			return url
 		}
 
-		// 5. Create temporary branch for the release
+		// 6. Create temporary branch for the release
 		pl.NewStepf("Create temporary branch from %s", state.VtOpRelease.ReleaseBranch)
-		newBranchName := git.FindNewGeneratedBranch(state.VtOpRelease.Remote, state.VtOpRelease.ReleaseBranch, "create-release")
+		newBranchName := git.FindNewGeneratedBranch(state.VtOpRelease.Remote, state.VtOpRelease.ReleaseBranch, git.BranchCreateRelease)
 
-		// 6. Update the vitess golang dependency with the new vitess tag
+		// 7. Update the vitess golang dependency with the new vitess tag
 		pl.NewStepf("Update the golang dependency of vitess to tag %s", strings.ToLower(state.VitessRelease.Release))
 		updateVitessDeps(state)
 
-		if !git.CommitAll(fmt.Sprintf("Set vitess golang dependencies to %s", strings.ToLower(state.VitessRelease.Release))) {
+		if !git.CommitAll(fmt.Sprintf("Set vitess golang dependencies to %s", strings.ToLower(state.VitessRelease.Release)), vtopDepsFiles) {
 			commitCount++
 
-			git.Push(state.VtOpRelease.Remote, newBranchName)
+			if !state.VerifyAndPush(pl, state.VtOpRelease.PushRemote, newBranchName) {
+				return ""
+			}
 		}
 
 		releaseNameWithRC := releaser.AddRCToReleaseTitle(state.VtOpRelease.Release, state.Issue.RC)
 		lowerReleaseName := strings.ToLower(releaseNameWithRC)
 
-		// 7. Update the version file of vtop
+		// 8. Update the version file of vtop
 		pl.NewStepf("Update version file to %s", lowerReleaseName)
 		code_freeze.UpdateVtOpVersionGoFile(lowerReleaseName)
 
-		if !git.CommitAll(fmt.Sprintf("Update the version file to %s", lowerReleaseName)) {
+		if !git.CommitAll(fmt.Sprintf("Update the version file to %s", lowerReleaseName), code_freeze.VtOpVersionFiles) {
 			commitCount++
 
-			git.Push(state.VtOpRelease.Remote, newBranchName)
+			if !state.VerifyAndPush(pl, state.VtOpRelease.PushRemote, newBranchName) {
+				return ""
+			}
 		}
 
-		// 8. Find out what is the previous release of vitess
-		pl.NewStepf("Figuring out what the previous release of vitess is")
-		state.GoToVitess()
-		vitessPreviousRelease := releaser.FindPreviousRelease(state.VitessRelease.Remote, state.VitessRelease.MajorRelease)
-		state.GoToVtOp()
-
 		// 9. Update test code with proper images
 		pl.NewStepf("Update vitess-operator test code to use proper images")
 		updateVtopTests(vitessPreviousRelease, strings.ToLower(state.VitessRelease.Release))
 
-		if !git.CommitAll("Update test code to use proper image") {
+		if !git.CommitAll("Update test code to use proper image", vtopTestsFiles) {
 			commitCount++
 
-			git.Push(state.VtOpRelease.Remote, newBranchName)
+			if !state.VerifyAndPush(pl, state.VtOpRelease.PushRemote, newBranchName) {
+				return ""
+			}
 		}
 
 		if commitCount > 0 {
@@ -168,12 +177,17 @@ func VtopCreateReleasePR(state *releaser.State) (*logging.ProgressLogging, func(
 			pr := github.PR{
 				Title:  releasePRName,
 				Body:   fmt.Sprintf("This Pull Request contains all the code for the %s release of vtop. You must must squash merge this PR as the resulting commit SHA will be used to tag the release.", lowerReleaseName),
-				Branch: newBranchName,
+				Branch: state.VtOpRelease.HeadRef(newBranchName),
 				Base:   state.VtOpRelease.ReleaseBranch,
 				Labels: []github.Label{},
 			}
-			_, url = pr.Create(state.IssueLink, state.VtOpRelease.Repo)
+
+			var reviewErr error
+			_, url, reviewErr = pr.Create(state.IssueLink, state.VtOpRelease.Repo)
 			pl.NewStepf("Pull Request created %s", url)
+			if reviewErr != nil {
+				pl.Warnf("%s", reviewErr)
+			}
 		} else {
 			pl.TotalSteps -= 2
 		}
@@ -184,6 +198,11 @@ func VtopCreateReleasePR(state *releaser.State) (*logging.ProgressLogging, func(
 	}
 }
 
+var (
+	vtopDepsFiles  = []string{"go.mod", "go.sum"}
+	vtopTestsFiles = []string{"test/endtoend/operator/**", filepath.Clean(vtopDefaultsFile)}
+)
+
 func updateVitessDeps(state *releaser.State) {
 	if !strings.HasPrefix(state.VitessRelease.Repo, "vitessio/vitess") {
 		// bailing out here, since we are doing a release on a fork / testing the vitess releaser
@@ -17,9 +17,10 @@ limitations under the License.
 package release
 
 import (
+	"context"
+	"errors"
 	"strconv"
 	"strings"
-	"time"
 
 	"github.com/vitessio/vitess-releaser/go/releaser"
 	"github.com/vitessio/vitess-releaser/go/releaser/github"
@@ -29,7 +30,11 @@ import (
 
 func VtopMergeReleasePR(state *releaser.State) (*logging.ProgressLogging, func() string) {
 	pl := &logging.ProgressLogging{
-		TotalSteps: 5,
+		TotalSteps: 6,
+	}
+
+	if state.AutoMerge {
+		pl.TotalSteps++
 	}
 
 	return pl, func() string {
@@ -44,20 +49,34 @@ func VtopMergeReleasePR(state *releaser.State) (*logging.ProgressLogging, func()
 			utils.BailOut(err, "failed to parse the PR number from GitHub URL: %s", url)
 		}
 
+		if state.AutoMerge {
+			pl.NewStepf("Enable auto-merge with squash on %s", url)
+			github.AutoMergePR(state.VtOpRelease.Repo, nb, github.MergeMethodSquash)
+		}
+
 		pl.NewStepf("Waiting for %s to be merged", url)
 
-	outer:
-		for {
-			select {
-			case <-time.After(5 * time.Second):
-				if github.IsPRMerged(state.VtOpRelease.Repo, nb) {
-					break outer
-				}
-			}
+		err = releaser.WaitForPRToBeMerged(pl, state.VtOpRelease.Repo, nb, utils.WaitOptions{})
+		if errors.Is(err, context.Canceled) {
+			pl.SetTotalStep(pl.GetDone() + 1)
+			pl.NewStepf("Stopped waiting, run this step again once the Pull Request is merged")
+
+			return ""
+		}
+
+		if err != nil {
+			utils.BailOut(err, "failed to wait for %s to be merged", url)
 		}
 
 		pl.NewStepf("Pull Request has been merged")
 
+		// the vtop release is tagged on the squashed commit, whose version files were updated by the Pull Request
+		pl.NewStepf("Verify that %s was squashed", url)
+
+		if err := github.VerifyMergeMethod(state.VtOpRelease.Repo, nb, github.MergeMethodSquash); err != nil {
+			utils.BailOut(err, "the vtop Release Pull Request must be squash-merged")
+		}
+
 		state.Issue.VtopMergeReleasePR.Done = true
 		state.Issue.VtopMergeReleasePR.URL = url
 		pl.NewStepf("Update Issue %s on GitHub", state.IssueLink)
@@ -22,13 +22,12 @@ import (
 
 	"github.com/vitessio/vitess-releaser/go/releaser"
 	"github.com/vitessio/vitess-releaser/go/releaser/git"
-	"github.com/vitessio/vitess-releaser/go/releaser/github"
 	"github.com/vitessio/vitess-releaser/go/releaser/logging"
 )
 
 func VtopTagRelease(state *releaser.State) (*logging.ProgressLogging, func() string) {
 	pl := &logging.ProgressLogging{
-		TotalSteps: 6,
+		TotalSteps: 9,
 	}
 
 	return pl, func() string {
@@ -37,26 +36,32 @@ func VtopTagRelease(state *releaser.State) (*logging.ProgressLogging, func() str
 
 		// 1. Setup of the vtop codebase
 		pl.NewStepf("Fetch from git remote")
-		git.CorrectCleanRepo(state.VtOpRelease.Repo)
-		git.ResetHard(state.VtOpRelease.Remote, state.VtOpRelease.ReleaseBranch)
+		git.CorrectRepo(state.VtOpRelease.Repo)
+
+		// 2. Find the commit to tag and make sure it contains the right version
+		target := resolveTagTarget(pl, state.VtOpRelease, state.Issue.VtopCreateReleasePR.URL)
 
-		// 2. Tag the latest commit
 		releaseNameWithRC := releaser.AddRCToReleaseTitle(state.VtOpRelease.Release, state.Issue.RC)
 		lowerReleaseName := strings.ToLower(releaseNameWithRC)
+		checkVersionAtTarget(pl, target, lowerReleaseName, true)
+
+		// 3. Tag the release commit
 		gitTag := fmt.Sprintf("v%s", lowerReleaseName)
 		pl.NewStepf("Tag and push %s", gitTag)
-		git.TagAndPush(state.VtOpRelease.Remote, gitTag)
 
-		// 3. Create the release on the GitHub UI
+		git.TagAndPush(state.VtOpRelease.Remote, gitTag, target, fmt.Sprintf(
+			"Release of vitess-operator %s\n\nRelease notes: https://github.com/%s/releases/tag/%s",
+			gitTag, state.VtOpRelease.Repo, gitTag,
+		))
+
+		// 4. Verify the tag that was pushed
+		pl.NewStepf("Verify the pushed tag")
+		git.VerifyPushedTag(state.VtOpRelease.Remote, gitTag, target)
+
+		// 5. Create the release on the GitHub UI
 		pl.NewStepf("Create the release on the GitHub UI")
 
-		url := github.CreateRelease(
-			state.VtOpRelease.Repo,
-			gitTag,
-			"",
-			state.VtOpRelease.IsLatestRelease && state.Issue.RC == 0,
-			state.Issue.RC > 0,
-		)
+		url := publishGitHubRelease(pl, state, state.VtOpRelease, gitTag, "", nil)
 		pl.NewStepf("Done %s", url)
 
 		state.Issue.VtopTagRelease.Done = true
@@ -0,0 +1,67 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package releaser
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/vitessio/vitess-releaser/go/releaser/utils"
+)
+
+// ParseReleaseAssetsFlag expands the files, or glob patterns, of the release assets into absolute paths.
+// The paths must be resolved before the releaser moves into a worktree. Two assets cannot have the same
+// base name, as it is their name on the GitHub release.
+func ParseReleaseAssetsFlag(patterns []string) []string {
+	var assets []string
+
+	for _, pattern := range patterns {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" {
+			continue
+		}
+
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			utils.BailOut(err, "invalid release asset pattern %s", pattern)
+		}
+
+		if len(matches) == 0 {
+			utils.BailOut(nil, "no release asset matches %s", pattern)
+		}
+
+		for _, m := range matches {
+			abs, err := filepath.Abs(m)
+			if err != nil {
+				utils.BailOut(err, "failed to resolve the release asset %s", m)
+			}
+
+			if slices.Contains(assets, abs) {
+				continue
+			}
+
+			if slices.ContainsFunc(assets, func(a string) bool { return filepath.Base(a) == filepath.Base(abs) }) {
+				utils.BailOut(nil, "two release assets are named %s", filepath.Base(abs))
+			}
+
+			assets = append(assets, abs)
+		}
+	}
+
+	return assets
+}
@@ -0,0 +1,48 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package releaser
+
+import (
+	"strings"
+
+	"github.com/vitessio/vitess-releaser/go/releaser/utils"
+)
+
+// ParseReviewersFlag validates the reviewers of the Pull Requests created by the tool: GitHub users, or teams
+// with the format org/team. When running live without reviewers, the release team of the vitessio organization is used.
+func ParseReviewersFlag(values []string, live bool) []string {
+	var reviewers []string
+
+	for _, v := range values {
+		v = strings.TrimPrefix(strings.TrimSpace(v), "@")
+		if v == "" {
+			continue
+		}
+
+		if strings.Count(v, "/") > 1 || strings.HasPrefix(v, "/") || strings.HasSuffix(v, "/") {
+			utils.BailOut(nil, "invalid reviewer %s, expected a GitHub user or a team with the format org/team", v)
+		}
+
+		reviewers = append(reviewers, v)
+	}
+
+	if len(reviewers) == 0 && live {
+		reviewers = []string{vitessOrg + "/" + releaseTeam}
+	}
+
+	return reviewers
+}
@@ -20,9 +20,11 @@ import (
 	"context"
 	"fmt"
 	"path"
+	"path/filepath"
 	"strings"
 	"syscall"
 
+	"github.com/vitessio/vitess-releaser/go/releaser/git"
 	"github.com/vitessio/vitess-releaser/go/releaser/utils"
 )
 
@@ -42,8 +44,14 @@ func WrapState(ctx context.Context, s *State) context.Context {
 }
 
 type ReleaseInformation struct {
-	Repo   string
-	Remote string
+	Repo string
+
+	// Remote is the upstream remote, from which we fetch and to which we push the tags.
+	// PushRemote is the remote on which generated branches are pushed, it points to
+	// the fork of PushOwner, or is the same as Remote when not using a fork.
+	Remote     string
+	PushRemote string
+	PushOwner  string
 
 	// BaseReleaseBranch is used to refer to the root release branch (i.e. "release-20.0") when doing
 	// an RC release or a GA. In this situation the ReleaseBranch will be set to something like "release-20.0-rc".
@@ -62,48 +70,98 @@ type State struct {
 	VitessRelease ReleaseInformation
 	VtOpRelease   ReleaseInformation
 
+	// Project is the GitHub Projects (v2) board on which we mirror the release steps, if any.
+	Project ProjectInformation
+
+	// VerifyChecks lists the local checks run on each commit before pushing it, i.e. "build" or "vet".
+	VerifyChecks []string
+
+	// ReleaseAssets lists the absolute paths of the local files attached to the vitess GitHub release,
+	// such as checksums, signatures or SBOMs.
+	ReleaseAssets []string
+
+	// Verbose shows additional details, such as the fetch statistics, in the UI.
+	Verbose bool
+
+	// AutoMerge enables GitHub auto-merge on the release Pull Requests on the release day.
+	AutoMerge bool
+
+	// ProtectionSourceBranch is the branch whose protection rules are copied to the new release branch.
+	ProtectionSourceBranch string
+
 	Issue     Issue
 	IssueLink string
 	IssueNbGH int
 
-	currentPath string
+	// issueBody is the body of the release issue as it was last loaded or uploaded, the issue is not uploaded again while it does not change.
+	issueBody string
+
+	// rootPath is the directory containing the vitess and vitess-operator repositories.
+	rootPath string
 }
 
 func (s *State) GoToVitess() {
-	p := pathVitess
-	if s.currentPath != "" {
-		p = "../" + p
+	s.goTo(pathVitess)
+}
+
+func (s *State) GoToVtOp() {
+	s.goTo(pathVitessOperator)
+}
+
+// goTo changes the current directory to the given repository. The repositories are
+// resolved from the directory in which the releaser was started, this way we can go
+// from one repository to the other even when the current directory is a temporary worktree.
+func (s *State) goTo(repo string) {
+	if s.rootPath == "" {
+		cwd, err := syscall.Getwd()
+		if err != nil {
+			utils.BailOut(err, "failed to get current working directory")
+		}
+
+		s.rootPath = cwd
 	}
 
-	s.currentPath = pathVitess
+	p := path.Join(s.rootPath, repo)
 
-	changeDir(p)
+	err := syscall.Chdir(p)
+	if err != nil {
+		utils.BailOut(err, "failed to change directory to %s", p)
+	}
 }
 
-func (s *State) GoToVtOp() {
-	p := pathVitessOperator
-	if s.currentPath != "" {
-		p = "../" + p
+// UseGitCache creates or reuses partial clones of the repositories in dir, configured with the
+// same remotes as the clones found in the current directory. All git operations are then done
+// in the partial clones, leaving the clones of the user untouched.
+func (s *State) UseGitCache(dir string, vtop bool) {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		utils.BailOut(err, "failed to resolve the git cache directory %s", dir)
 	}
 
-	s.currentPath = pathVitessOperator
+	repos := []string{pathVitess}
+	if vtop {
+		repos = append(repos, pathVitessOperator)
+	}
 
-	changeDir(p)
-}
+	for _, repo := range repos {
+		s.goTo(repo)
+		git.EnsurePartialClone(path.Join(absDir, repo), git.ListRemotes())
+	}
 
-func (s *State) GetTag() string {
-	return fmt.Sprintf("v%s", strings.ToLower(s.VitessRelease.Release))
+	s.rootPath = absDir
+	s.GoToVitess()
 }
 
-func changeDir(p string) {
-	cwd, err := syscall.Getwd()
-	if err != nil {
-		utils.BailOut(err, "failed to get current working directory")
+// HeadRef returns the reference to use as the head of a Pull Request opened from the given branch:
+// "owner:branch" when the branch was pushed to a fork.
+func (ri ReleaseInformation) HeadRef(branch string) string {
+	if ri.PushOwner == "" {
+		return branch
 	}
 
-	p = path.Join(cwd, p)
-	err = syscall.Chdir(p)
-	if err != nil {
-		utils.BailOut(err, "failed to change directory to %s", p)
-	}
+	return ri.PushOwner + ":" + branch
+}
+
+func (s *State) GetTag() string {
+	return fmt.Sprintf("v%s", strings.ToLower(s.VitessRelease.Release))
 }
@@ -39,6 +39,7 @@ const (
 	CreateReleasePR           = "Create Release PR"
 	CreateMilestone           = "Create Milestone"
 	VtopUpdateGolang          = "Update Go version in vitess-operator"
+	CreateBlogPostPR          = "Create Blog Post PR"
 	UpdateCobraDocs           = "Update Cobra Docs"
 
 	// Release.
@@ -66,4 +67,5 @@ const (
 	Twitter                = "Twitter"
 	CloseIssue             = "Close Issue"
 	RemoveBypassProtection = "Remove Bypass Protection"
+	CleanupBranches        = "Cleanup Branches"
 )
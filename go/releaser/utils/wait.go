@@ -0,0 +1,93 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrWaitTimeout is returned by WaitFor when the condition was not met before the timeout.
+var ErrWaitTimeout = errors.New("timed out waiting for the condition")
+
+// WaitOptions configures WaitFor. The zero value polls every 5 seconds, backs off up to
+// one minute between polls, and never times out.
+type WaitOptions struct {
+	// Interval is the delay between the first two polls, multiplied by Backoff after every poll,
+	// up to MaxInterval.
+	Interval    time.Duration
+	MaxInterval time.Duration
+	Backoff     float64
+
+	// Timeout is how long to wait for the condition, no timeout if zero.
+	Timeout time.Duration
+}
+
+const (
+	defaultWaitInterval    = 5 * time.Second
+	defaultWaitMaxInterval = time.Minute
+	defaultWaitBackoff     = 1.5
+)
+
+func (o WaitOptions) withDefaults() WaitOptions {
+	if o.Interval <= 0 {
+		o.Interval = defaultWaitInterval
+	}
+
+	if o.MaxInterval < o.Interval {
+		o.MaxInterval = max(defaultWaitMaxInterval, o.Interval)
+	}
+
+	if o.Backoff < 1 {
+		o.Backoff = defaultWaitBackoff
+	}
+
+	return o
+}
+
+// WaitFor polls cond until it returns true or an error. It returns ctx.Err() if ctx is cancelled,
+// and ErrWaitTimeout if the timeout expires first.
+func WaitFor(ctx context.Context, opts WaitOptions, cond func() (bool, error)) error {
+	opts = opts.withDefaults()
+
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeoutCause(ctx, opts.Timeout, ErrWaitTimeout)
+		defer cancel()
+	}
+
+	interval := opts.Interval
+
+	for {
+		ok, err := cond()
+		if err != nil || ok {
+			return err
+		}
+
+		timer := time.NewTimer(interval)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return context.Cause(ctx)
+		case <-timer.C:
+		}
+
+		interval = min(time.Duration(float64(interval)*opts.Backoff), opts.MaxInterval)
+	}
+}
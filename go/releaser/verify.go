@@ -0,0 +1,195 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package releaser
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/vitessio/vitess-releaser/go/releaser/git"
+	"github.com/vitessio/vitess-releaser/go/releaser/logging"
+	"github.com/vitessio/vitess-releaser/go/releaser/utils"
+)
+
+const (
+	VerifyBuild = "build"
+	VerifyVet   = "vet"
+	VerifyYAML  = "yaml"
+	VerifyMaven = "maven"
+
+	// maxVerifyOutputLines is the number of lines of the output of a failed check shown to the user.
+	maxVerifyOutputLines = 20
+)
+
+var verifyChecks = []string{VerifyBuild, VerifyVet, VerifyYAML, VerifyMaven}
+
+// ParseVerifyFlag validates the list of local checks to run before pushing a commit.
+func ParseVerifyFlag(values []string) []string {
+	var checks []string
+
+	for _, v := range values {
+		v = strings.ToLower(strings.TrimSpace(v))
+		if v == "" {
+			continue
+		}
+
+		if !slices.Contains(verifyChecks, v) {
+			utils.BailOut(nil, "unknown verification check '%s', expected one of: %s", v, strings.Join(verifyChecks, ", "))
+		}
+
+		checks = append(checks, v)
+	}
+
+	return checks
+}
+
+// VerifyAndPush runs the local verification checks on the last commit, and pushes the branch
+// only if they all pass. When a check fails, its output is shown in the progress logging and
+// the total number of steps is adjusted, assuming the caller only has to update the issue
+// (two steps) before returning.
+func (s *State) VerifyAndPush(pl *logging.ProgressLogging, remote, branch string) bool {
+	if len(s.VerifyChecks) > 0 {
+		pl.SetTotalStep(pl.GetTotal() + 1)
+
+		changed := git.ChangedFilesInLastCommit()
+
+		for _, check := range s.VerifyChecks {
+			out, err := runVerifyCheck(check, changed)
+			if err != nil {
+				pl.NewStepf("Check '%s' failed, %s was not pushed: %v\n%s", check, branch, err, lastLines(out, maxVerifyOutputLines))
+				pl.SetTotalStep(pl.GetDone() + 2)
+
+				return false
+			}
+		}
+
+		pl.NewStepf("Local checks passed: %s", strings.Join(s.VerifyChecks, ", "))
+	}
+
+	git.Push(remote, branch)
+
+	return true
+}
+
+func runVerifyCheck(check string, changed []string) (string, error) {
+	switch check {
+	case VerifyBuild:
+		if _, err := os.Stat("go.mod"); err != nil {
+			return "", nil
+		}
+
+		return execCheck("", "go", "build", "./...")
+	case VerifyVet:
+		var pkgs []string
+
+		for _, f := range changed {
+			if !strings.HasSuffix(f, ".go") {
+				continue
+			}
+
+			pkg := "./" + path.Dir(f)
+			if !slices.Contains(pkgs, pkg) {
+				pkgs = append(pkgs, pkg)
+			}
+		}
+
+		if len(pkgs) == 0 {
+			return "", nil
+		}
+
+		return execCheck("", "go", append([]string{"vet"}, pkgs...)...)
+	case VerifyYAML:
+		for _, f := range changed {
+			if ext := filepath.Ext(f); ext != ".yaml" && ext != ".yml" {
+				continue
+			}
+
+			if err := parseYAMLFile(f); err != nil {
+				return "", err
+			}
+		}
+
+		return "", nil
+	case VerifyMaven:
+		if !slices.ContainsFunc(changed, func(f string) bool { return strings.HasPrefix(f, "java/") }) {
+			return "", nil
+		}
+
+		return execCheck("java", "mvn", "-o", "validate")
+	}
+
+	return "", fmt.Errorf("unknown check %s", check)
+}
+
+func execCheck(dir, name string, args ...string) (string, error) {
+	cmd := exec.Command(name, args...)
+	cmd.Dir = dir
+
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return string(out), fmt.Errorf("%w: failed to execute: %s", err, cmd.String())
+	}
+
+	return string(out), nil
+}
+
+func parseYAMLFile(file string) error {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		// the file was deleted by the commit
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+
+		return err
+	}
+
+	// A file can contain several YAML documents, all of them must be valid
+	dec := yaml.NewDecoder(bytes.NewReader(content))
+
+	for {
+		var doc any
+
+		err := dec.Decode(&doc)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return fmt.Errorf("%s: %w", file, err)
+		}
+	}
+}
+
+func lastLines(s string, n int) string {
+	lines := strings.Split(strings.TrimSpace(s), "\n")
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package releaser
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestParseVerifyFlag(t *testing.T) {
+	tcs := []struct {
+		values []string
+		want   []string
+	}{
+		{values: nil, want: nil},
+		{values: []string{""}, want: nil},
+		{values: []string{"build", "vet"}, want: []string{VerifyBuild, VerifyVet}},
+		{values: []string{" YAML ", "", "Maven"}, want: []string{VerifyYAML, VerifyMaven}},
+	}
+
+	for _, tc := range tcs {
+		t.Run(strings.Join(tc.values, ","), func(t *testing.T) {
+			if got := ParseVerifyFlag(tc.values); !slices.Equal(got, tc.want) {
+				t.Fatalf("expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestParseVerifyFlagUnknownCheck(t *testing.T) {
+	// ParseVerifyFlag bails out, which exits the process: run it in a child process
+	if os.Getenv("VERIFY_UNKNOWN_CHECK") == "1" {
+		ParseVerifyFlag([]string{"build", "lint"})
+		return
+	}
+
+	cmd := exec.Command(os.Args[0], "-test.run=^TestParseVerifyFlagUnknownCheck$")
+	cmd.Env = append(os.Environ(), "VERIFY_UNKNOWN_CHECK=1")
+
+	out, err := cmd.CombinedOutput()
+	if err == nil {
+		t.Fatalf("expected the process to exit with an error, got: %s", out)
+	}
+
+	if !strings.Contains(string(out), "unknown verification check 'lint'") {
+		t.Fatalf("expected the unknown check to be reported, got: %s", out)
+	}
+}
+
+func TestParseYAMLFile(t *testing.T) {
+	dir := t.TempDir()
+
+	tcs := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{name: "valid.yml", content: "name: release\non:\n  push:\n    branches: [main]\n"},
+		{name: "documents.yaml", content: "kind: A\n---\nkind: B\n"},
+		{name: "empty.yaml", content: ""},
+		{name: "invalid.yaml", content: "name: release\n  on: push\n", wantErr: "invalid.yaml"},
+		{name: "invalid-document.yaml", content: "kind: A\n---\nkind: [B\n", wantErr: "invalid-document.yaml"},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			file := filepath.Join(dir, tc.name)
+			if err := os.WriteFile(file, []byte(tc.content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			err := parseYAMLFile(file)
+			if tc.wantErr == "" && err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			if tc.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tc.wantErr)) {
+				t.Fatalf("expected an error mentioning %q, got %v", tc.wantErr, err)
+			}
+		})
+	}
+
+	// A file deleted by the commit has nothing to check
+	if err := parseYAMLFile(filepath.Join(dir, "deleted.yaml")); err != nil {
+		t.Fatalf("expected no error for a deleted file, got %v", err)
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"os"
 	"os/exec"
 	"path"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
@@ -63,9 +64,10 @@ const versionName = "%s"
 // First, it tries to figure out if the major release we want to use is on main, if
 // it is, it returns the SNAPSHOT version of the main branch.
 //
-// Secondly, if the release we want to use is not on the main branch, it checks out
-// to a release branch matching the given major release number. The SNAPSHOT version
-// on that release branch is then returned.
+// Secondly, if the release we want to use is not on the main branch, it reads the
+// SNAPSHOT version of the release branch matching the given major release number.
+//
+// The version files are read from the remote branches, the local checkout is left untouched.
 func FindNextRelease(remote, majorRelease string, isVtOp bool, rc int) (currentRelease, releaseBranchName string, isLatestRelease, isFromMain, ga bool) {
 	fnGetCurrentRelease := getCurrentReleaseVitess
 	fnReleaseToMajor := releaseToMajorVitess
@@ -77,10 +79,9 @@ func FindNextRelease(remote, majorRelease string, isVtOp bool, rc int) (currentR
 		releaseBranchName = fmt.Sprintf("release-%s", majorRelease)
 	}
 
-	git.Checkout("main")
-	git.ResetHard(remote, "main")
+	git.FetchBranches(remote, "main")
 
-	currentRelease = fnGetCurrentRelease()
+	currentRelease = fnGetCurrentRelease(remote, "main")
 	mainMajor := fnReleaseToMajor(currentRelease)
 
 	if isVtOp {
@@ -107,10 +108,9 @@ func FindNextRelease(remote, majorRelease string, isVtOp bool, rc int) (currentR
 	}
 
 	// main branch does not match, let's try release branches
-	git.Checkout(releaseBranchName)
-	git.ResetHard(remote, releaseBranchName)
+	git.FetchBranches(remote, releaseBranchName)
 
-	currentRelease = fnGetCurrentRelease()
+	currentRelease = fnGetCurrentRelease(remote, releaseBranchName)
 	major := fnReleaseToMajor(currentRelease)
 
 	// if the current release and the wanted release are different, it means there is an
@@ -153,10 +153,9 @@ func FindPreviousRelease(remote, currentMajor string) string {
 
 	previousMajor := majorNb - 1
 	previousReleaseBranch := fmt.Sprintf("release-%d.0", previousMajor)
-	git.Checkout(previousReleaseBranch)
-	git.ResetHard(remote, previousReleaseBranch)
+	git.FetchBranches(remote, previousReleaseBranch)
 
-	currentRelease := getCurrentReleaseVitess()
+	currentRelease := getCurrentReleaseVitess(remote, previousReleaseBranch)
 	currentReleaseSlice := strings.Split(currentRelease, ".")
 
 	if len(currentReleaseSlice) != 3 {
@@ -180,18 +179,39 @@ func FindNextMajorRelease(currentMajor string) string {
 	return fmt.Sprintf("%d.0.0", majorNb+1)
 }
 
-func getCurrentReleaseVitess() string {
-	// Execute the following command to find the version from the `version.go` file:
-	// sed -n 's/.*versionName.*\"\([[:digit:]\.]*\).*\"/\1/p' ./go/vt/servenv/version.go
-	out := utils.Exec("sed", "-n", "s/.*versionName.*\"\\([[:digit:]\\.]*\\).*\"/\\1/p", "./go/vt/servenv/version.go")
-	return strings.ReplaceAll(out, "\n", "")
+var (
+	versionNameRegexVitess = regexp.MustCompile(`versionName.*"([0-9.]*).*"`)
+	versionNameRegexVtOp   = regexp.MustCompile(`Version =.*"([0-9.]*).*"`)
+
+	fullVersionRegexVitess = regexp.MustCompile(`versionName = "([^"]*)"`)
+	fullVersionRegexVtOp   = regexp.MustCompile(`Version = "([^"]*)"`)
+)
+
+// VersionAtRef returns the version found in the version.go file at the given git ref,
+// including its suffix, i.e. "21.0.0-rc1" or "21.0.1-SNAPSHOT".
+func VersionAtRef(ref string, isVtOp bool) string {
+	if isVtOp {
+		return findVersion(git.ShowFileAtRef(ref, "./version/version.go"), fullVersionRegexVtOp)
+	}
+
+	return findVersion(git.ShowFileAtRef(ref, versionGoFile), fullVersionRegexVitess)
+}
+
+func getCurrentReleaseVitess(remote, branch string) string {
+	return findVersion(git.ShowFile(remote, branch, versionGoFile), versionNameRegexVitess)
 }
 
-func getCurrentReleaseVtOp() string {
-	// Execute the following command to find the version from the `version.go` file:
-	// sed -n 's/.*Version.*\"\([[:digit:]\.]*\).*\"/\1/p' ./version/version.go
-	out := utils.Exec("sed", "-n", "s/.*Version =.*\"\\([[:digit:]\\.]*\\).*\"/\\1/p", "./version/version.go")
-	return strings.ReplaceAll(out, "\n", "")
+func getCurrentReleaseVtOp(remote, branch string) string {
+	return findVersion(git.ShowFile(remote, branch, "./version/version.go"), versionNameRegexVtOp)
+}
+
+func findVersion(content string, re *regexp.Regexp) string {
+	match := re.FindStringSubmatch(content)
+	if len(match) != 2 {
+		return ""
+	}
+
+	return match[1]
 }
 
 func releaseToMajorVitess(release string) string {
@@ -207,6 +227,9 @@ func releaseToMajorVtOp(release string) string {
 	return fmt.Sprintf("%s.%s", parts[0], parts[1])
 }
 
+// VersionFiles are the files modified by UpdateVersionGoFile and UpdateJavaDir.
+var VersionFiles = []string{path.Clean(versionGoFile), "java/**/pom.xml"}
+
 func UpdateVersionGoFile(newVersion string) {
 	err := os.WriteFile(versionGoFile, []byte(fmt.Sprintf(versionGo, time.Now().Year(), newVersion)), os.ModePerm)
 	if err != nil {
@@ -217,7 +240,7 @@ func UpdateVersionGoFile(newVersion string) {
 func UpdateJavaDir(newVersion string) {
 	//  cd $ROOT/java || exit 1
 	//  mvn versions:set -DnewVersion=$1
-	cmd := exec.Command("mvn", "versions:set", fmt.Sprintf("-DnewVersion=%s", newVersion))
+	cmd := exec.Command("mvn", "versions:set", fmt.Sprintf("-DnewVersion=%s", newVersion), "-DgenerateBackupPoms=false")
 
 	pwd, err := os.Getwd()
 	if err != nil {
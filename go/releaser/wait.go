@@ -0,0 +1,63 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package releaser
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/vitessio/vitess-releaser/go/releaser/git"
+	"github.com/vitessio/vitess-releaser/go/releaser/github"
+	"github.com/vitessio/vitess-releaser/go/releaser/logging"
+	"github.com/vitessio/vitess-releaser/go/releaser/utils"
+)
+
+// WaitForPRToBeMerged polls the Pull Request until it is merged, showing its review and checks
+// state in the progress dialog. The wait can be cancelled from the TUI, in which case the
+// context error is returned and the step must leave its state untouched, so it can be resumed.
+// Errors reading the status are retried, unless the Pull Request is not found or not accessible.
+// Once merged, the fetched refs are invalidated as the base branch moved.
+func WaitForPRToBeMerged(pl *logging.ProgressLogging, repo string, nb int, opts utils.WaitOptions) error {
+	defer pl.SetWaiting(false, "")
+
+	pl.SetWaiting(true, fmt.Sprintf("Pull Request #%d: waiting for the first status", nb))
+
+	return utils.WaitFor(pl.Context(), opts, func() (bool, error) {
+		status, err := github.PRStatusOf(repo, nb)
+		if errors.Is(err, github.ErrNotFound) || errors.Is(err, github.ErrUnauthorized) {
+			return false, fmt.Errorf("failed to get the status of pull request #%d: %w", nb, err)
+		}
+
+		// other errors, i.e. a GitHub outage or the rate limit, are only shown until the next poll
+		if err != nil {
+			pl.SetWaiting(true, fmt.Sprintf("Pull Request #%d: failed to get the status, retrying: %v", nb, err))
+			return false, nil
+		}
+
+		pl.SetWaiting(true, fmt.Sprintf("Pull Request #%d: %s", nb, status))
+
+		switch status.State {
+		case "MERGED":
+			git.InvalidateAllFetches()
+			return true, nil
+		case "CLOSED":
+			return false, fmt.Errorf("pull request #%d was closed without being merged", nb)
+		}
+
+		return false, nil
+	})
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2024 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package releaser
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/vitessio/vitess-releaser/go/releaser/github"
+	"github.com/vitessio/vitess-releaser/go/releaser/github/githubtest"
+	"github.com/vitessio/vitess-releaser/go/releaser/logging"
+	"github.com/vitessio/vitess-releaser/go/releaser/utils"
+)
+
+const testRepo = "vitessio/vitess"
+
+// newServer starts a fake GitHub API and makes the functions of the github package use it.
+func newServer(t *testing.T) *githubtest.Server {
+	t.Helper()
+
+	s := githubtest.NewServer()
+	github.SetClient(s.Client())
+
+	t.Cleanup(func() {
+		github.SetClient(nil)
+		s.Close()
+	})
+
+	return s
+}
+
+func TestWaitForPRToBeMerged(t *testing.T) {
+	opts := utils.WaitOptions{Interval: time.Millisecond, Timeout: 5 * time.Second}
+
+	t.Run("transient errors", func(t *testing.T) {
+		s := newServer(t)
+
+		nb := s.AddPR(testRepo, github.PR{Title: "Release of v21.0.0", Branch: "release-21.0-create-release-1", Base: "release-21.0"})
+		s.MergePR(testRepo, nb, "abc")
+
+		// the first poll gives up after all its attempts, the second one is rate limited
+		s.Fail(githubtest.Failure{Method: http.MethodPost, Path: "/graphql", Status: http.StatusBadGateway, Count: githubtest.RetryPolicy.MaxAttempts})
+		s.Fail(githubtest.Failure{Method: http.MethodPost, Path: "/graphql", Status: http.StatusForbidden, Message: "API rate limit exceeded", Header: http.Header{"Retry-After": {"3600"}}})
+
+		if err := WaitForPRToBeMerged(&logging.ProgressLogging{}, testRepo, nb, opts); err != nil {
+			t.Fatalf("expected the transient errors to be retried, got %v", err)
+		}
+
+		if n := s.Requests(http.MethodPost, "/graphql"); n != githubtest.RetryPolicy.MaxAttempts+2 {
+			t.Fatalf("expected %d requests, got %d", githubtest.RetryPolicy.MaxAttempts+2, n)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		newServer(t)
+
+		err := WaitForPRToBeMerged(&logging.ProgressLogging{}, testRepo, 42, opts)
+		if !errors.Is(err, github.ErrNotFound) {
+			t.Fatalf("expected ErrNotFound, got %v", err)
+		}
+	})
+
+	t.Run("unauthorized", func(t *testing.T) {
+		s := newServer(t)
+
+		nb := s.AddPR(testRepo, github.PR{Title: "Release of v21.0.0", Branch: "release-21.0-create-release-1", Base: "release-21.0"})
+		s.Fail(githubtest.Failure{Method: http.MethodPost, Path: "/graphql", Status: http.StatusUnauthorized, Message: "Bad credentials"})
+
+		err := WaitForPRToBeMerged(&logging.ProgressLogging{}, testRepo, nb, opts)
+		if !errors.Is(err, github.ErrUnauthorized) {
+			t.Fatalf("expected ErrUnauthorized, got %v", err)
+		}
+	})
+}